@@ -0,0 +1,492 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"golang.org/x/net/context"
+
+	pb "github.com/brotherlogic/discogssyncer/server"
+	pbd "github.com/brotherlogic/godiscogs"
+)
+
+// backupItems splits the collection into single item collections for NDJSON
+func (syncer *Syncer) backupItems() []*pb.RecordCollection {
+	c := syncer.collection
+	var items []*pb.RecordCollection
+
+	for _, f := range c.Folders {
+		items = append(items, &pb.RecordCollection{Folders: []*pb.CollectionFolder{&pb.CollectionFolder{Folder: f.Folder}}})
+		for _, r := range f.GetReleases().GetReleases() {
+			folder := &pb.CollectionFolder{Folder: f.Folder, Releases: &pb.ReleaseList{Releases: []*pbd.Release{r}}}
+			items = append(items, &pb.RecordCollection{Folders: []*pb.CollectionFolder{folder}})
+		}
+	}
+	for _, m := range c.Metadata {
+		items = append(items, &pb.RecordCollection{Metadata: []*pb.ReleaseMetadata{m}})
+	}
+	for _, w := range c.GetWantlist().GetWant() {
+		items = append(items, &pb.RecordCollection{Wantlist: &pb.Wantlist{Want: []*pb.Want{w}}})
+	}
+	for _, r := range c.GetCache().GetReleases() {
+		items = append(items, &pb.RecordCollection{Cache: &pb.ReleaseList{Releases: []*pbd.Release{r}}})
+	}
+	for _, f := range c.GetSchema().GetFields() {
+		items = append(items, &pb.RecordCollection{Schema: &pb.FieldSchema{Fields: []*pb.FieldDefinition{f}}})
+	}
+	for _, p := range c.Plays {
+		items = append(items, &pb.RecordCollection{Plays: []*pb.Play{p}})
+	}
+	for _, s := range c.Suggestions {
+		items = append(items, &pb.RecordCollection{Suggestions: []*pb.Suggestion{s}})
+	}
+	for _, s := range c.SmartFolders {
+		items = append(items, &pb.RecordCollection{SmartFolders: []*pb.SmartFolder{s}})
+	}
+	for _, r := range c.FolderRules {
+		items = append(items, &pb.RecordCollection{FolderRules: []*pb.FolderRule{r}})
+	}
+	for _, a := range c.RuleAudit {
+		items = append(items, &pb.RecordCollection{RuleAudit: []*pb.RuleAudit{a}})
+	}
+	for _, l := range c.Loans {
+		items = append(items, &pb.RecordCollection{Loans: []*pb.Loan{l}})
+	}
+
+	return items
+}
+
+// backupLines hands the collection to send in the given format, every line ending in a newline
+func (syncer *Syncer) backupLines(format pb.BackupFormat, send func(line string) error) error {
+	if format == pb.BackupFormat_JSON {
+		m := &jsonpb.Marshaler{Indent: "  "}
+		data, err := m.MarshalToString(syncer.collection)
+		if err != nil {
+			return err
+		}
+		return send(data + "\n")
+	}
+
+	m := &jsonpb.Marshaler{}
+	for _, item := range syncer.backupItems() {
+		data, err := m.MarshalToString(item)
+		if err == nil {
+			err = send(data + "\n")
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// combineBackup folds a single NDJSON item into the collection we're building
+func combineBackup(dst, item *pb.RecordCollection) {
+	for _, f := range item.Folders {
+		var folder *pb.CollectionFolder
+		for _, existing := range dst.Folders {
+			if existing.GetFolder().GetId() == f.GetFolder().GetId() {
+				folder = existing
+			}
+		}
+		if folder == nil {
+			folder = &pb.CollectionFolder{Folder: f.Folder, Releases: &pb.ReleaseList{}}
+			dst.Folders = append(dst.Folders, folder)
+		}
+		if folder.GetFolder().GetName() == "" {
+			folder.Folder = f.Folder
+		}
+		folder.Releases.Releases = append(folder.Releases.Releases, f.GetReleases().GetReleases()...)
+	}
+
+	dst.Metadata = append(dst.Metadata, item.Metadata...)
+	if item.Wantlist != nil {
+		dst.Wantlist.Want = append(dst.Wantlist.Want, item.Wantlist.Want...)
+	}
+	if item.Cache != nil {
+		dst.Cache.Releases = append(dst.Cache.Releases, item.Cache.Releases...)
+	}
+	if item.Schema != nil {
+		dst.Schema.Fields = append(dst.Schema.Fields, item.Schema.Fields...)
+	}
+	dst.Plays = append(dst.Plays, item.Plays...)
+	dst.Suggestions = append(dst.Suggestions, item.Suggestions...)
+	dst.SmartFolders = append(dst.SmartFolders, item.SmartFolders...)
+	dst.FolderRules = append(dst.FolderRules, item.FolderRules...)
+	dst.RuleAudit = append(dst.RuleAudit, item.RuleAudit...)
+	dst.Loans = append(dst.Loans, item.Loans...)
+}
+
+func parseBackup(data string, format pb.BackupFormat) (*pb.RecordCollection, error) {
+	if format == pb.BackupFormat_JSON {
+		collection := &pb.RecordCollection{}
+		if err := jsonpb.UnmarshalString(data, collection); err != nil {
+			return nil, err
+		}
+		return collection, nil
+	}
+
+	collection := &pb.RecordCollection{Wantlist: &pb.Wantlist{}, Cache: &pb.ReleaseList{}, Schema: &pb.FieldSchema{}}
+	scanner := bufio.NewScanner(strings.NewReader(data))
+	scanner.Buffer(nil, 64*1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+		item := &pb.RecordCollection{}
+		if err := jsonpb.UnmarshalString(scanner.Text(), item); err != nil {
+			return nil, fmt.Errorf("Line %v: %v", line, err)
+		}
+		combineBackup(collection, item)
+	}
+	return collection, scanner.Err()
+}
+
+func releaseKey(r *pbd.Release) string {
+	return fmt.Sprintf("%v/%v", r.Id, r.InstanceId)
+}
+
+// wantKey names a want by its release, or by its master for a master want
+func wantKey(w *pb.Want) string {
+	if w.ReleaseId != 0 {
+		return itoa(w.ReleaseId)
+	}
+	return "master " + itoa(w.MasterId)
+}
+
+// validateBackup lists everything in a backup that we can't restore
+func validateBackup(c *pb.RecordCollection) []string {
+	var problems []string
+	seen := make(map[string]bool)
+	check := func(kind, key string, bad bool) {
+		if bad {
+			problems = append(problems, fmt.Sprintf("Bad %v %v", kind, key))
+		} else if seen[kind+" "+key] {
+			problems = append(problems, fmt.Sprintf("Duplicate %v %v", kind, key))
+		}
+		seen[kind+" "+key] = true
+	}
+
+	for _, f := range c.Folders {
+		if f.Folder == nil {
+			problems = append(problems, "Folder with no details")
+			continue
+		}
+		check("folder", itoa(f.Folder.Id), f.Folder.Id < 0)
+		for _, r := range f.GetReleases().GetReleases() {
+			check("release", releaseKey(r), r.Id <= 0 || (r.FolderId != 0 && r.FolderId != f.Folder.Id))
+		}
+	}
+	for _, m := range c.Metadata {
+		check("metadata", itoa(m.Id), m.Id <= 0)
+	}
+	for _, w := range c.GetWantlist().GetWant() {
+		check("want", wantKey(w), w.ReleaseId < 0 || w.MasterId < 0 || (w.ReleaseId == 0 && w.MasterId == 0))
+	}
+	for _, r := range c.GetCache().GetReleases() {
+		check("cached release", itoa(r.Id), r.Id <= 0)
+	}
+	for _, f := range c.GetSchema().GetFields() {
+		check("field", f.Name, f.Name == "")
+	}
+	for _, s := range c.SmartFolders {
+		check("smart folder", itoa(s.GetFolder().GetId()), s.GetFolder().GetId() >= 0)
+	}
+	for _, r := range c.FolderRules {
+		check("folder rule", r.Name, r.Name == "")
+	}
+
+	return problems
+}
+
+// isBlank is reflect.Value.IsZero for the kinds of field protos generate
+func isBlank(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map:
+		return v.Len() == 0
+	case reflect.Ptr, reflect.Interface:
+		return v.IsNil()
+	}
+	return reflect.DeepEqual(v.Interface(), reflect.Zero(v.Type()).Interface())
+}
+
+// fillBlanks copies across the top level fields of src which are unset in dst
+func fillBlanks(dst, src proto.Message) {
+	d := reflect.ValueOf(dst).Elem()
+	s := reflect.ValueOf(src).Elem()
+	for i := 0; i < d.NumField(); i++ {
+		if d.Field(i).CanSet() && !strings.HasPrefix(d.Type().Field(i).Name, "XXX_") && isBlank(d.Field(i)) {
+			d.Field(i).Set(s.Field(i))
+		}
+	}
+}
+
+// restorer applies a backup to the collection under a conflict policy
+type restorer struct {
+	policy pb.ConflictPolicy
+	dryRun bool
+	report *pb.RestoreReport
+}
+
+// restore works out what to do with one item, calling set with the new value unless this is a dry run
+func (r *restorer) restore(kind, key string, local, backup proto.Message, set func(proto.Message)) {
+	var result proto.Message
+	action := ""
+	switch {
+	case reflect.ValueOf(local).IsNil():
+		result, action = proto.Clone(backup), "added"
+	case proto.Equal(local, backup):
+		return
+	case r.policy == pb.ConflictPolicy_OVERWRITE:
+		result, action = proto.Clone(backup), "overwritten"
+	case r.policy == pb.ConflictPolicy_MERGE:
+		result = proto.Clone(local)
+		fillBlanks(result, backup)
+		action = "merged"
+		if proto.Equal(result, local) {
+			result, action = nil, "skipped"
+		}
+	default:
+		action = "skipped"
+	}
+
+	r.report.Changes = append(r.report.Changes, &pb.RestoreChange{Kind: kind, Key: key, Action: action})
+	if result != nil && !r.dryRun {
+		set(result)
+	}
+}
+
+// record adds a log entry, such as a play, unless we already have it
+func (r *restorer) record(kind, key string, have bool, add func()) {
+	if have {
+		return
+	}
+	r.report.Changes = append(r.report.Changes, &pb.RestoreChange{Kind: kind, Key: key, Action: "added"})
+	if !r.dryRun {
+		add()
+	}
+}
+
+// locateInstance finds the folder and position of a copy of a release
+func (syncer *Syncer) locateInstance(id, instance int32) (*pb.CollectionFolder, int) {
+	for _, f := range syncer.collection.Folders {
+		for i, r := range f.GetReleases().GetReleases() {
+			if r.Id == id && r.InstanceId == instance {
+				return f, i
+			}
+		}
+	}
+	return nil, -1
+}
+
+// placeInstance puts a copy of a release into its folder, replacing the copy we had
+func (syncer *Syncer) placeInstance(rel *pbd.Release) {
+	if f, i := syncer.locateInstance(rel.Id, rel.InstanceId); f != nil {
+		if f.Folder.Id == rel.FolderId {
+			f.Releases.Releases[i] = rel
+			return
+		}
+		f.Releases.Releases = append(f.Releases.Releases[:i], f.Releases.Releases[i+1:]...)
+	}
+
+	folder := syncer.findFolder(rel.FolderId)
+	if folder == nil {
+		folder = &pb.CollectionFolder{Folder: &pbd.Folder{Id: rel.FolderId}}
+		syncer.collection.Folders = append(syncer.collection.Folders, folder)
+	}
+	if folder.Releases == nil {
+		folder.Releases = &pb.ReleaseList{}
+	}
+	folder.Releases.Releases = append(folder.Releases.Releases, rel)
+}
+
+func (syncer *Syncer) restoreBackup(backup *pb.RecordCollection, r *restorer) {
+	c := syncer.collection
+	if c.Wantlist == nil {
+		c.Wantlist = &pb.Wantlist{}
+	}
+
+	// The schema goes first so restored custom fields have their definitions
+	for _, f := range backup.GetSchema().GetFields() {
+		r.restore("field", f.Name, syncer.findField(f.Name), f, func(m proto.Message) {
+			if local := syncer.findField(f.Name); local != nil {
+				*local = *m.(*pb.FieldDefinition)
+			} else {
+				syncer.getSchema().Fields = append(syncer.getSchema().Fields, m.(*pb.FieldDefinition))
+			}
+		})
+	}
+
+	for _, f := range backup.Folders {
+		var local *pbd.Folder
+		if existing := syncer.findFolder(f.Folder.Id); existing != nil {
+			local = existing.Folder
+		}
+		r.restore("folder", itoa(f.Folder.Id), local, f.Folder, func(m proto.Message) {
+			if existing := syncer.findFolder(f.Folder.Id); existing != nil {
+				existing.Folder = m.(*pbd.Folder)
+			} else {
+				c.Folders = append(c.Folders, &pb.CollectionFolder{Folder: m.(*pbd.Folder), Releases: &pb.ReleaseList{}})
+			}
+		})
+	}
+
+	for _, f := range backup.Folders {
+		for _, rel := range f.GetReleases().GetReleases() {
+			rel.FolderId = f.Folder.Id
+			var local *pbd.Release
+			if folder, i := syncer.locateInstance(rel.Id, rel.InstanceId); folder != nil {
+				local = folder.Releases.Releases[i]
+			}
+			r.restore("release", releaseKey(rel), local, rel, func(m proto.Message) {
+				syncer.placeInstance(m.(*pbd.Release))
+			})
+		}
+	}
+
+	for _, md := range backup.Metadata {
+		r.restore("metadata", itoa(md.Id), syncer.findMetadata(md.Id), md, func(m proto.Message) {
+			for i, existing := range c.Metadata {
+				if existing.Id == md.Id {
+					c.Metadata[i] = m.(*pb.ReleaseMetadata)
+					return
+				}
+			}
+			c.Metadata = append(c.Metadata, m.(*pb.ReleaseMetadata))
+		})
+	}
+
+	for _, w := range backup.GetWantlist().GetWant() {
+		var local *pb.Want
+		for _, existing := range c.Wantlist.Want {
+			if sameWant(existing, w) {
+				local = existing
+			}
+		}
+		r.restore("want", wantKey(w), local, w, func(m proto.Message) {
+			for i, existing := range c.Wantlist.Want {
+				if sameWant(existing, w) {
+					c.Wantlist.Want[i] = m.(*pb.Want)
+					return
+				}
+			}
+			c.Wantlist.Want = append(c.Wantlist.Want, m.(*pb.Want))
+		})
+	}
+
+	for _, rel := range backup.GetCache().GetReleases() {
+		var local *pbd.Release
+		for _, existing := range c.GetCache().GetReleases() {
+			if existing.Id == rel.Id {
+				local = existing
+			}
+		}
+		r.restore("cached release", itoa(rel.Id), local, rel, func(m proto.Message) {
+			syncer.cacheRelease(m.(*pbd.Release))
+		})
+	}
+
+	for _, s := range backup.SmartFolders {
+		r.restore("smart folder", itoa(s.Folder.Id), syncer.findSmartFolder(&pbd.Folder{Id: s.Folder.Id}), s, func(m proto.Message) {
+			for i, existing := range c.SmartFolders {
+				if existing.Folder.Id == s.Folder.Id {
+					c.SmartFolders[i] = m.(*pb.SmartFolder)
+					return
+				}
+			}
+			c.SmartFolders = append(c.SmartFolders, m.(*pb.SmartFolder))
+		})
+	}
+
+	for _, rule := range backup.FolderRules {
+		var local *pb.FolderRule
+		for _, existing := range c.FolderRules {
+			if existing.Name == rule.Name {
+				local = existing
+			}
+		}
+		r.restore("folder rule", rule.Name, local, rule, func(m proto.Message) {
+			for i, existing := range c.FolderRules {
+				if existing.Name == rule.Name {
+					c.FolderRules[i] = m.(*pb.FolderRule)
+					return
+				}
+			}
+			c.FolderRules = append(c.FolderRules, m.(*pb.FolderRule))
+		})
+	}
+
+	// Logs are only ever added to, so the policy doesn't apply
+	for _, p := range backup.Plays {
+		have := false
+		for _, existing := range c.Plays {
+			have = have || proto.Equal(existing, p)
+		}
+		r.record("play", fmt.Sprintf("%v@%v", p.ReleaseId, p.Date), have, func() { c.Plays = append(c.Plays, p) })
+	}
+	for _, s := range backup.Suggestions {
+		have := false
+		for _, existing := range c.Suggestions {
+			have = have || proto.Equal(existing, s)
+		}
+		r.record("suggestion", fmt.Sprintf("%v@%v", s.ReleaseId, s.Date), have, func() { c.Suggestions = append(c.Suggestions, s) })
+	}
+	for _, a := range backup.RuleAudit {
+		have := false
+		for _, existing := range c.RuleAudit {
+			have = have || proto.Equal(existing, a)
+		}
+		r.record("rule audit", fmt.Sprintf("%v@%v", a.GetRelease().GetId(), a.Date), have, func() { c.RuleAudit = append(c.RuleAudit, a) })
+	}
+	for _, l := range backup.Loans {
+		have := false
+		for _, existing := range c.Loans {
+			have = have || proto.Equal(existing, l)
+		}
+		r.record("loan", fmt.Sprintf("%v@%v", l.ReleaseId, l.Lent), have, func() { c.Loans = append(c.Loans, l) })
+	}
+}
+
+// BackupCollection streams out the whole collection as JSON or NDJSON
+func (syncer *Syncer) BackupCollection(in *pb.BackupRequest, stream pb.DiscogsService_BackupCollectionServer) error {
+	t := time.Now()
+	err := syncer.backupLines(in.Format, func(line string) error {
+		return stream.Send(&pb.ExportRow{Line: line})
+	})
+	syncer.LogFunction("BackupCollection", t)
+	return err
+}
+
+// RestoreCollection loads a backup into the collection, reporting what changed
+func (syncer *Syncer) RestoreCollection(ctx context.Context, in *pb.RestoreRequest) (*pb.RestoreReport, error) {
+	t := time.Now()
+
+	backup, err := parseBackup(in.Data, in.Format)
+	if err != nil {
+		return nil, err
+	}
+	if problems := validateBackup(backup); len(problems) > 0 {
+		return nil, fmt.Errorf("Unable to restore: %v", strings.Join(problems, "; "))
+	}
+
+	r := &restorer{policy: in.Policy, dryRun: in.DryRun, report: &pb.RestoreReport{}}
+	syncer.restoreBackup(backup, r)
+
+	if !in.DryRun {
+		// The restore swaps in new messages, so drop the ones we've handed out from the maps
+		syncer.mapM.Lock()
+		syncer.rMap = make(map[int]*pbd.Release)
+		syncer.mMap = make(map[int32]*pb.ReleaseMetadata)
+		syncer.mapM.Unlock()
+		syncer.saveCollection()
+	}
+	syncer.LogFunction("RestoreCollection", t)
+	return r.report, nil
+}
@@ -0,0 +1,175 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"golang.org/x/net/context"
+
+	pb "github.com/brotherlogic/discogssyncer/server"
+	pbd "github.com/brotherlogic/godiscogs"
+)
+
+type testBackupStream struct {
+	pb.DiscogsService_BackupCollectionServer
+	lines []string
+}
+
+func (s *testBackupStream) Send(row *pb.ExportRow) error {
+	s.lines = append(s.lines, row.Line)
+	return nil
+}
+
+func backupSyncer(t *testing.T, foldername string) (*Syncer, map[pb.BackupFormat]string) {
+	syncer := GetTestSyncer(foldername, true)
+	syncer.SaveCollection()
+	syncer.AddField(context.Background(), &pb.FieldDefinition{Name: "grade"})
+	syncer.UpdateMetadata(context.Background(), &pb.MetadataUpdate{Release: &pbd.Release{Id: 25, FolderId: 23}, Update: &pb.ReleaseMetadata{Cost: 1500}})
+	syncer.collection.Wantlist.Want = append(syncer.collection.Wantlist.Want, &pb.Want{ReleaseId: 77, Priority: 2})
+	syncer.collection.Wantlist.Want = append(syncer.collection.Wantlist.Want, &pb.Want{MasterId: 245, Country: "UK"}, &pb.Want{MasterId: 234})
+	syncer.collection.Plays = append(syncer.collection.Plays, &pb.Play{ReleaseId: 25, InstanceId: 1234, Date: 100})
+
+	backups := make(map[pb.BackupFormat]string)
+	for _, format := range []pb.BackupFormat{pb.BackupFormat_JSON, pb.BackupFormat_NDJSON} {
+		stream := &testBackupStream{}
+		if err := syncer.BackupCollection(&pb.BackupRequest{Format: format}, stream); err != nil {
+			t.Fatalf("Error backing up: %v", err)
+		}
+		backups[format] = strings.Join(stream.lines, "")
+	}
+	return syncer, backups
+}
+
+func TestBackupRoundTrip(t *testing.T) {
+	original, backups := backupSyncer(t, ".testbackup")
+
+	if strings.Count(backups[pb.BackupFormat_JSON], "\n") < 2 || !strings.HasSuffix(backups[pb.BackupFormat_JSON], "\n") {
+		t.Errorf("JSON backup is not indented: %v", backups[pb.BackupFormat_JSON])
+	}
+	if len(original.backupItems()) != strings.Count(backups[pb.BackupFormat_NDJSON], "\n") {
+		t.Errorf("NDJSON backup is not one item per line: %v", backups[pb.BackupFormat_NDJSON])
+	}
+
+	for format, data := range backups {
+		syncer := GetTestSyncer(".testrestore", true)
+		report, err := syncer.RestoreCollection(context.Background(), &pb.RestoreRequest{Data: data, Format: format})
+		if err != nil {
+			t.Fatalf("Error restoring %v: %v", format, err)
+		}
+
+		for _, c := range report.Changes {
+			if c.Action != "added" {
+				t.Errorf("Restoring to an empty collection has done more than add: %v", c)
+			}
+		}
+		if !proto.Equal(syncer.collection, original.collection) {
+			t.Errorf("%v restore does not match:\n%v\n%v", format, syncer.collection, original.collection)
+		}
+	}
+}
+
+func TestRestorePolicies(t *testing.T) {
+	for _, test := range []struct {
+		policy pb.ConflictPolicy
+		name   string
+		cost   int32
+		action string
+	}{
+		{pb.ConflictPolicy_SKIP, "Renamed", 0, "skipped"},
+		{pb.ConflictPolicy_OVERWRITE, "Testing", 1500, "overwritten"},
+		{pb.ConflictPolicy_MERGE, "Renamed", 1500, "merged"},
+	} {
+		syncer, backups := backupSyncer(t, ".testrestorepolicy")
+		syncer.findFolder(23).Folder.Name = "Renamed"
+		syncer.findMetadata(25).Cost = 0
+
+		report, err := syncer.RestoreCollection(context.Background(), &pb.RestoreRequest{Data: backups[pb.BackupFormat_NDJSON], Format: pb.BackupFormat_NDJSON, Policy: test.policy})
+		if err != nil {
+			t.Fatalf("Error restoring: %v", err)
+		}
+
+		if syncer.findFolder(23).Folder.Name != test.name || syncer.findMetadata(25).Cost != test.cost {
+			t.Errorf("%v has restored badly: %v, %v", test.policy, syncer.findFolder(23).Folder, syncer.findMetadata(25))
+		}
+
+		actions := make(map[string]string)
+		for _, c := range report.Changes {
+			actions[c.Kind+" "+c.Key] = c.Action
+		}
+		if len(report.Changes) != 2 || actions["metadata 25"] != test.action {
+			t.Errorf("%v has reported badly: %v", test.policy, report.Changes)
+		}
+	}
+}
+
+func TestRestoreMovesRelease(t *testing.T) {
+	syncer, backups := backupSyncer(t, ".testrestoremove")
+	syncer.moveRelease(&pb.ReleaseMove{Release: &pbd.Release{Id: 25, FolderId: 23, InstanceId: 1234}, NewFolderId: 25}, false, false)
+
+	_, err := syncer.RestoreCollection(context.Background(), &pb.RestoreRequest{Data: backups[pb.BackupFormat_JSON], Policy: pb.ConflictPolicy_OVERWRITE})
+	if err != nil {
+		t.Fatalf("Error restoring: %v", err)
+	}
+
+	if len(syncer.getReleases(25).GetReleases()) != 0 || len(syncer.getReleases(23).GetReleases()) != 2 {
+		t.Errorf("Release has not been moved back: %v", syncer.collection.Folders)
+	}
+}
+
+func TestRestoreRefreshesMetadata(t *testing.T) {
+	syncer, backups := backupSyncer(t, ".testrestoremetadata")
+	syncer.findMetadata(25).Cost = 0
+	if md, err := syncer.GetMetadata(context.Background(), &pbd.Release{Id: 25, FolderId: 23}); err != nil || md.Cost != 0 {
+		t.Fatalf("Bad metadata before restore: %v (%v)", md, err)
+	}
+
+	_, err := syncer.RestoreCollection(context.Background(), &pb.RestoreRequest{Data: backups[pb.BackupFormat_JSON], Policy: pb.ConflictPolicy_OVERWRITE})
+	if err != nil {
+		t.Fatalf("Error restoring: %v", err)
+	}
+
+	if md, err := syncer.GetMetadata(context.Background(), &pbd.Release{Id: 25, FolderId: 23}); err != nil || md.Cost != 1500 {
+		t.Errorf("Restored metadata is not being served: %v (%v)", md, err)
+	}
+}
+
+func TestRestoreDryRun(t *testing.T) {
+	syncer, backups := backupSyncer(t, ".testrestoredryrun")
+	syncer.collection.Wantlist.Want = nil
+
+	report, err := syncer.RestoreCollection(context.Background(), &pb.RestoreRequest{Data: backups[pb.BackupFormat_JSON], DryRun: true})
+	if err != nil {
+		t.Fatalf("Error restoring: %v", err)
+	}
+
+	// Each master want is restored separately rather than colliding on release id 0
+	if len(report.Changes) != 3 || report.Changes[2].Kind != "want" || report.Changes[2].Key != "master 234" || len(syncer.collection.Wantlist.Want) != 0 {
+		t.Errorf("Dry run has gone wrong: %v, %v", report, syncer.collection.Wantlist)
+	}
+}
+
+func TestRestoreValidation(t *testing.T) {
+	syncer := GetTestSyncer(".testrestorevalidation", true)
+	syncer.SaveCollection()
+
+	for _, data := range []string{
+		"{\"folders\": [{\"folder\": {\"id\": 23}, \"releases\": {\"releases\": [{\"id\": 0}]}}]}",
+		"{\"metadata\": [{\"id\": 12}, {\"id\": 12}]}",
+		"{\"not_a_field\": 12}",
+		"not json",
+	} {
+		_, err := syncer.RestoreCollection(context.Background(), &pb.RestoreRequest{Data: data})
+		if err == nil {
+			t.Errorf("Bad backup has restored: %v", data)
+		}
+	}
+
+	_, err := syncer.RestoreCollection(context.Background(), &pb.RestoreRequest{Data: "{\"metadata\": [{\"id\": 12}]}\nbroken\n", Format: pb.BackupFormat_NDJSON})
+	if err == nil || !strings.Contains(err.Error(), "Line 2") {
+		t.Errorf("Bad NDJSON line has not been reported: %v", err)
+	}
+	if syncer.findMetadata(12) != nil {
+		t.Errorf("Failed restore has changed the collection")
+	}
+}
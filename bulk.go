@@ -0,0 +1,60 @@
+package main
+
+import (
+	"time"
+
+	"golang.org/x/net/context"
+
+	pb "github.com/brotherlogic/discogssyncer/server"
+)
+
+// throttle holds off until we're clear to make another discogs call, callers queue
+// up behind each other
+func (syncer *Syncer) throttle() {
+	syncer.throttleM.Lock()
+	defer syncer.throttleM.Unlock()
+	if wait := syncer.discogsDelay - time.Since(syncer.lastDiscogsCall); wait > 0 {
+		time.Sleep(wait)
+	}
+	syncer.lastDiscogsCall = time.Now()
+}
+
+func opResult(res *pb.OperationResult, err error) *pb.OperationResult {
+	if err != nil {
+		res.Error = err.Error()
+	} else {
+		res.Success = true
+	}
+	return res
+}
+
+// BulkMove moves a set of releases, saving once at the end
+func (syncer *Syncer) BulkMove(ctx context.Context, in *pb.BulkMoveRequest) (*pb.BulkResponse, error) {
+	t := time.Now()
+	resp := &pb.BulkResponse{}
+	for _, move := range in.Moves {
+		err := syncer.moveRelease(move, false, true)
+		resp.Results = append(resp.Results, opResult(&pb.OperationResult{Release: move.Release}, err))
+	}
+
+	syncer.saveCollection()
+	syncer.LogFunction("BulkMove", t)
+	return resp, nil
+}
+
+// BulkUpdateMetadata applies a set of metadata updates, saving once at the end
+func (syncer *Syncer) BulkUpdateMetadata(ctx context.Context, in *pb.BulkMetadataRequest) (*pb.BulkResponse, error) {
+	t := time.Now()
+	resp := &pb.BulkResponse{}
+	for _, update := range in.Updates {
+		m, err := syncer.doMetadataUpdate(update)
+		if err == nil {
+			syncer.touch(m.Id)
+		}
+		resp.Results = append(resp.Results, opResult(&pb.OperationResult{Release: update.Release}, err))
+	}
+
+	syncer.saveCollection()
+	syncer.LogFunction("BulkUpdateMetadata", t)
+	return resp, nil
+}
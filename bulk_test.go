@@ -0,0 +1,126 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+
+	pb "github.com/brotherlogic/discogssyncer/server"
+	pbd "github.com/brotherlogic/godiscogs"
+)
+
+func TestBulkMove(t *testing.T) {
+	syncer := GetTestSyncer(".testbulkmove", true)
+	syncer.SaveCollection()
+
+	resp, err := syncer.BulkMove(context.Background(), &pb.BulkMoveRequest{Moves: []*pb.ReleaseMove{
+		&pb.ReleaseMove{Release: &pbd.Release{Id: 25, FolderId: 23, InstanceId: 1234}, NewFolderId: 25},
+		&pb.ReleaseMove{Release: &pbd.Release{Id: 32, FolderId: 23, InstanceId: 1233}, NewFolderId: 99},
+		&pb.ReleaseMove{Release: &pbd.Release{Id: 29, FolderId: 22}, NewFolderId: 25},
+	}})
+	if err != nil {
+		t.Fatalf("Error in bulk move: %v", err)
+	}
+
+	if len(resp.Results) != 3 || !resp.Results[0].Success || resp.Results[1].Success || !resp.Results[2].Success {
+		t.Errorf("Bad move results: %v", resp)
+	}
+
+	moved := syncer.getReleases(25).Releases
+	if len(moved) != 2 || moved[0].MasterId != 234 || moved[0].FolderId != 25 {
+		t.Errorf("Releases have been badly moved: %v", moved)
+	}
+	if len(syncer.getReleases(23).Releases) != 1 {
+		t.Errorf("Failed move has changed the source folder: %v", syncer.getReleases(23))
+	}
+}
+
+func TestBulkMoveThrottles(t *testing.T) {
+	syncer := GetTestSyncer(".testbulkmovethrottle", true)
+	syncer.SaveCollection()
+	syncer.discogsDelay = time.Millisecond * 20
+
+	start := time.Now()
+	syncer.BulkMove(context.Background(), &pb.BulkMoveRequest{Moves: []*pb.ReleaseMove{
+		&pb.ReleaseMove{Release: &pbd.Release{Id: 25, FolderId: 23, InstanceId: 1234}, NewFolderId: 25},
+		&pb.ReleaseMove{Release: &pbd.Release{Id: 32, FolderId: 23, InstanceId: 1233}, NewFolderId: 25},
+		&pb.ReleaseMove{Release: &pbd.Release{Id: 29, FolderId: 22}, NewFolderId: 25},
+	}})
+
+	if time.Since(start) < time.Millisecond*40 {
+		t.Errorf("Moves have not been throttled: %v", time.Since(start))
+	}
+}
+
+func TestFailedBulkMovesAreNotThrottled(t *testing.T) {
+	syncer := GetTestSyncer(".testbulkmovefailthrottle", true)
+	syncer.SaveCollection()
+	syncer.discogsDelay = time.Second
+	syncer.lastDiscogsCall = time.Now()
+
+	start := time.Now()
+	syncer.BulkMove(context.Background(), &pb.BulkMoveRequest{Moves: []*pb.ReleaseMove{
+		&pb.ReleaseMove{Release: &pbd.Release{Id: 25, FolderId: 23, InstanceId: 1234}, NewFolderId: 99},
+		&pb.ReleaseMove{NewFolderId: 25},
+	}})
+	if time.Since(start) > time.Millisecond*500 {
+		t.Errorf("Moves which never reached discogs have been throttled: %v", time.Since(start))
+	}
+}
+
+func TestSingleMoveIsNotThrottled(t *testing.T) {
+	syncer := GetTestSyncer(".testsinglemovethrottle", true)
+	syncer.SaveCollection()
+	syncer.discogsDelay = time.Second
+	syncer.lastDiscogsCall = time.Now()
+
+	start := time.Now()
+	syncer.MoveToFolder(context.Background(), &pb.ReleaseMove{Release: &pbd.Release{Id: 25, FolderId: 23, InstanceId: 1234}, NewFolderId: 25})
+	if time.Since(start) > time.Millisecond*500 {
+		t.Errorf("Single move has been throttled: %v", time.Since(start))
+	}
+}
+
+func TestThrottleIsShared(t *testing.T) {
+	syncer := GetTestSyncer(".testthrottleshared", true)
+	syncer.discogsDelay = time.Millisecond * 20
+
+	start := time.Now()
+	done := make(chan bool)
+	for i := 0; i < 3; i++ {
+		go func() {
+			syncer.throttle()
+			done <- true
+		}()
+	}
+	for i := 0; i < 3; i++ {
+		<-done
+	}
+	if time.Since(start) < time.Millisecond*40 {
+		t.Errorf("Concurrent calls have not been spaced out: %v", time.Since(start))
+	}
+}
+
+func TestBulkUpdateMetadata(t *testing.T) {
+	syncer := GetTestSyncer(".testbulkupdatemetadata", true)
+	syncer.SaveCollection()
+
+	resp, err := syncer.BulkUpdateMetadata(context.Background(), &pb.BulkMetadataRequest{Updates: []*pb.MetadataUpdate{
+		&pb.MetadataUpdate{Release: &pbd.Release{Id: 25, FolderId: 23}, Update: &pb.ReleaseMetadata{Cost: 1200}},
+		&pb.MetadataUpdate{Release: &pbd.Release{Id: 999, FolderId: 23}, Update: &pb.ReleaseMetadata{Cost: 1200}},
+		&pb.MetadataUpdate{Release: &pbd.Release{Id: 32, FolderId: 23}},
+		&pb.MetadataUpdate{Release: &pbd.Release{Id: 29, FolderId: 22}, Update: &pb.ReleaseMetadata{Cost: 800}},
+	}})
+	if err != nil {
+		t.Fatalf("Error in bulk update: %v", err)
+	}
+
+	if len(resp.Results) != 4 || !resp.Results[0].Success || resp.Results[1].Success || resp.Results[2].Success || !resp.Results[3].Success {
+		t.Errorf("Bad update results: %v", resp)
+	}
+
+	if syncer.findMetadata(25).Cost != 1200 || syncer.findMetadata(29).Cost != 800 {
+		t.Errorf("Updates have not been applied")
+	}
+}
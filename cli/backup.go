@@ -0,0 +1,123 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/golang/protobuf/proto"
+
+	pb "github.com/brotherlogic/discogssyncer/server"
+)
+
+// writeRows copies a stream of rows to file, or stdout if that's unset; the rows are
+// the result, so nothing is returned for main to print
+func writeRows(file string, recv func() (*pb.ExportRow, error)) (proto.Message, error) {
+	var out io.Writer = os.Stdout
+	if len(file) > 0 {
+		f, err := os.Create(file)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		out = f
+	}
+
+	for {
+		row, err := recv()
+		if err == io.EOF {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if _, err := io.WriteString(out, row.Line); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func export(c *cli, args []string) (proto.Message, error) {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	columns := fs.String("columns", "", "Comma separated columns to export, defaults to everything")
+	dateFormat := fs.String("date_format", "", "Go time layout for dates, defaults to 2006-01-02")
+	file := fs.String("file", "", "File to write the CSV to, defaults to stdout")
+	if err := parse(fs, args, false); err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.context()
+	defer cancel()
+	stream, err := c.client.ExportCollection(ctx, &pb.ExportRequest{Columns: splitList(*columns), DateFormat: *dateFormat})
+	if err != nil {
+		return nil, err
+	}
+	return writeRows(*file, stream.Recv)
+}
+
+func importCSV(c *cli, args []string) (proto.Message, error) {
+	fs := flag.NewFlagSet("import", flag.ContinueOnError)
+	apply := fs.Bool("apply", false, "Make the changes rather than just reporting them")
+	if err := parse(fs, args, true); err != nil {
+		return nil, err
+	}
+	if fs.NArg() != 1 {
+		return nil, errors.New("import needs a single CSV file")
+	}
+	data, err := ioutil.ReadFile(fs.Arg(0))
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.context()
+	defer cancel()
+	return c.client.ImportDiscogsCSV(ctx, &pb.DiscogsImportRequest{Csv: string(data), DryRun: !*apply})
+}
+
+func backup(c *cli, args []string) (proto.Message, error) {
+	fs := flag.NewFlagSet("backup", flag.ContinueOnError)
+	format := fs.String("format", "json", "How to write the backup: json or ndjson")
+	file := fs.String("file", "", "File to write the backup to, defaults to stdout")
+	if err := parse(fs, args, false); err != nil {
+		return nil, err
+	}
+	f, err := enumValue(pb.BackupFormat_value, *format, "format")
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.context()
+	defer cancel()
+	stream, err := c.client.BackupCollection(ctx, &pb.BackupRequest{Format: pb.BackupFormat(f)})
+	if err != nil {
+		return nil, err
+	}
+	return writeRows(*file, stream.Recv)
+}
+
+func restore(c *cli, args []string) (proto.Message, error) {
+	fs := flag.NewFlagSet("restore", flag.ContinueOnError)
+	format := fs.String("format", "json", "How the backup is written: json or ndjson")
+	policy := fs.String("policy", "skip", "What to do with things we already have: skip, overwrite or merge")
+	apply := fs.Bool("apply", false, "Make the changes rather than just reporting them")
+	if err := parse(fs, args, true); err != nil {
+		return nil, err
+	}
+	if fs.NArg() != 1 {
+		return nil, errors.New("restore needs a single backup file")
+	}
+	f, err := enumValue(pb.BackupFormat_value, *format, "format")
+	if err != nil {
+		return nil, err
+	}
+	p, err := enumValue(pb.ConflictPolicy_value, *policy, "policy")
+	if err != nil {
+		return nil, err
+	}
+	data, err := ioutil.ReadFile(fs.Arg(0))
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.context()
+	defer cancel()
+	return c.client.RestoreCollection(ctx, &pb.RestoreRequest{Data: string(data), Format: pb.BackupFormat(f), Policy: pb.ConflictPolicy(p), DryRun: !*apply})
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/brotherlogic/discogssyncer/dial"
+	"github.com/brotherlogic/goserver/utils"
+	"github.com/golang/protobuf/proto"
+	"golang.org/x/net/context"
+
+	pb "github.com/brotherlogic/discogssyncer/server"
+)
+
+// command is a single subcommand, args are whatever follows its name
+type command struct {
+	usage string
+	run   func(c *cli, args []string) (proto.Message, error)
+}
+
+type cli struct {
+	client  pb.DiscogsServiceClient
+	timeout time.Duration
+}
+
+func (c *cli) context() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), c.timeout)
+}
+
+// lookup finds the command to run, trying two word commands like "want add" first
+func lookup(args []string) (command, []string, bool) {
+	if len(args) > 1 {
+		if cmd, ok := commands[args[0]+" "+args[1]]; ok {
+			return cmd, args[2:], true
+		}
+	}
+	if len(args) > 0 {
+		if cmd, ok := commands[args[0]]; ok {
+			return cmd, args[1:], true
+		}
+	}
+	return command{}, nil, false
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "Usage: cli [flags] <command> [args]\n\nFlags:\n")
+	flag.PrintDefaults()
+	fmt.Fprintf(os.Stderr, "\nCommands:\n")
+	var names []string
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  %-18v %v\n", name, commands[name].usage)
+	}
+}
+
+func main() {
+	var server = flag.String("server", "", "host:port of the syncer, found through discovery if not set")
+	var discovery = flag.String("discovery", utils.Discover, "host:port of the discovery server")
+	var output = flag.String("output", "table", "How to print results: table or json")
+	var timeout = flag.Duration("timeout", time.Second*30, "How long to wait for the server")
+	flag.Usage = usage
+	flag.Parse()
+
+	cmd, args, ok := lookup(flag.Args())
+	if !ok {
+		usage()
+		os.Exit(2)
+	}
+	if *output != "table" && *output != "json" {
+		log.Fatalf("Unknown output %v", *output)
+	}
+
+	conn, err := dial.Syncer(*server, *discovery)
+	if err != nil {
+		log.Fatalf("Unable to dial server: %v", err)
+	}
+	defer conn.Close()
+
+	res, err := cmd.run(&cli{client: pb.NewDiscogsServiceClient(conn), timeout: *timeout}, args)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+	if res == nil {
+		// The command has written its own output
+		return
+	}
+
+	if *output == "json" {
+		err = printJSON(os.Stdout, res)
+	} else {
+		err = printTable(os.Stdout, res)
+	}
+	if err != nil {
+		log.Fatalf("Unable to print result: %v", err)
+	}
+}
+
+// parseID reads a positional release id
+func parseID(args []string) (int32, error) {
+	if len(args) != 1 {
+		return 0, fmt.Errorf("Expected a single release id, got %v", strings.Join(args, " "))
+	}
+	id, err := strconv.Atoi(args[0])
+	return int32(id), err
+}
@@ -0,0 +1,522 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+
+	pb "github.com/brotherlogic/discogssyncer/server"
+	pbd "github.com/brotherlogic/godiscogs"
+)
+
+const day = 60 * 60 * 24
+
+var commands = map[string]command{
+	"collection":         {"List every release in the collection", collection},
+	"folder":             {"<id or name>... List the records in folders", folder},
+	"folder create":      {"<name> Create a folder", folderCreate},
+	"folder rename":      {"-id -name Rename a folder", folderRename},
+	"folder delete":      {"-id [-to] Delete a folder, moving what's in it to -to", folderDelete},
+	"search":             {"[-tags a,b -fields name=value,...] <query> Search titles and artists", search},
+	"move":               {"-id -instance -from -to Move a release between folders", move},
+	"move bulk":          {"-to <id[:instance]>... Move several releases into a folder", moveBulk},
+	"rate":               {"-id -instance -folder -rating Rate a release", rate},
+	"metadata get":       {"<id> Show the metadata for a release", metadataGet},
+	"metadata set":       {"-id [-cost -date_added -file_path -notes -others] Update release metadata", metadataSet},
+	"metadata bulk":      {"<file> Apply a JSON BulkMetadataRequest", metadataBulk},
+	"want list":          {"[-min_priority -wanted_only] List the wantlist", wantList},
+	"want add":           {"-id [-master -priority -note -max_price] Add a want", wantAdd},
+	"want edit":          {"-id [-master -priority -note -max_price -valued] Edit a want", wantEdit},
+	"want delete":        {"[-master] <id> Remove a want", wantDelete},
+	"want expand":        {"<master id> Add the pressings a master want allows", wantExpand},
+	"want collapse":      {"[-priority] Drop wants below a priority from discogs", wantCollapse},
+	"want rebuild":       {"Put every want back on discogs", wantRebuild},
+	"spend":              {"-year [-month -tags a,b] Show what we spent", spend},
+	"sell":               {"<id> List a release for sale", sell},
+	"sell candidates":    {"[-max_rating -untouched_days -min_value -duplicates -limit] Suggest records to sell", sellCandidates},
+	"sell bulk":          {"<id>... List several releases for sale", sellBulk},
+	"play":               {"-id [-instance -date -side -track -listener] Log a listen", play},
+	"play list":          {"[-id -instance -since -until -listener] List listens", playList},
+	"play counts":        {"[-least -limit] Count listens per record", playCounts},
+	"play never":         {"[-owned_days] List records we've never played", playNever},
+	"play streaks":       {"[-listener] Show listening streaks", playStreaks},
+	"suggest":            {"[-folders a,b -strategy -genre -style -avoid_days] Suggest something to play", suggest},
+	"tag add":            {"-id [-instance] <tag>... Tag a release", tagAdd},
+	"tag remove":         {"-id [-instance] <tag>... Untag a release", tagRemove},
+	"tag list":           {"List every tag and how often it's used", tagList},
+	"tag releases":       {"<tag>... List the releases carrying all the tags", tagReleases},
+	"tag rename":         {"<from> <to> Rename a tag, merging it if to is in use", tagRename},
+	"field list":         {"List the custom fields", fieldList},
+	"field add":          {"-name [-type -options a,b] Add a custom field", fieldAdd},
+	"field delete":       {"<name> Remove a custom field", fieldDelete},
+	"smartfolder list":   {"List the smart folders", smartFolderList},
+	"smartfolder add":    {"-name <field:comparison:value>... Add a smart folder", smartFolderAdd},
+	"smartfolder update": {"-id -name <field:comparison:value>... Replace a smart folder", smartFolderUpdate},
+	"smartfolder delete": {"<id> Remove a smart folder", smartFolderDelete},
+	"rule list":          {"List the folder rules", ruleList},
+	"rule add":           {"-name -folder <field:comparison:value>... Add a folder rule", ruleAdd},
+	"rule delete":        {"<name> Remove a folder rule", ruleDelete},
+	"rule preview":       {"Show what the folder rules would move", rulePreview},
+	"rule audit":         {"Show what the folder rules have moved", ruleAudit},
+	"shelf set":          {"-id [-instance] -shelf -position Place a record on a shelf", shelfSet},
+	"shelf order":        {"-folder [-order -apply -shelf] Order a folder along its shelf", shelfOrder},
+	"shelf insert":       {"-id -folder [-order] Find where a new record goes", shelfInsert},
+	"loan lend":          {"-id [-instance -due] -borrower Lend a record out", loanLend},
+	"loan return":        {"-id [-instance] Mark a record as returned", loanReturn},
+	"loan list":          {"[-id -borrower -overdue -all] List loans", loanList},
+	"export":             {"[-columns a,b -date_format -file] Export the collection as CSV", export},
+	"import":             {"[-apply] <file> Import the collection CSV exported from discogs", importCSV},
+	"backup":             {"[-format -file] Back up the collection", backup},
+	"restore":            {"[-format -policy -apply] <file> Restore a backup", restore},
+	"sync":               {"Sync the collection and wantlist with discogs", sync},
+}
+
+// parse runs a subcommand's flags, failing on anything left over unless positional is set
+func parse(fs *flag.FlagSet, args []string, positional bool) error {
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if !positional && fs.NArg() > 0 {
+		return fmt.Errorf("Unexpected arguments: %v", strings.Join(fs.Args(), " "))
+	}
+	return nil
+}
+
+// given reports whether a flag was set on the command line
+func given(fs *flag.FlagSet, name string) bool {
+	found := false
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			found = true
+		}
+	})
+	return found
+}
+
+func splitList(list string) []string {
+	if list == "" {
+		return nil
+	}
+	return strings.Split(list, ",")
+}
+
+// parseDay reads a YYYY-MM-DD date, zero if it's empty
+func parseDay(value string) (int64, error) {
+	if value == "" {
+		return 0, nil
+	}
+	d, err := time.ParseInLocation("2006-01-02", value, time.Local)
+	if err != nil {
+		return 0, err
+	}
+	return d.Unix(), nil
+}
+
+// parseRelease reads a release given as id or id:instance
+func parseRelease(value string) (*pbd.Release, error) {
+	parts := strings.SplitN(value, ":", 2)
+	id, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return nil, fmt.Errorf("Bad release %v", value)
+	}
+	rel := &pbd.Release{Id: int32(id)}
+	if len(parts) == 2 {
+		instance, err := strconv.Atoi(parts[1])
+		if err != nil {
+			return nil, fmt.Errorf("Bad instance in %v", value)
+		}
+		rel.InstanceId = int32(instance)
+	}
+	return rel, nil
+}
+
+// enumValue looks up an enum by name, ignoring case
+func enumValue(values map[string]int32, name, what string) (int32, error) {
+	if v, ok := values[strings.ToUpper(name)]; ok {
+		return v, nil
+	}
+	var names []string
+	for n := range values {
+		names = append(names, strings.ToLower(n))
+	}
+	sort.Strings(names)
+	return 0, fmt.Errorf("Unknown %v %v, expected one of %v", what, name, strings.Join(names, ", "))
+}
+
+// parseConditions reads conditions given as field:comparison:value, e.g. rating:at_least:4
+func parseConditions(args []string) ([]*pb.Condition, error) {
+	var conditions []*pb.Condition
+	for _, arg := range args {
+		parts := strings.SplitN(arg, ":", 3)
+		if len(parts) != 3 {
+			return nil, fmt.Errorf("Conditions are field:comparison:value, not %v", arg)
+		}
+		field, err := enumValue(pb.SmartField_value, parts[0], "field")
+		if err != nil {
+			return nil, err
+		}
+		comparison, err := enumValue(pb.Comparison_value, parts[1], "comparison")
+		if err != nil {
+			return nil, err
+		}
+		conditions = append(conditions, &pb.Condition{Field: pb.SmartField(field), Comparison: pb.Comparison(comparison), Value: parts[2]})
+	}
+	return conditions, nil
+}
+
+// parsePounds turns 12.50 into 1250 pence
+func parsePounds(value string) (int32, error) {
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, err
+	}
+	return int32(f*100 + 0.5), nil
+}
+
+func collection(c *cli, args []string) (proto.Message, error) {
+	if len(args) > 0 {
+		return nil, errors.New("collection takes no arguments")
+	}
+	ctx, cancel := c.context()
+	defer cancel()
+	return c.client.GetCollection(ctx, &pb.Empty{})
+}
+
+func folder(c *cli, args []string) (proto.Message, error) {
+	if len(args) == 0 {
+		return nil, errors.New("Name at least one folder")
+	}
+	list := &pb.FolderList{}
+	for _, arg := range args {
+		if id, err := strconv.Atoi(arg); err == nil {
+			list.Folders = append(list.Folders, &pbd.Folder{Id: int32(id)})
+		} else {
+			list.Folders = append(list.Folders, &pbd.Folder{Name: arg})
+		}
+	}
+	ctx, cancel := c.context()
+	defer cancel()
+	return c.client.GetReleasesInFolder(ctx, list)
+}
+
+func search(c *cli, args []string) (proto.Message, error) {
+	fs := flag.NewFlagSet("search", flag.ContinueOnError)
+	tags := fs.String("tags", "", "Comma separated tags the releases must carry")
+	fields := fs.String("fields", "", "Comma separated name=value custom fields the releases must match")
+	if err := parse(fs, args, true); err != nil {
+		return nil, err
+	}
+	req := &pb.SearchRequest{Query: strings.Join(fs.Args(), " "), Tags: splitList(*tags)}
+	for _, f := range splitList(*fields) {
+		parts := strings.SplitN(f, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("Fields are name=value, not %v", f)
+		}
+		req.Fields = append(req.Fields, &pb.CustomField{Name: parts[0], Value: parts[1]})
+	}
+	ctx, cancel := c.context()
+	defer cancel()
+	return c.client.Search(ctx, req)
+}
+
+func move(c *cli, args []string) (proto.Message, error) {
+	fs := flag.NewFlagSet("move", flag.ContinueOnError)
+	id := fs.Int("id", 0, "The release to move")
+	instance := fs.Int("instance", 0, "The copy of the release to move")
+	from := fs.Int("from", 0, "The folder it's in")
+	to := fs.Int("to", 0, "The folder to move it to")
+	if err := parse(fs, args, false); err != nil {
+		return nil, err
+	}
+	if *id == 0 || *to == 0 {
+		return nil, errors.New("move needs -id and -to")
+	}
+	ctx, cancel := c.context()
+	defer cancel()
+	return c.client.MoveToFolder(ctx, &pb.ReleaseMove{Release: &pbd.Release{Id: int32(*id), InstanceId: int32(*instance), FolderId: int32(*from)}, NewFolderId: int32(*to)})
+}
+
+func rate(c *cli, args []string) (proto.Message, error) {
+	fs := flag.NewFlagSet("rate", flag.ContinueOnError)
+	id := fs.Int("id", 0, "The release to rate")
+	instance := fs.Int("instance", 0, "The copy of the release to rate")
+	folder := fs.Int("folder", 0, "The folder it's in")
+	rating := fs.Int("rating", 0, "The rating, 0 to 5")
+	if err := parse(fs, args, false); err != nil {
+		return nil, err
+	}
+	if *id == 0 || *rating < 0 || *rating > 5 {
+		return nil, errors.New("rate needs -id and a -rating between 0 and 5")
+	}
+	ctx, cancel := c.context()
+	defer cancel()
+	return c.client.UpdateRating(ctx, &pbd.Release{Id: int32(*id), InstanceId: int32(*instance), FolderId: int32(*folder), Rating: int32(*rating)})
+}
+
+func metadataGet(c *cli, args []string) (proto.Message, error) {
+	id, err := parseID(args)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.context()
+	defer cancel()
+	return c.client.GetMetadata(ctx, &pbd.Release{Id: id})
+}
+
+func metadataSet(c *cli, args []string) (proto.Message, error) {
+	fs := flag.NewFlagSet("metadata set", flag.ContinueOnError)
+	id := fs.Int("id", 0, "The release to update")
+	folder := fs.Int("folder", 0, "The folder it's in")
+	cost := fs.String("cost", "", "What we paid, in pounds")
+	dateAdded := fs.String("date_added", "", "When we got it, as YYYY-MM-DD")
+	filePath := fs.String("file_path", "", "The path to the file on iTunes")
+	notes := fs.String("notes", "", "Free text notes")
+	others := fs.Bool("others", false, "Whether we have other copies")
+	if err := parse(fs, args, false); err != nil {
+		return nil, err
+	}
+	if *id == 0 {
+		return nil, errors.New("metadata set needs -id")
+	}
+
+	ctx, cancel := c.context()
+	defer cancel()
+	release := &pbd.Release{Id: int32(*id), FolderId: int32(*folder)}
+	current, err := c.client.GetMetadata(ctx, release)
+	if err != nil {
+		return nil, err
+	}
+
+	// Others is cleared unless we send it, so keep the current value unless asked
+	update := &pb.ReleaseMetadata{FilePath: *filePath, Notes: *notes, Others: current.Others}
+	if given(fs, "others") {
+		update.Others = *others
+	}
+	if *cost != "" {
+		if update.Cost, err = parsePounds(*cost); err != nil {
+			return nil, err
+		}
+	}
+	if update.DateAdded, err = parseDay(*dateAdded); err != nil {
+		return nil, err
+	}
+
+	return c.client.UpdateMetadata(ctx, &pb.MetadataUpdate{Release: release, Update: update})
+}
+
+func metadataBulk(c *cli, args []string) (proto.Message, error) {
+	if len(args) != 1 {
+		return nil, errors.New("metadata bulk needs a single file")
+	}
+	data, err := ioutil.ReadFile(args[0])
+	if err != nil {
+		return nil, err
+	}
+	req := &pb.BulkMetadataRequest{}
+	if err := jsonpb.UnmarshalString(string(data), req); err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.context()
+	defer cancel()
+	return c.client.BulkUpdateMetadata(ctx, req)
+}
+
+func wantList(c *cli, args []string) (proto.Message, error) {
+	fs := flag.NewFlagSet("want list", flag.ContinueOnError)
+	priority := fs.Int("min_priority", 0, "Only show wants at or above this priority")
+	wanted := fs.Bool("wanted_only", false, "Only show wants on the discogs wantlist")
+	if err := parse(fs, args, false); err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.context()
+	defer cancel()
+	return c.client.GetWantlist(ctx, &pb.WantlistRequest{MinPriority: int32(*priority), WantedOnly: *wanted})
+}
+
+// wantFlags sets up the flags shared by want add and want edit
+func wantFlags(fs *flag.FlagSet) func() (*pb.Want, error) {
+	id := fs.Int("id", 0, "The release we want")
+	master := fs.Int("master", 0, "The master release, when any pressing will do")
+	priority := fs.Int("priority", 0, "How much we want it, higher means more")
+	note := fs.String("note", "", "Free text notes")
+	maxPrice := fs.String("max_price", "", "The most we'll pay, in pounds")
+	return func() (*pb.Want, error) {
+		if *id == 0 && *master == 0 {
+			return nil, errors.New("Wants need -id or -master")
+		}
+		want := &pb.Want{ReleaseId: int32(*id), MasterId: int32(*master), Priority: int32(*priority), Note: *note}
+		if *maxPrice != "" {
+			price, err := parsePounds(*maxPrice)
+			if err != nil {
+				return nil, err
+			}
+			want.MaxPrice = price
+		}
+		return want, nil
+	}
+}
+
+func wantAdd(c *cli, args []string) (proto.Message, error) {
+	fs := flag.NewFlagSet("want add", flag.ContinueOnError)
+	build := wantFlags(fs)
+	if err := parse(fs, args, false); err != nil {
+		return nil, err
+	}
+	want, err := build()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.context()
+	defer cancel()
+	if _, err := c.client.AddWant(ctx, want); err != nil {
+		return nil, err
+	}
+	return want, nil
+}
+
+func wantEdit(c *cli, args []string) (proto.Message, error) {
+	fs := flag.NewFlagSet("want edit", flag.ContinueOnError)
+	build := wantFlags(fs)
+	valued := fs.Bool("valued", false, "Whether we value the want")
+	if err := parse(fs, args, false); err != nil {
+		return nil, err
+	}
+	want, err := build()
+	if err != nil {
+		return nil, err
+	}
+
+	want.Valued = *valued
+
+	// Only change what we've been given, even when it's being set back to zero
+	for _, name := range []string{"priority", "note", "max_price", "valued"} {
+		if given(fs, name) {
+			want.UpdateMask = append(want.UpdateMask, name)
+		}
+	}
+
+	ctx, cancel := c.context()
+	defer cancel()
+	return c.client.EditWant(ctx, want)
+}
+
+func wantDelete(c *cli, args []string) (proto.Message, error) {
+	fs := flag.NewFlagSet("want delete", flag.ContinueOnError)
+	master := fs.Bool("master", false, "The id is of a master want")
+	if err := parse(fs, args, true); err != nil {
+		return nil, err
+	}
+	id, err := parseID(fs.Args())
+	if err != nil {
+		return nil, err
+	}
+	want := &pb.Want{ReleaseId: id}
+	if *master {
+		want = &pb.Want{MasterId: id}
+	}
+	ctx, cancel := c.context()
+	defer cancel()
+	return c.client.DeleteWant(ctx, want)
+}
+
+func wantExpand(c *cli, args []string) (proto.Message, error) {
+	id, err := parseID(args)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.context()
+	defer cancel()
+	return c.client.ExpandWant(ctx, &pb.Want{MasterId: id})
+}
+
+func wantCollapse(c *cli, args []string) (proto.Message, error) {
+	fs := flag.NewFlagSet("want collapse", flag.ContinueOnError)
+	priority := fs.Int("priority", 0, "Keep wants at or above this priority, valued wants when unset")
+	if err := parse(fs, args, false); err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.context()
+	defer cancel()
+	return c.client.CollapseWantlist(ctx, &pb.CollapseRequest{PriorityThreshold: int32(*priority)})
+}
+
+func wantRebuild(c *cli, args []string) (proto.Message, error) {
+	if len(args) > 0 {
+		return nil, errors.New("want rebuild takes no arguments")
+	}
+	ctx, cancel := c.context()
+	defer cancel()
+	return c.client.RebuildWantlist(ctx, &pb.Empty{})
+}
+
+func spend(c *cli, args []string) (proto.Message, error) {
+	fs := flag.NewFlagSet("spend", flag.ContinueOnError)
+	year := fs.Int("year", time.Now().Year(), "The year to total")
+	month := fs.Int("month", 0, "The month to total, the whole year when unset")
+	tags := fs.String("tags", "", "Comma separated tags the releases must carry")
+	if err := parse(fs, args, false); err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.context()
+	defer cancel()
+	return c.client.GetSpend(ctx, &pb.SpendRequest{Year: int32(*year), Month: int32(*month), Tags: splitList(*tags)})
+}
+
+func sell(c *cli, args []string) (proto.Message, error) {
+	id, err := parseID(args)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.context()
+	defer cancel()
+	return c.client.Sell(ctx, &pbd.Release{Id: id})
+}
+
+func sellCandidates(c *cli, args []string) (proto.Message, error) {
+	fs := flag.NewFlagSet("sell candidates", flag.ContinueOnError)
+	maxRating := fs.Int("max_rating", 0, "Flag records rated at or below this")
+	untouched := fs.Int("untouched_days", 0, "Flag records we haven't touched in this many days")
+	minValue := fs.Float64("min_value", 0, "Skip records worth less than this")
+	duplicates := fs.Bool("duplicates", false, "Flag records where we have another pressing")
+	limit := fs.Int("limit", 0, "The most candidates to show, all of them when unset")
+	if err := parse(fs, args, false); err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.context()
+	defer cancel()
+	return c.client.SellCandidates(ctx, &pb.SellCandidatesRequest{MaxRating: int32(*maxRating), UntouchedFor: int64(*untouched) * day, MinValue: float32(*minValue), IncludeDuplicates: *duplicates, Limit: int32(*limit)})
+}
+
+func sellBulk(c *cli, args []string) (proto.Message, error) {
+	if len(args) == 0 {
+		return nil, errors.New("Name at least one release to sell")
+	}
+	req := &pb.BulkSellRequest{}
+	for _, arg := range args {
+		rel, err := parseRelease(arg)
+		if err != nil {
+			return nil, err
+		}
+		req.Releases = append(req.Releases, rel)
+	}
+	ctx, cancel := c.context()
+	defer cancel()
+	return c.client.BulkSell(ctx, req)
+}
+
+func sync(c *cli, args []string) (proto.Message, error) {
+	if len(args) > 0 {
+		return nil, errors.New("sync takes no arguments")
+	}
+	ctx, cancel := c.context()
+	defer cancel()
+	return c.client.SyncWithDiscogs(ctx, &pb.Empty{})
+}
@@ -0,0 +1,233 @@
+package main
+
+import (
+	"errors"
+	"flag"
+
+	"github.com/golang/protobuf/proto"
+
+	pb "github.com/brotherlogic/discogssyncer/server"
+	pbd "github.com/brotherlogic/godiscogs"
+)
+
+func folderCreate(c *cli, args []string) (proto.Message, error) {
+	if len(args) != 1 {
+		return nil, errors.New("folder create needs a single folder name")
+	}
+	ctx, cancel := c.context()
+	defer cancel()
+	return c.client.CreateFolder(ctx, &pbd.Folder{Name: args[0]})
+}
+
+func folderRename(c *cli, args []string) (proto.Message, error) {
+	fs := flag.NewFlagSet("folder rename", flag.ContinueOnError)
+	id := fs.Int("id", 0, "The folder to rename")
+	name := fs.String("name", "", "Its new name")
+	if err := parse(fs, args, false); err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.context()
+	defer cancel()
+	return c.client.RenameFolder(ctx, &pbd.Folder{Id: int32(*id), Name: *name})
+}
+
+func folderDelete(c *cli, args []string) (proto.Message, error) {
+	fs := flag.NewFlagSet("folder delete", flag.ContinueOnError)
+	id := fs.Int("id", 0, "The folder to delete")
+	to := fs.Int("to", 0, "Where to move anything in the folder, only empty folders are deleted when unset")
+	if err := parse(fs, args, false); err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.context()
+	defer cancel()
+	return c.client.DeleteFolder(ctx, &pb.FolderDelete{Folder: &pbd.Folder{Id: int32(*id)}, DestinationId: int32(*to)})
+}
+
+func moveBulk(c *cli, args []string) (proto.Message, error) {
+	fs := flag.NewFlagSet("move bulk", flag.ContinueOnError)
+	to := fs.Int("to", 0, "The folder to move them to")
+	if err := parse(fs, args, true); err != nil {
+		return nil, err
+	}
+	if *to == 0 || fs.NArg() == 0 {
+		return nil, errors.New("move bulk needs -to and at least one release")
+	}
+	req := &pb.BulkMoveRequest{}
+	for _, arg := range fs.Args() {
+		rel, err := parseRelease(arg)
+		if err != nil {
+			return nil, err
+		}
+		req.Moves = append(req.Moves, &pb.ReleaseMove{Release: rel, NewFolderId: int32(*to)})
+	}
+	ctx, cancel := c.context()
+	defer cancel()
+	return c.client.BulkMove(ctx, req)
+}
+
+func smartFolderList(c *cli, args []string) (proto.Message, error) {
+	if len(args) > 0 {
+		return nil, errors.New("smartfolder list takes no arguments")
+	}
+	ctx, cancel := c.context()
+	defer cancel()
+	return c.client.GetSmartFolders(ctx, &pb.Empty{})
+}
+
+// smartFolderFlags reads the folder shared by smartfolder add and smartfolder update
+func smartFolderFlags(name string, args []string, needID bool) (*pb.SmartFolder, error) {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	id := fs.Int("id", 0, "The smart folder to replace")
+	folderName := fs.String("name", "", "The name of the smart folder")
+	if err := parse(fs, args, true); err != nil {
+		return nil, err
+	}
+	if needID && *id == 0 {
+		return nil, errors.New(name + " needs -id")
+	}
+	conditions, err := parseConditions(fs.Args())
+	if err != nil {
+		return nil, err
+	}
+	return &pb.SmartFolder{Folder: &pbd.Folder{Id: int32(*id), Name: *folderName}, Conditions: conditions}, nil
+}
+
+func smartFolderAdd(c *cli, args []string) (proto.Message, error) {
+	sf, err := smartFolderFlags("smartfolder add", args, false)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.context()
+	defer cancel()
+	return c.client.AddSmartFolder(ctx, sf)
+}
+
+func smartFolderUpdate(c *cli, args []string) (proto.Message, error) {
+	sf, err := smartFolderFlags("smartfolder update", args, true)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.context()
+	defer cancel()
+	return c.client.UpdateSmartFolder(ctx, sf)
+}
+
+func smartFolderDelete(c *cli, args []string) (proto.Message, error) {
+	id, err := parseID(args)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.context()
+	defer cancel()
+	return c.client.DeleteSmartFolder(ctx, &pbd.Folder{Id: id})
+}
+
+func ruleList(c *cli, args []string) (proto.Message, error) {
+	if len(args) > 0 {
+		return nil, errors.New("rule list takes no arguments")
+	}
+	ctx, cancel := c.context()
+	defer cancel()
+	return c.client.GetFolderRules(ctx, &pb.Empty{})
+}
+
+func ruleAdd(c *cli, args []string) (proto.Message, error) {
+	fs := flag.NewFlagSet("rule add", flag.ContinueOnError)
+	name := fs.String("name", "", "The name of the rule")
+	folder := fs.Int("folder", 0, "Where matching releases get moved")
+	if err := parse(fs, args, true); err != nil {
+		return nil, err
+	}
+	conditions, err := parseConditions(fs.Args())
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.context()
+	defer cancel()
+	return c.client.AddFolderRule(ctx, &pb.FolderRule{Name: *name, FolderId: int32(*folder), Conditions: conditions})
+}
+
+func ruleDelete(c *cli, args []string) (proto.Message, error) {
+	if len(args) != 1 {
+		return nil, errors.New("rule delete needs a single rule name")
+	}
+	ctx, cancel := c.context()
+	defer cancel()
+	return c.client.DeleteFolderRule(ctx, &pb.FolderRule{Name: args[0]})
+}
+
+func rulePreview(c *cli, args []string) (proto.Message, error) {
+	if len(args) > 0 {
+		return nil, errors.New("rule preview takes no arguments")
+	}
+	ctx, cancel := c.context()
+	defer cancel()
+	return c.client.PreviewFolderRules(ctx, &pb.Empty{})
+}
+
+func ruleAudit(c *cli, args []string) (proto.Message, error) {
+	if len(args) > 0 {
+		return nil, errors.New("rule audit takes no arguments")
+	}
+	ctx, cancel := c.context()
+	defer cancel()
+	return c.client.GetRuleAudit(ctx, &pb.Empty{})
+}
+
+func shelfSet(c *cli, args []string) (proto.Message, error) {
+	fs := flag.NewFlagSet("shelf set", flag.ContinueOnError)
+	id := fs.Int("id", 0, "The release to place")
+	instance := fs.Int("instance", 0, "The copy to place")
+	shelf := fs.String("shelf", "", "The shelf it's on")
+	position := fs.Int("position", 0, "Where it is on the shelf, counting from 1")
+	if err := parse(fs, args, false); err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.context()
+	defer cancel()
+	return c.client.SetShelfPosition(ctx, &pb.ShelfPosition{ReleaseId: int32(*id), InstanceId: int32(*instance), Shelf: *shelf, Position: int32(*position)})
+}
+
+// shelfOrderFlag adds the -order flag shared by shelf order and shelf insert
+func shelfOrderFlag(fs *flag.FlagSet) func() (pb.ShelfOrder, error) {
+	order := fs.String("order", "by_artist", "How to order the shelf: by_artist, by_label, by_date_added or custom")
+	return func() (pb.ShelfOrder, error) {
+		o, err := enumValue(pb.ShelfOrder_value, *order, "order")
+		return pb.ShelfOrder(o), err
+	}
+}
+
+func shelfOrder(c *cli, args []string) (proto.Message, error) {
+	fs := flag.NewFlagSet("shelf order", flag.ContinueOnError)
+	folder := fs.Int("folder", 0, "The folder to order")
+	order := shelfOrderFlag(fs)
+	apply := fs.Bool("apply", false, "Store the order as the shelf positions")
+	shelf := fs.String("shelf", "", "The shelf to store it as, the folder name when unset")
+	if err := parse(fs, args, false); err != nil {
+		return nil, err
+	}
+	o, err := order()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.context()
+	defer cancel()
+	return c.client.OrderFolder(ctx, &pb.OrderRequest{FolderId: int32(*folder), Order: o, Apply: *apply, Shelf: *shelf})
+}
+
+func shelfInsert(c *cli, args []string) (proto.Message, error) {
+	fs := flag.NewFlagSet("shelf insert", flag.ContinueOnError)
+	id := fs.Int("id", 0, "The new release")
+	folder := fs.Int("folder", 0, "The folder it's going into")
+	order := shelfOrderFlag(fs)
+	if err := parse(fs, args, false); err != nil {
+		return nil, err
+	}
+	o, err := order()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.context()
+	defer cancel()
+	return c.client.GetInsertionPoint(ctx, &pb.InsertionRequest{Release: &pbd.Release{Id: int32(*id)}, FolderId: int32(*folder), Order: o})
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"flag"
+
+	"github.com/golang/protobuf/proto"
+
+	pb "github.com/brotherlogic/discogssyncer/server"
+)
+
+func loanLend(c *cli, args []string) (proto.Message, error) {
+	fs := flag.NewFlagSet("loan lend", flag.ContinueOnError)
+	id := fs.Int("id", 0, "The release to lend")
+	instance := fs.Int("instance", 0, "The copy to lend")
+	borrower := fs.String("borrower", "", "Who's borrowing it")
+	due := fs.String("due", "", "When we want it back, as YYYY-MM-DD")
+	if err := parse(fs, args, false); err != nil {
+		return nil, err
+	}
+	dueBy, err := parseDay(*due)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.context()
+	defer cancel()
+	return c.client.LendRecord(ctx, &pb.Loan{ReleaseId: int32(*id), InstanceId: int32(*instance), Borrower: *borrower, Due: dueBy})
+}
+
+func loanReturn(c *cli, args []string) (proto.Message, error) {
+	fs := flag.NewFlagSet("loan return", flag.ContinueOnError)
+	id := fs.Int("id", 0, "The release that's come back")
+	instance := fs.Int("instance", 0, "The copy that's come back")
+	if err := parse(fs, args, false); err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.context()
+	defer cancel()
+	return c.client.ReturnRecord(ctx, &pb.Loan{ReleaseId: int32(*id), InstanceId: int32(*instance)})
+}
+
+func loanList(c *cli, args []string) (proto.Message, error) {
+	fs := flag.NewFlagSet("loan list", flag.ContinueOnError)
+	id := fs.Int("id", 0, "Only show loans of this release")
+	borrower := fs.String("borrower", "", "Only show loans to this borrower")
+	overdue := fs.Bool("overdue", false, "Only show loans past their due date")
+	all := fs.Bool("all", false, "Include loans which have come back")
+	if err := parse(fs, args, false); err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.context()
+	defer cancel()
+	return c.client.ListLoans(ctx, &pb.LoanRequest{ReleaseId: int32(*id), Borrower: *borrower, OverdueOnly: *overdue, IncludeReturned: *all})
+}
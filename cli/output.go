@@ -0,0 +1,159 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+
+	pb "github.com/brotherlogic/discogssyncer/server"
+	pbd "github.com/brotherlogic/godiscogs"
+)
+
+func printJSON(w io.Writer, res proto.Message) error {
+	m := &jsonpb.Marshaler{Indent: "  "}
+	data, err := m.MarshalToString(res)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, data)
+	return err
+}
+
+func date(v int64) string {
+	if v <= 0 {
+		return "-"
+	}
+	return time.Unix(v, 0).Format("2006-01-02")
+}
+
+func pounds(pence int32) string {
+	return fmt.Sprintf("%.2f", float64(pence)/100)
+}
+
+func releaseRows(tw io.Writer, releases []*pbd.Release) {
+	fmt.Fprintln(tw, "ID\tINSTANCE\tFOLDER\tRATING\tARTIST\tTITLE")
+	for _, r := range releases {
+		fmt.Fprintf(tw, "%v\t%v\t%v\t%v\t%v\t%v\n", r.Id, r.InstanceId, r.FolderId, r.Rating, pbd.GetReleaseArtist(*r), r.Title)
+	}
+}
+
+func wantRows(tw io.Writer, wants []*pb.Want) {
+	fmt.Fprintln(tw, "RELEASE\tMASTER\tPRIORITY\tMAX PRICE\tVALUED\tWANTED\tADDED\tNOTE")
+	for _, w := range wants {
+		fmt.Fprintf(tw, "%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\n", w.ReleaseId, w.MasterId, w.Priority, pounds(w.MaxPrice), w.Valued, w.Wanted, date(w.DateAdded), w.Note)
+	}
+}
+
+func metadataRows(tw io.Writer, m *pb.ReleaseMetadata) {
+	var tags []string
+	for _, t := range m.Tags {
+		tags = append(tags, t.Name)
+	}
+	fmt.Fprintf(tw, "ID\t%v\n", m.Id)
+	fmt.Fprintf(tw, "DATE ADDED\t%v\n", date(m.DateAdded))
+	fmt.Fprintf(tw, "REFRESHED\t%v\n", date(m.DateRefreshed))
+	fmt.Fprintf(tw, "TOUCHED\t%v\n", date(m.LastTouched))
+	fmt.Fprintf(tw, "COST\t%v\n", pounds(m.Cost))
+	fmt.Fprintf(tw, "OTHERS\t%v\n", m.Others)
+	fmt.Fprintf(tw, "FILE PATH\t%v\n", m.FilePath)
+	fmt.Fprintf(tw, "NOTES\t%v\n", m.Notes)
+	fmt.Fprintf(tw, "TAGS\t%v\n", strings.Join(tags, ", "))
+	for _, c := range m.Custom {
+		fmt.Fprintf(tw, "%v\t%v\n", strings.ToUpper(c.Name), c.Value)
+	}
+}
+
+// printTable lays out the results we know about as columns, anything else as proto text
+func printTable(w io.Writer, res proto.Message) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	switch r := res.(type) {
+	case *pb.Empty:
+		fmt.Fprintln(tw, "OK")
+	case *pb.ReleaseList:
+		releaseRows(tw, r.Releases)
+	case *pbd.Release:
+		releaseRows(tw, []*pbd.Release{r})
+	case *pb.RecordList:
+		fmt.Fprintln(tw, "ID\tINSTANCE\tFOLDER\tRATING\tADDED\tCOST\tARTIST\tTITLE")
+		for _, rec := range r.Records {
+			rel := rec.GetRelease()
+			if rel == nil {
+				continue
+			}
+			fmt.Fprintf(tw, "%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\n", rel.Id, rel.InstanceId, rel.FolderId, rel.Rating, date(rec.GetMetadata().GetDateAdded()), pounds(rec.GetMetadata().GetCost()), pbd.GetReleaseArtist(*rel), rel.Title)
+		}
+	case *pb.ReleaseMetadata:
+		metadataRows(tw, r)
+	case *pb.Wantlist:
+		wantRows(tw, r.Want)
+	case *pb.Want:
+		wantRows(tw, []*pb.Want{r})
+	case *pb.SpendResponse:
+		fmt.Fprintln(tw, "ID\tDATE ADDED\tCOST")
+		for _, s := range r.Spends {
+			fmt.Fprintf(tw, "%v\t%v\t%v\n", s.GetRelease().GetId(), date(s.GetUpdate().GetDateAdded()), pounds(s.GetUpdate().GetCost()))
+		}
+		fmt.Fprintf(tw, "TOTAL\t\t%v\n", pounds(r.TotalSpend))
+	case *pb.SyncResult:
+		fmt.Fprintf(tw, "Synced, %v wants fulfilled\n", len(r.Fulfilled))
+		if len(r.Fulfilled) > 0 {
+			wantRows(tw, r.Fulfilled)
+		}
+	case *pb.SellCandidateList:
+		fmt.Fprintln(tw, "ID\tINSTANCE\tVALUE\tSCORE\tTITLE\tREASONS")
+		for _, c := range r.Candidates {
+			fmt.Fprintf(tw, "%v\t%v\t%.2f\t%v\t%v\t%v\n", c.GetRelease().GetId(), c.GetRelease().GetInstanceId(), c.Value, c.Score, c.GetRelease().GetTitle(), strings.Join(c.Reasons, ", "))
+		}
+	case *pb.BulkSellResponse:
+		fmt.Fprintln(tw, "ID\tPRICE\tSOLD\tERROR")
+		for _, res := range r.Results {
+			fmt.Fprintf(tw, "%v\t%.2f\t%v\t%v\n", res.GetRelease().GetId(), res.Price, res.Success, res.Error)
+		}
+	case *pb.BulkResponse:
+		fmt.Fprintln(tw, "ID\tINSTANCE\tOK\tERROR")
+		for _, res := range r.Results {
+			fmt.Fprintf(tw, "%v\t%v\t%v\t%v\n", res.GetRelease().GetId(), res.GetRelease().GetInstanceId(), res.Success, res.Error)
+		}
+	case *pb.PlayList:
+		fmt.Fprintln(tw, "ID\tINSTANCE\tDATE\tSIDE\tTRACK\tLISTENER")
+		for _, p := range r.Plays {
+			fmt.Fprintf(tw, "%v\t%v\t%v\t%v\t%v\t%v\n", p.ReleaseId, p.InstanceId, date(p.Date), p.Side, p.Track, p.Listener)
+		}
+	case *pb.PlayCountList:
+		fmt.Fprintln(tw, "ID\tPLAYS\tLAST PLAYED\tTITLE")
+		for _, c := range r.Counts {
+			fmt.Fprintf(tw, "%v\t%v\t%v\t%v\n", c.GetRelease().GetId(), c.Count, date(c.LastPlayed), c.GetRelease().GetTitle())
+		}
+	case *pb.TagCountList:
+		fmt.Fprintln(tw, "TAG\tCOUNT")
+		for _, c := range r.Counts {
+			fmt.Fprintf(tw, "%v\t%v\n", c.Tag, c.Count)
+		}
+	case *pb.LoanList:
+		fmt.Fprintln(tw, "ID\tINSTANCE\tBORROWER\tLENT\tDUE\tRETURNED")
+		for _, l := range r.Loans {
+			fmt.Fprintf(tw, "%v\t%v\t%v\t%v\t%v\t%v\n", l.ReleaseId, l.InstanceId, l.Borrower, date(l.Lent), date(l.Due), date(l.Returned))
+		}
+	case *pb.ImportReport:
+		fmt.Fprintln(tw, "ID\tINSTANCE\tFIELD\tOLD\tNEW")
+		for _, c := range r.Changes {
+			fmt.Fprintf(tw, "%v\t%v\t%v\t%q\t%q\n", c.ReleaseId, c.InstanceId, c.Field, c.OldValue, c.NewValue)
+		}
+		for _, e := range r.Errors {
+			fmt.Fprintf(tw, "ERROR\t%v\n", e)
+		}
+	case *pb.RestoreReport:
+		fmt.Fprintln(tw, "KIND\tKEY\tACTION")
+		for _, c := range r.Changes {
+			fmt.Fprintf(tw, "%v\t%v\t%v\n", c.Kind, c.Key, c.Action)
+		}
+	default:
+		fmt.Fprint(tw, proto.MarshalTextString(res))
+	}
+	return tw.Flush()
+}
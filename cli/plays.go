@@ -0,0 +1,115 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+
+	"github.com/golang/protobuf/proto"
+
+	pb "github.com/brotherlogic/discogssyncer/server"
+)
+
+func play(c *cli, args []string) (proto.Message, error) {
+	fs := flag.NewFlagSet("play", flag.ContinueOnError)
+	id := fs.Int("id", 0, "The release we listened to")
+	instance := fs.Int("instance", 0, "The copy we listened to")
+	date := fs.String("date", "", "When we listened, as YYYY-MM-DD, defaults to now")
+	side := fs.String("side", "", "The side we listened to")
+	track := fs.Int("track", 0, "The track we listened to")
+	listener := fs.String("listener", "", "Who was listening")
+	if err := parse(fs, args, false); err != nil {
+		return nil, err
+	}
+	when, err := parseDay(*date)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.context()
+	defer cancel()
+	return c.client.RecordPlay(ctx, &pb.Play{ReleaseId: int32(*id), InstanceId: int32(*instance), Date: when, Side: *side, Track: int32(*track), Listener: *listener})
+}
+
+func playList(c *cli, args []string) (proto.Message, error) {
+	fs := flag.NewFlagSet("play list", flag.ContinueOnError)
+	id := fs.Int("id", 0, "Only show listens of this release")
+	instance := fs.Int("instance", 0, "Only show listens of this copy")
+	since := fs.String("since", "", "Only show listens from this date, as YYYY-MM-DD")
+	until := fs.String("until", "", "Only show listens up to this date, as YYYY-MM-DD")
+	listener := fs.String("listener", "", "Only show listens by this listener")
+	if err := parse(fs, args, false); err != nil {
+		return nil, err
+	}
+	req := &pb.PlayRequest{ReleaseId: int32(*id), InstanceId: int32(*instance), Listener: *listener}
+	var err error
+	if req.Since, err = parseDay(*since); err != nil {
+		return nil, err
+	}
+	if req.Until, err = parseDay(*until); err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.context()
+	defer cancel()
+	return c.client.GetPlays(ctx, req)
+}
+
+func playCounts(c *cli, args []string) (proto.Message, error) {
+	fs := flag.NewFlagSet("play counts", flag.ContinueOnError)
+	least := fs.Bool("least", false, "Put the least played records first")
+	limit := fs.Int("limit", 0, "The most records to show, all of them when unset")
+	if err := parse(fs, args, false); err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.context()
+	defer cancel()
+	return c.client.GetPlayCounts(ctx, &pb.PlayCountRequest{LeastFirst: *least, Limit: int32(*limit)})
+}
+
+func playNever(c *cli, args []string) (proto.Message, error) {
+	fs := flag.NewFlagSet("play never", flag.ContinueOnError)
+	owned := fs.Int("owned_days", 0, "Only show records we've had for this many days")
+	if err := parse(fs, args, false); err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.context()
+	defer cancel()
+	return c.client.GetNeverPlayed(ctx, &pb.NeverPlayedRequest{OwnedFor: int64(*owned) * day})
+}
+
+func playStreaks(c *cli, args []string) (proto.Message, error) {
+	fs := flag.NewFlagSet("play streaks", flag.ContinueOnError)
+	listener := fs.String("listener", "", "Only count listens by this listener")
+	if err := parse(fs, args, false); err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.context()
+	defer cancel()
+	return c.client.GetStreaks(ctx, &pb.StreakRequest{Listener: *listener})
+}
+
+func suggest(c *cli, args []string) (proto.Message, error) {
+	fs := flag.NewFlagSet("suggest", flag.ContinueOnError)
+	folders := fs.String("folders", "", "Comma separated folder ids to pick from, the whole collection when unset")
+	strategy := fs.String("strategy", "least_recently_suggested", "How to pick: least_recently_suggested, weighted_by_rating, newest_first or random")
+	genre := fs.String("genre", "", "Only pick records in this genre")
+	style := fs.String("style", "", "Only pick records in this style")
+	avoid := fs.Int("avoid_days", 0, "Don't repeat anything suggested in this many days")
+	if err := parse(fs, args, false); err != nil {
+		return nil, err
+	}
+	s, err := enumValue(pb.SuggestionStrategy_value, *strategy, "strategy")
+	if err != nil {
+		return nil, err
+	}
+	req := &pb.SuggestionRequest{Strategy: pb.SuggestionStrategy(s), Genre: *genre, Style: *style, AvoidWindow: int64(*avoid) * day}
+	for _, f := range splitList(*folders) {
+		id, err := strconv.Atoi(f)
+		if err != nil {
+			return nil, fmt.Errorf("Bad folder %v", f)
+		}
+		req.Folders = append(req.Folders, int32(id))
+	}
+	ctx, cancel := c.context()
+	defer cancel()
+	return c.client.GetListeningSuggestion(ctx, req)
+}
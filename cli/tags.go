@@ -0,0 +1,107 @@
+package main
+
+import (
+	"errors"
+	"flag"
+
+	"github.com/golang/protobuf/proto"
+
+	pb "github.com/brotherlogic/discogssyncer/server"
+	pbd "github.com/brotherlogic/godiscogs"
+)
+
+// tagFlags reads the release and tags shared by tag add and tag remove
+func tagFlags(name string, args []string) (*pb.TagRequest, error) {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	id := fs.Int("id", 0, "The release to tag")
+	instance := fs.Int("instance", 0, "Only tag this copy of the release")
+	if err := parse(fs, args, true); err != nil {
+		return nil, err
+	}
+	if *id == 0 || fs.NArg() == 0 {
+		return nil, errors.New(name + " needs -id and at least one tag")
+	}
+	return &pb.TagRequest{Release: &pbd.Release{Id: int32(*id)}, InstanceId: int32(*instance), Tags: fs.Args()}, nil
+}
+
+func tagAdd(c *cli, args []string) (proto.Message, error) {
+	req, err := tagFlags("tag add", args)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.context()
+	defer cancel()
+	return c.client.AddTags(ctx, req)
+}
+
+func tagRemove(c *cli, args []string) (proto.Message, error) {
+	req, err := tagFlags("tag remove", args)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.context()
+	defer cancel()
+	return c.client.RemoveTags(ctx, req)
+}
+
+func tagList(c *cli, args []string) (proto.Message, error) {
+	if len(args) > 0 {
+		return nil, errors.New("tag list takes no arguments")
+	}
+	ctx, cancel := c.context()
+	defer cancel()
+	return c.client.ListTags(ctx, &pb.Empty{})
+}
+
+func tagReleases(c *cli, args []string) (proto.Message, error) {
+	if len(args) == 0 {
+		return nil, errors.New("Name at least one tag")
+	}
+	ctx, cancel := c.context()
+	defer cancel()
+	return c.client.GetReleasesByTag(ctx, &pb.TagQuery{Tags: args})
+}
+
+func tagRename(c *cli, args []string) (proto.Message, error) {
+	if len(args) != 2 {
+		return nil, errors.New("tag rename needs the tag and its new name")
+	}
+	ctx, cancel := c.context()
+	defer cancel()
+	return c.client.RenameTag(ctx, &pb.TagRename{From: args[0], To: args[1]})
+}
+
+func fieldList(c *cli, args []string) (proto.Message, error) {
+	if len(args) > 0 {
+		return nil, errors.New("field list takes no arguments")
+	}
+	ctx, cancel := c.context()
+	defer cancel()
+	return c.client.GetFields(ctx, &pb.Empty{})
+}
+
+func fieldAdd(c *cli, args []string) (proto.Message, error) {
+	fs := flag.NewFlagSet("field add", flag.ContinueOnError)
+	name := fs.String("name", "", "The name of the field")
+	fieldType := fs.String("type", "string", "The type of the field: string, int, date, enum or bool")
+	options := fs.String("options", "", "Comma separated values an enum field can take")
+	if err := parse(fs, args, false); err != nil {
+		return nil, err
+	}
+	t, err := enumValue(pb.FieldType_value, *fieldType, "type")
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.context()
+	defer cancel()
+	return c.client.AddField(ctx, &pb.FieldDefinition{Name: *name, Type: pb.FieldType(t), Options: splitList(*options)})
+}
+
+func fieldDelete(c *cli, args []string) (proto.Message, error) {
+	if len(args) != 1 {
+		return nil, errors.New("field delete needs a single field name")
+	}
+	ctx, cancel := c.context()
+	defer cancel()
+	return c.client.DeleteField(ctx, &pb.FieldDefinition{Name: args[0]})
+}
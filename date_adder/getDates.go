@@ -1,117 +1,122 @@
 package main
 
 import (
-	"bufio"
+	"flag"
+	"fmt"
 	"log"
 	"os"
-	"regexp"
-	"strconv"
+	"strings"
 	"time"
 
+	"github.com/brotherlogic/discogssyncer/dial"
+	"github.com/brotherlogic/discogssyncer/discogscsv"
+	"github.com/brotherlogic/goserver/utils"
 	"golang.org/x/net/context"
-	"google.golang.org/grpc"
 
 	pb "github.com/brotherlogic/discogssyncer/server"
-	pbdi "github.com/brotherlogic/discovery/proto"
 	pbd "github.com/brotherlogic/godiscogs"
 )
 
-func getIP(servername string, ip string, port int) (string, int) {
-	conn, _ := grpc.Dial(ip+":"+strconv.Itoa(port), grpc.WithInsecure())
-	defer conn.Close()
-
-	registry := pbdi.NewDiscoveryServiceClient(conn)
-	entry := pbdi.RegistryEntry{Name: servername}
-	r, _ := registry.Discover(context.Background(), &entry)
-	return r.Ip, int(r.Port)
+// shouldSet decides whether a found date replaces the current one
+func shouldSet(policy string, current int64, found time.Time) bool {
+	if current == found.Unix() {
+		return false
+	}
+	switch policy {
+	case "missing":
+		return current <= 0
+	case "earliest":
+		return current <= 0 || found.Unix() < current
+	case "overwrite":
+		return true
+	}
+	return false
 }
 
-func setDate(releaseID int, folderID int, date string) {
-	log.Printf("Setting %v -> %v", releaseID, date)
-	layout := "02-Jan-06 03:04 PM"
-	t, err := time.Parse(layout, date)
-	if err != nil {
-		log.Fatal(err)
-	}
+type backfiller struct {
+	client pb.DiscogsServiceClient
+	policy string
+	dryRun bool
 
-	release := &pbd.Release{Id: int32(releaseID), FolderId: int32(folderID)}
-	metadata := &pb.ReleaseMetadata{DateAdded: t.Unix()}
-	update := &pb.MetadataUpdate{Release: release, Update: metadata}
+	changed []string
+	skipped int
+	failed  []error
+}
 
-	server, port := getIP("discogssyncer", "10.0.1.17", 50055)
-	conn, err := grpc.Dial(server+":"+strconv.Itoa(port), grpc.WithInsecure())
-	if err != nil {
-		log.Fatal(err)
-	}
-	defer conn.Close()
+func (b *backfiller) apply(d dated) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
 
-	client := pb.NewDiscogsServiceClient(conn)
-	r, err := client.UpdateMetadata(context.Background(), update)
+	release := &pbd.Release{Id: int32(d.releaseID), FolderId: int32(d.folderID)}
+	current, err := b.client.GetMetadata(ctx, release)
 	if err != nil {
-		log.Fatal(err)
+		b.failed = append(b.failed, fmt.Errorf("%v: release %v: %v", d.origin, d.releaseID, err))
+		return
 	}
 
-	log.Printf("Moved %v", r)
-}
+	if !shouldSet(b.policy, current.DateAdded, d.date) {
+		b.skipped++
+		return
+	}
 
-func processFile(filename string) {
-	file, err := os.Open(filename)
-	if err != nil {
-		log.Fatal(err)
+	if !b.dryRun {
+		_, err = b.client.UpdateMetadata(ctx, &pb.MetadataUpdate{Release: release, Update: &pb.ReleaseMetadata{DateAdded: d.date.Unix(), Others: current.Others}})
+		if err != nil {
+			b.failed = append(b.failed, fmt.Errorf("%v: release %v: %v", d.origin, d.releaseID, err))
+			return
+		}
 	}
 
-	defer file.Close()
+	old := "unset"
+	if current.DateAdded > 0 {
+		old = time.Unix(current.DateAdded, 0).Format(discogscsv.DateFormat)
+	}
+	b.changed = append(b.changed, fmt.Sprintf("%v: %v -> %v (%v)", d.releaseID, old, d.date.Format(discogscsv.DateFormat), d.origin))
+}
 
-	regger, err := regexp.Compile("/release/(\\d*?)\"")
-	if err != nil {
-		log.Fatal(err)
+func main() {
+	var input = flag.String("input", "", "Comma separated HTML pages or discogs CSV exports to read dates from")
+	var source = flag.String("source", "auto", "How to read the inputs: auto, html or csv")
+	var policy = flag.String("policy", "missing", "Which dates to set: missing, earliest (missing or earlier than ours) or overwrite")
+	var server = flag.String("server", "", "host:port of the syncer, found through discovery if not set")
+	var discovery = flag.String("discovery", utils.Discover, "host:port of the discovery server")
+	var dryRun = flag.Bool("dry_run", false, "Report what would change without changing anything")
+	flag.Parse()
+
+	if *input == "" {
+		log.Fatalf("Nothing to read, set -input")
 	}
-	reggerd, err := regexp.Compile("data-header=\"Added\".*span title=\"(.*?)\"")
-	if err != nil {
-		log.Fatal(err)
+	if !shouldSet(*policy, 0, time.Now()) {
+		log.Fatalf("Unknown policy %v", *policy)
 	}
 
-	reggerf, err := regexp.Compile("\\?folder=(\\d+)")
+	conn, err := dial.Syncer(*server, *discovery)
 	if err != nil {
-		log.Fatal(err)
+		log.Fatalf("Unable to dial server: %v", err)
 	}
+	defer conn.Close()
 
-	releaseID := ""
-	folderID := ""
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		text := scanner.Text()
-		results := regger.FindStringSubmatch(text)
-		if results != nil {
-			releaseID = results[1]
-		}
-		results3 := reggerf.FindStringSubmatch(text)
-		if results3 != nil {
-			folderID = results3[1]
+	b := &backfiller{client: pb.NewDiscogsServiceClient(conn), policy: *policy, dryRun: *dryRun}
+	for _, filename := range strings.Split(*input, ",") {
+		dates, errs := readDates(filename, *source)
+		b.failed = append(b.failed, errs...)
+		for _, d := range dates {
+			b.apply(d)
 		}
-		results2 := reggerd.FindStringSubmatch(text)
-		if results2 != nil {
-			strDate := results2[1]
-			relID, _ := strconv.Atoi(releaseID)
-			folID, _ := strconv.Atoi(folderID)
-			setDate(relID, folID, strDate)
-		}
-
 	}
 
-	if err := scanner.Err(); err != nil {
-		log.Fatal(err)
+	verb := "Changed"
+	if *dryRun {
+		verb = "Would change"
+	}
+	fmt.Printf("%v %v dates, left %v alone, %v failures\n", verb, len(b.changed), b.skipped, len(b.failed))
+	for _, c := range b.changed {
+		fmt.Printf("  %v\n", c)
+	}
+	for _, err := range b.failed {
+		fmt.Printf("  FAILED %v\n", err)
+	}
+	if len(b.failed) > 0 {
+		os.Exit(1)
 	}
-}
-
-func main() {
-	processFile("data/1.htm")
-	processFile("data/2.htm")
-	processFile("data/3.htm")
-	processFile("data/4.htm")
-	processFile("data/5.htm")
-	processFile("data/6.htm")
-	processFile("data/7.htm")
-	processFile("data/8.htm")
-
 }
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/brotherlogic/discogssyncer/discogscsv"
+)
+
+// dated is a date added we've found for a release, origin says where we found it
+type dated struct {
+	releaseID int
+	folderID  int
+	date      time.Time
+	origin    string
+}
+
+var (
+	releaseRegex = regexp.MustCompile("/release/(\\d*?)\"")
+	addedRegex   = regexp.MustCompile("data-header=\"Added\".*span title=\"(.*?)\"")
+	folderRegex  = regexp.MustCompile("\\?folder=(\\d+)")
+)
+
+const htmlLayout = "02-Jan-06 03:04 PM"
+
+// sourceFor works out how to read a file, going by its extension unless told otherwise
+func sourceFor(filename, source string) string {
+	if source != "auto" {
+		return source
+	}
+	if strings.ToLower(filepath.Ext(filename)) == ".csv" {
+		return "csv"
+	}
+	return "html"
+}
+
+// readDates pulls the dates out of a file, along with anything we couldn't read
+func readDates(filename, source string) ([]dated, []error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, []error{err}
+	}
+	defer file.Close()
+
+	switch sourceFor(filename, source) {
+	case "html":
+		return readHTML(filename, file)
+	case "csv":
+		return readCSV(filename, file)
+	}
+	return nil, []error{fmt.Errorf("%v: unknown source %v", filename, source)}
+}
+
+// readHTML scrapes a saved page of the discogs collection view
+func readHTML(filename string, r io.Reader) ([]dated, []error) {
+	var dates []dated
+	var errs []error
+
+	releaseID := ""
+	folderID := ""
+	line := 0
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line++
+		text := scanner.Text()
+		if results := releaseRegex.FindStringSubmatch(text); results != nil {
+			releaseID = results[1]
+		}
+		if results := folderRegex.FindStringSubmatch(text); results != nil {
+			folderID = results[1]
+		}
+		if results := addedRegex.FindStringSubmatch(text); results != nil {
+			origin := fmt.Sprintf("%v:%v", filename, line)
+			relID, err := strconv.Atoi(releaseID)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%v: no release for date %v", origin, results[1]))
+				continue
+			}
+			// The page shows local times, as the CSV export does
+			t, err := time.ParseInLocation(htmlLayout, results[1], time.Local)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%v: %v", origin, err))
+				continue
+			}
+			folID, _ := strconv.Atoi(folderID)
+			dates = append(dates, dated{releaseID: relID, folderID: folID, date: t, origin: origin})
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		errs = append(errs, fmt.Errorf("%v: %v", filename, err))
+	}
+	return dates, errs
+}
+
+// readCSV reads the discogs collection CSV export
+func readCSV(filename string, r io.Reader) ([]dated, []error) {
+	cols, records, err := discogscsv.Read(r)
+	if err != nil {
+		return nil, []error{fmt.Errorf("%v: %v", filename, err)}
+	}
+	if _, ok := cols["date added"]; !ok {
+		return nil, []error{fmt.Errorf("%v: needs a Date Added column", filename)}
+	}
+
+	var dates []dated
+	var errs []error
+	for i, record := range records {
+		// Line numbers count the header
+		row, err := cols.ParseRow(record, i+2)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%v: %v", filename, err))
+			continue
+		}
+		if row.DateAdded == 0 {
+			continue
+		}
+		dates = append(dates, dated{releaseID: int(row.ReleaseID), date: time.Unix(row.DateAdded, 0), origin: fmt.Sprintf("%v:%v", filename, row.Line)})
+	}
+	return dates, errs
+}
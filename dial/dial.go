@@ -0,0 +1,44 @@
+// Package dial connects the command line tools to the syncer
+package dial
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+
+	pbdi "github.com/brotherlogic/discovery/proto"
+)
+
+// FindServer asks the discovery server at discovery where name is running
+func FindServer(discovery, name string) (string, int, error) {
+	conn, err := grpc.Dial(discovery, grpc.WithInsecure())
+	if err != nil {
+		return "", -1, fmt.Errorf("Cannot reach discover server: %v (trying to discover %v)", err, name)
+	}
+	defer conn.Close()
+
+	registry := pbdi.NewDiscoveryServiceClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	r, err := registry.Discover(ctx, &pbdi.RegistryEntry{Name: name})
+	if err != nil {
+		return "", -1, fmt.Errorf("Unable to find %v: %v", name, err)
+	}
+	return r.Ip, int(r.Port), nil
+}
+
+// Syncer connects to the syncer at server, or wherever discovery says it is if server is empty
+func Syncer(server, discovery string) (*grpc.ClientConn, error) {
+	if server == "" {
+		host, port, err := FindServer(discovery, "discogssyncer")
+		if err != nil {
+			return nil, err
+		}
+		server = host + ":" + strconv.Itoa(port)
+	}
+	return grpc.Dial(server, grpc.WithInsecure())
+}
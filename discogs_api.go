@@ -0,0 +1,173 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	pbd "github.com/brotherlogic/godiscogs"
+)
+
+const discogsAPIURL = "https://api.discogs.com"
+
+// discogsAPI fills in the calls we need which godiscogs doesn't make, going straight to
+// the discogs API; everything else goes through the retriever
+type discogsAPI struct {
+	*pbd.DiscogsRetriever
+	token  string
+	base   string
+	client *http.Client
+
+	// The folder calls are made against the user who owns the token
+	userM *sync.Mutex
+	user  string
+}
+
+// discogsAPI has to be a full saver on top of the upstream retriever
+var _ saver = &discogsAPI{}
+
+func newDiscogsAPI(token string) *discogsAPI {
+	return &discogsAPI{DiscogsRetriever: pbd.NewDiscogsRetriever(token), token: token, base: discogsAPIURL, client: &http.Client{}, userM: &sync.Mutex{}}
+}
+
+// call makes a request of the API, decoding the reply into out if that's set
+func (d *discogsAPI) call(method, path string, in, out interface{}) error {
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, d.base+path, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Discogs token="+d.token)
+	req.Header.Set("User-Agent", "discogssyncer")
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("Discogs returned %v for %v %v", resp.Status, method, path)
+	}
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+func (d *discogsAPI) username() (string, error) {
+	d.userM.Lock()
+	defer d.userM.Unlock()
+	if len(d.user) == 0 {
+		identity := struct {
+			Username string `json:"username"`
+		}{}
+		if err := d.call("GET", "/oauth/identity", nil, &identity); err != nil {
+			return "", err
+		}
+		d.user = identity.Username
+	}
+	return d.user, nil
+}
+
+func (d *discogsAPI) foldersPath() (string, error) {
+	user, err := d.username()
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("/users/%v/collection/folders", user), nil
+}
+
+// CreateFolder creates a new collection folder
+func (d *discogsAPI) CreateFolder(name string) (pbd.Folder, error) {
+	path, err := d.foldersPath()
+	if err != nil {
+		return pbd.Folder{}, err
+	}
+	folder := struct {
+		ID   int32  `json:"id"`
+		Name string `json:"name"`
+	}{}
+	if err := d.call("POST", path, map[string]string{"name": name}, &folder); err != nil {
+		return pbd.Folder{}, err
+	}
+	return pbd.Folder{Id: folder.ID, Name: folder.Name}, nil
+}
+
+// RenameFolder renames a collection folder
+func (d *discogsAPI) RenameFolder(folderID int, name string) error {
+	path, err := d.foldersPath()
+	if err != nil {
+		return err
+	}
+	return d.call("POST", fmt.Sprintf("%v/%v", path, folderID), map[string]string{"name": name}, nil)
+}
+
+// DeleteFolder deletes a collection folder, which discogs only allows once it's empty
+func (d *discogsAPI) DeleteFolder(folderID int) error {
+	path, err := d.foldersPath()
+	if err != nil {
+		return err
+	}
+	return d.call("DELETE", fmt.Sprintf("%v/%v", path, folderID), nil, nil)
+}
+
+type masterVersions struct {
+	Pagination struct {
+		Pages int `json:"pages"`
+	} `json:"pagination"`
+	Versions []struct {
+		ID           int32    `json:"id"`
+		Title        string   `json:"title"`
+		Label        string   `json:"label"`
+		Catno        string   `json:"catno"`
+		Country      string   `json:"country"`
+		Released     string   `json:"released"`
+		Format       string   `json:"format"`
+		MajorFormats []string `json:"major_formats"`
+	} `json:"versions"`
+}
+
+// GetMasterReleases lists every pressing of a master
+func (d *discogsAPI) GetMasterReleases(masterID int) ([]pbd.Release, error) {
+	var releases []pbd.Release
+	for page := 1; ; page++ {
+		versions := &masterVersions{}
+		if err := d.call("GET", fmt.Sprintf("/masters/%v/versions?per_page=100&page=%v", masterID, page), nil, versions); err != nil {
+			return nil, err
+		}
+
+		for _, v := range versions.Versions {
+			rel := pbd.Release{Id: v.ID, Title: v.Title, Country: v.Country, Released: v.Released, MasterId: int32(masterID)}
+			if len(v.Label) > 0 {
+				rel.Labels = []*pbd.Label{&pbd.Label{Name: v.Label, Catno: v.Catno}}
+			}
+			var descriptions []string
+			if len(v.Format) > 0 {
+				descriptions = strings.Split(v.Format, ", ")
+			}
+			for _, f := range v.MajorFormats {
+				rel.Formats = append(rel.Formats, &pbd.Format{Name: f, Descriptions: descriptions})
+			}
+			releases = append(releases, rel)
+		}
+
+		if page >= versions.Pagination.Pages {
+			return releases, nil
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// fakeDiscogs serves just enough of the discogs API for discogsAPI, recording what it's asked
+type fakeDiscogs struct {
+	calls []string
+}
+
+func (f *fakeDiscogs) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.calls = append(f.calls, r.Method+" "+r.URL.RequestURI())
+	if r.Header.Get("Authorization") != "Discogs token=testtoken" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	switch {
+	case r.URL.Path == "/oauth/identity":
+		fmt.Fprint(w, `{"username": "tester"}`)
+	case r.Method == "POST" && r.URL.Path == "/users/tester/collection/folders":
+		body := map[string]string{}
+		json.NewDecoder(r.Body).Decode(&body)
+		fmt.Fprintf(w, `{"id": 77, "name": %q}`, body["name"])
+	case r.URL.Path == "/users/tester/collection/folders/77":
+		w.WriteHeader(http.StatusNoContent)
+	case r.URL.Path == "/masters/245/versions" && r.URL.Query().Get("page") == "1":
+		fmt.Fprint(w, `{"pagination": {"pages": 2}, "versions": [{"id": 30, "title": "One", "country": "UK", "released": "1972", "format": "LP, Album", "major_formats": ["Vinyl"], "label": "Island", "catno": "ILPS 1"}]}`)
+	case r.URL.Path == "/masters/245/versions":
+		fmt.Fprint(w, `{"pagination": {"pages": 2}, "versions": [{"id": 32, "title": "One", "country": "US", "released": "1973", "major_formats": ["CD"]}]}`)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func testDiscogsAPI() (*discogsAPI, *fakeDiscogs, func()) {
+	fake := &fakeDiscogs{}
+	server := httptest.NewServer(fake)
+	api := newDiscogsAPI("testtoken")
+	api.base = server.URL
+	return api, fake, server.Close
+}
+
+func TestDiscogsAPIFolders(t *testing.T) {
+	api, fake, done := testDiscogsAPI()
+	defer done()
+
+	folder, err := api.CreateFolder("Jazz")
+	if err != nil || folder.Id != 77 || folder.Name != "Jazz" {
+		t.Fatalf("Bad folder: %v (%v)", folder, err)
+	}
+	if err := api.RenameFolder(77, "Blue Note"); err != nil {
+		t.Errorf("Error renaming folder: %v", err)
+	}
+	if err := api.DeleteFolder(77); err != nil {
+		t.Errorf("Error deleting folder: %v", err)
+	}
+	if err := api.DeleteFolder(78); err == nil {
+		t.Errorf("Missing folder has been deleted")
+	}
+
+	// The user is only looked up once
+	if len(fake.calls) != 5 || fake.calls[0] != "GET /oauth/identity" || fake.calls[3] != "DELETE /users/tester/collection/folders/77" {
+		t.Errorf("Bad calls: %v", fake.calls)
+	}
+}
+
+func TestDiscogsAPIMasterReleases(t *testing.T) {
+	api, _, done := testDiscogsAPI()
+	defer done()
+
+	releases, err := api.GetMasterReleases(245)
+	if err != nil {
+		t.Fatalf("Error getting pressings: %v", err)
+	}
+	if len(releases) != 2 || releases[0].MasterId != 245 || releases[1].Id != 32 {
+		t.Fatalf("Bad pressings: %v", releases)
+	}
+	if len(releases[0].Labels) != 1 || len(releases[0].Formats) != 1 || releases[0].Formats[0].Descriptions[0] != "LP" {
+		t.Errorf("Pressing details have been lost: %v", releases[0])
+	}
+}
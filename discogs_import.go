@@ -0,0 +1,174 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/brotherlogic/discogssyncer/discogscsv"
+	pb "github.com/brotherlogic/discogssyncer/server"
+	pbd "github.com/brotherlogic/godiscogs"
+)
+
+// statusMessage drops the code from a status error, the report already says what failed
+func statusMessage(err error) string {
+	st, _ := status.FromError(err)
+	return st.Message()
+}
+
+// matchInstance picks the local copy a row refers to; the export has no instance ids so
+// we prefer an unclaimed copy in the named folder, then any unclaimed copy
+func (syncer *Syncer) matchInstance(row *discogscsv.Row, claimed map[int32]bool) *pbd.Release {
+	var fallback *pbd.Release
+	for _, f := range syncer.collection.Folders {
+		for _, r := range f.GetReleases().GetReleases() {
+			if r.Id != row.ReleaseID || claimed[r.InstanceId] {
+				continue
+			}
+			if f.Folder.Name == row.Folder {
+				return r
+			}
+			if fallback == nil {
+				fallback = r
+			}
+		}
+	}
+	return fallback
+}
+
+func (syncer *Syncer) folderByName(name string) *pb.CollectionFolder {
+	for _, f := range syncer.collection.Folders {
+		if f.Folder.Name == name {
+			return f
+		}
+	}
+	return nil
+}
+
+func importChange(rel *pbd.Release, field, oldValue, newValue string) *pb.ImportChange {
+	return &pb.ImportChange{ReleaseId: rel.Id, InstanceId: rel.InstanceId, Field: field, OldValue: oldValue, NewValue: newValue}
+}
+
+// diffRow works out what importing a row would change
+func (syncer *Syncer) diffRow(row *discogscsv.Row, rel *pbd.Release) ([]*pb.ImportChange, error) {
+	var changes []*pb.ImportChange
+	md := syncer.findMetadata(rel.Id)
+
+	if row.DateAdded > 0 && row.DateAdded != md.GetDateAdded() {
+		changes = append(changes, importChange(rel, "date_added", formatDate(md.GetDateAdded(), discogscsv.DateFormat), formatDate(row.DateAdded, discogscsv.DateFormat)))
+	}
+	if row.HasRating && row.Rating != rel.Rating {
+		changes = append(changes, importChange(rel, "rating", itoa(rel.Rating), itoa(row.Rating)))
+	}
+	if len(row.Folder) > 0 {
+		folder := syncer.folderByName(row.Folder)
+		if folder == nil {
+			return nil, status.Errorf(codes.InvalidArgument, "Line %v: unknown folder %q", row.Line, row.Folder)
+		}
+		if folder.Folder.Id != rel.FolderId {
+			old := ""
+			if f := syncer.findFolder(rel.FolderId); f != nil {
+				old = f.Folder.Name
+			}
+			changes = append(changes, importChange(rel, "folder", old, row.Folder))
+		}
+	}
+	if row.Notes != md.GetNotes() && len(row.Notes) > 0 {
+		changes = append(changes, importChange(rel, "notes", md.GetNotes(), row.Notes))
+	}
+
+	return changes, nil
+}
+
+// applyRow pushes the changes for a row, metadata first since a move changes the folder
+func (syncer *Syncer) applyRow(row *discogscsv.Row, rel *pbd.Release, changes []*pb.ImportChange) error {
+	update := &pb.ReleaseMetadata{}
+	updated := false
+	for _, c := range changes {
+		switch c.Field {
+		case "date_added":
+			update.DateAdded = row.DateAdded
+			updated = true
+		case "notes":
+			update.Notes = row.Notes
+			updated = true
+		}
+	}
+	if updated {
+		// Others is a manual boolean, so carry the current value across
+		update.Others = syncer.findMetadata(rel.Id).GetOthers()
+		if _, err := syncer.doMetadataUpdate(&pb.MetadataUpdate{Release: rel, Update: update}); err != nil {
+			return err
+		}
+		syncer.touch(rel.Id)
+	}
+
+	for _, c := range changes {
+		switch c.Field {
+		case "rating":
+			syncer.throttle()
+			syncer.retr.SetRating(int(rel.FolderId), int(rel.Id), int(rel.InstanceId), int(row.Rating))
+			rel.Rating = row.Rating
+			syncer.touch(rel.Id)
+		case "folder":
+			folder := syncer.folderByName(row.Folder)
+			if err := syncer.moveRelease(&pb.ReleaseMove{Release: rel, NewFolderId: folder.Folder.Id}, false, true); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// ImportDiscogsCSV applies the discogs collection export to the local collection
+func (syncer *Syncer) ImportDiscogsCSV(ctx context.Context, in *pb.DiscogsImportRequest) (*pb.ImportReport, error) {
+	t := time.Now()
+
+	cols, records, err := discogscsv.Read(strings.NewReader(in.Csv))
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	report := &pb.ImportReport{}
+	claimed := make(map[int32]bool)
+	for i, record := range records {
+		// Line numbers count the header
+		row, err := cols.ParseRow(record, i+2)
+		if err != nil {
+			report.Errors = append(report.Errors, statusMessage(err))
+			continue
+		}
+
+		rel := syncer.matchInstance(row, claimed)
+		if rel == nil {
+			report.Errors = append(report.Errors, fmt.Sprintf("Line %v: no local copy of %v", row.Line, row.ReleaseID))
+			continue
+		}
+		claimed[rel.InstanceId] = true
+
+		changes, err := syncer.diffRow(row, rel)
+		if err != nil {
+			report.Errors = append(report.Errors, statusMessage(err))
+			continue
+		}
+
+		if !in.DryRun && len(changes) > 0 {
+			if err := syncer.applyRow(row, rel, changes); err != nil {
+				report.Errors = append(report.Errors, fmt.Sprintf("Line %v: %v", row.Line, err))
+				continue
+			}
+		}
+		report.Changes = append(report.Changes, changes...)
+	}
+
+	if !in.DryRun {
+		syncer.saveCollection()
+	}
+	syncer.LogFunction("ImportDiscogsCSV", t)
+	return report, nil
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+
+	"github.com/brotherlogic/discogssyncer/discogscsv"
+	pb "github.com/brotherlogic/discogssyncer/server"
+)
+
+const testDiscogsCSV = `Catalog#,Artist,Title,Label,Format,Rating,Released,release_id,CollectionFolder,Date Added,Collection Media Condition,Collection Sleeve Condition,Collection Notes
+ABC1,Slint,Spiderland,Touch And Go,LP,4,1991,25,TestingTwo,2017-03-04 10:11:12,Mint (M),Mint (M),"Signed, by the band"
+ABC2,Someone,Something,Label,LP,,1991,32,Testing,,,,
+ABC3,Nobody,Nothing,Label,LP,,1991,999,Testing,,,,
+ABC4,Someone,Something,Label,LP,,1991,32,Missing,,,,
+`
+
+func TestImportDiscogsCSVDryRun(t *testing.T) {
+	syncer := GetTestSyncer(".testimportdryrun", true)
+	syncer.SaveCollection()
+
+	report, err := syncer.ImportDiscogsCSV(context.Background(), &pb.DiscogsImportRequest{Csv: testDiscogsCSV, DryRun: true})
+	if err != nil {
+		t.Fatalf("Error importing: %v", err)
+	}
+
+	fields := make(map[string]*pb.ImportChange)
+	for _, c := range report.Changes {
+		if c.ReleaseId != 25 || c.InstanceId != 1234 {
+			t.Errorf("Change for the wrong release: %v", c)
+		}
+		fields[c.Field] = c
+	}
+	if len(fields) != 4 || fields["folder"].OldValue != "Testing" || fields["folder"].NewValue != "TestingTwo" || fields["notes"].NewValue != "Signed, by the band" || fields["rating"].NewValue != "4" {
+		t.Errorf("Bad changes: %v", report.Changes)
+	}
+	if len(report.Errors) != 2 {
+		t.Errorf("Expected the missing release and the second copy to fail: %v", report.Errors)
+	}
+
+	if len(syncer.getReleases(25).GetReleases()) != 0 || syncer.findMetadata(25).GetNotes() != "" {
+		t.Errorf("Dry run has changed the collection")
+	}
+}
+
+func TestImportDiscogsCSV(t *testing.T) {
+	syncer := GetTestSyncer(".testimport", true)
+	syncer.SaveCollection()
+
+	_, err := syncer.ImportDiscogsCSV(context.Background(), &pb.DiscogsImportRequest{Csv: testDiscogsCSV})
+	if err != nil {
+		t.Fatalf("Error importing: %v", err)
+	}
+
+	rel := syncer.findInstance(25, 1234)
+	if rel == nil || rel.FolderId != 25 || rel.Rating != 4 {
+		t.Errorf("Release has not been updated: %v", rel)
+	}
+
+	added, _ := time.ParseInLocation(discogscsv.DateFormat, "2017-03-04 10:11:12", time.Local)
+	md := syncer.findMetadata(25)
+	if md.DateAdded != added.Unix() || md.Notes != "Signed, by the band" {
+		t.Errorf("Metadata has not been updated: %v", md)
+	}
+
+	// A second run should have nothing left to do
+	report, _ := syncer.ImportDiscogsCSV(context.Background(), &pb.DiscogsImportRequest{Csv: testDiscogsCSV, DryRun: true})
+	if len(report.Changes) != 0 {
+		t.Errorf("Import has not settled: %v", report.Changes)
+	}
+}
+
+func TestImportDiscogsCSVBadInput(t *testing.T) {
+	syncer := GetTestSyncer(".testimportbad", true)
+	for _, csv := range []string{"", "Artist,Title\nSlint,Spiderland\n", "release_id,Title\n\"25,Spiderland\n"} {
+		if _, err := syncer.ImportDiscogsCSV(context.Background(), &pb.DiscogsImportRequest{Csv: csv}); errorCode(err) != codes.InvalidArgument {
+			t.Errorf("Import of %q gave %v", csv, err)
+		}
+	}
+
+	report, err := syncer.ImportDiscogsCSV(context.Background(), &pb.DiscogsImportRequest{Csv: "release_id,Rating\nabc,1\n", DryRun: true})
+	if err != nil || len(report.Errors) != 1 || !strings.HasPrefix(report.Errors[0], "Line 2: bad release_id") {
+		t.Errorf("Bad line has been badly reported: %v (%v)", report, err)
+	}
+}
@@ -0,0 +1,89 @@
+// Package discogscsv reads the collection CSV export from discogs
+package discogscsv
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DateFormat is how the export writes Date Added, in local time
+const DateFormat = "2006-01-02 15:04:05"
+
+// Row is one line of the export, holding the fields we read
+type Row struct {
+	Line      int
+	ReleaseID int32
+	Folder    string
+	DateAdded int64
+	Rating    int32
+	HasRating bool
+	Notes     string
+}
+
+// Columns maps the lower cased header names onto their positions
+type Columns map[string]int
+
+// Read reads the whole export, splitting off the header
+func Read(r io.Reader) (Columns, [][]string, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, nil, fmt.Errorf("Unable to parse CSV: %v", err)
+	}
+	if len(records) == 0 {
+		return nil, nil, errors.New("CSV is empty")
+	}
+
+	cols := make(Columns)
+	for i, name := range records[0] {
+		cols[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	if _, ok := cols["release_id"]; !ok {
+		return nil, nil, errors.New("CSV has no release_id column")
+	}
+	return cols, records[1:], nil
+}
+
+// Value is the trimmed value of the named column, empty if the record doesn't have it
+func (cols Columns) Value(record []string, name string) string {
+	if i, ok := cols[name]; ok && i < len(record) {
+		return strings.TrimSpace(record[i])
+	}
+	return ""
+}
+
+// ParseRow pulls out the fields we read from a record, line counts the header
+func (cols Columns) ParseRow(record []string, line int) (*Row, error) {
+	row := &Row{Line: line, Folder: cols.Value(record, "collectionfolder"), Notes: cols.Value(record, "collection notes")}
+
+	id, err := strconv.Atoi(cols.Value(record, "release_id"))
+	if err != nil {
+		return nil, fmt.Errorf("Line %v: bad release_id: %v", line, err)
+	}
+	row.ReleaseID = int32(id)
+
+	if added := cols.Value(record, "date added"); len(added) > 0 {
+		d, err := time.ParseInLocation(DateFormat, added, time.Local)
+		if err != nil {
+			return nil, fmt.Errorf("Line %v: bad Date Added: %v", line, err)
+		}
+		row.DateAdded = d.Unix()
+	}
+
+	if rating := cols.Value(record, "rating"); len(rating) > 0 {
+		r, err := strconv.Atoi(rating)
+		if err != nil || r < 0 || r > 5 {
+			return nil, fmt.Errorf("Line %v: bad Rating %q", line, rating)
+		}
+		row.Rating = int32(r)
+		row.HasRating = true
+	}
+
+	return row, nil
+}
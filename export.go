@@ -0,0 +1,212 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	pb "github.com/brotherlogic/discogssyncer/server"
+	pbd "github.com/brotherlogic/godiscogs"
+)
+
+// exportRecord is everything we know about one copy of a release
+type exportRecord struct {
+	release *pbd.Release
+	meta    *pb.ReleaseMetadata
+	folder  string
+}
+
+type exportColumn struct {
+	name  string
+	value func(syncer *Syncer, r exportRecord, layout string) string
+}
+
+func formatDate(v int64, layout string) string {
+	if v == 0 {
+		return ""
+	}
+	return time.Unix(v, 0).Format(layout)
+}
+
+func joinNames(names []string) string {
+	return strings.Join(names, "; ")
+}
+
+func itoa(v int32) string {
+	return strconv.Itoa(int(v))
+}
+
+// The built in columns, in their default order
+var exportColumns = []exportColumn{
+	{"release_id", func(s *Syncer, r exportRecord, l string) string { return itoa(r.release.Id) }},
+	{"instance_id", func(s *Syncer, r exportRecord, l string) string { return itoa(r.release.InstanceId) }},
+	{"title", func(s *Syncer, r exportRecord, l string) string { return r.release.Title }},
+	{"artist", func(s *Syncer, r exportRecord, l string) string { return pbd.GetReleaseArtist(*r.release) }},
+	{"label", func(s *Syncer, r exportRecord, l string) string {
+		var names []string
+		for _, label := range r.release.Labels {
+			names = append(names, label.Name)
+		}
+		return joinNames(names)
+	}},
+	{"catno", func(s *Syncer, r exportRecord, l string) string {
+		var names []string
+		for _, label := range r.release.Labels {
+			names = append(names, label.Catno)
+		}
+		return joinNames(names)
+	}},
+	{"format", func(s *Syncer, r exportRecord, l string) string {
+		var names []string
+		for _, format := range r.release.Formats {
+			names = append(names, format.Name)
+		}
+		return joinNames(names)
+	}},
+	{"year", func(s *Syncer, r exportRecord, l string) string {
+		if year := releaseYear(r.release); year > 0 {
+			return itoa(year)
+		}
+		return ""
+	}},
+	{"country", func(s *Syncer, r exportRecord, l string) string { return r.release.Country }},
+	{"genres", func(s *Syncer, r exportRecord, l string) string { return joinNames(r.release.Genres) }},
+	{"styles", func(s *Syncer, r exportRecord, l string) string { return joinNames(r.release.Styles) }},
+	{"master_id", func(s *Syncer, r exportRecord, l string) string { return itoa(r.release.MasterId) }},
+	{"folder_id", func(s *Syncer, r exportRecord, l string) string { return itoa(r.release.FolderId) }},
+	{"folder_name", func(s *Syncer, r exportRecord, l string) string { return r.folder }},
+	{"rating", func(s *Syncer, r exportRecord, l string) string { return itoa(r.release.Rating) }},
+	{"date_added", func(s *Syncer, r exportRecord, l string) string { return formatDate(r.meta.GetDateAdded(), l) }},
+	{"date_refreshed", func(s *Syncer, r exportRecord, l string) string { return formatDate(r.meta.GetDateRefreshed(), l) }},
+	{"file_path", func(s *Syncer, r exportRecord, l string) string { return r.meta.GetFilePath() }},
+	{"cost", func(s *Syncer, r exportRecord, l string) string { return itoa(r.meta.GetCost()) }},
+	{"others", func(s *Syncer, r exportRecord, l string) string { return strconv.FormatBool(r.meta.GetOthers()) }},
+	{"last_cache", func(s *Syncer, r exportRecord, l string) string { return formatDate(r.meta.GetLastCache(), l) }},
+	{"last_touched", func(s *Syncer, r exportRecord, l string) string { return formatDate(r.meta.GetLastTouched(), l) }},
+	{"want_fulfilled", func(s *Syncer, r exportRecord, l string) string { return formatDate(r.meta.GetWantFulfilled(), l) }},
+	{"want_price", func(s *Syncer, r exportRecord, l string) string { return itoa(r.meta.GetWantPrice()) }},
+	{"notes", func(s *Syncer, r exportRecord, l string) string { return r.meta.GetNotes() }},
+	{"tags", func(s *Syncer, r exportRecord, l string) string { return joinNames(releaseTags(r.meta, r.release)) }},
+	{"shelf", func(s *Syncer, r exportRecord, l string) string { return s.shelfPosition(r.release).GetShelf() }},
+	{"shelf_position", func(s *Syncer, r exportRecord, l string) string {
+		if p := s.shelfPosition(r.release); p != nil {
+			return itoa(p.Position)
+		}
+		return ""
+	}},
+}
+
+// customValue picks the value of a custom field for a copy, preferring one set on the copy itself
+func customValue(md *pb.ReleaseMetadata, rel *pbd.Release, name string) string {
+	value := ""
+	for _, c := range md.GetCustom() {
+		if c.Name == name {
+			if c.InstanceId == rel.InstanceId && c.InstanceId != 0 {
+				return c.Value
+			}
+			if c.InstanceId == 0 {
+				value = c.Value
+			}
+		}
+	}
+	return value
+}
+
+func (syncer *Syncer) exportColumns(names []string) ([]exportColumn, error) {
+	if len(names) == 0 {
+		columns := append([]exportColumn{}, exportColumns...)
+		for _, f := range syncer.getSchema().Fields {
+			names = append(names, f.Name)
+		}
+		for _, name := range names {
+			columns = append(columns, customColumn(name))
+		}
+		return columns, nil
+	}
+
+	var columns []exportColumn
+	for _, name := range names {
+		found := false
+		for _, c := range exportColumns {
+			if c.name == name {
+				columns = append(columns, c)
+				found = true
+			}
+		}
+		if !found && syncer.findField(name) != nil {
+			columns = append(columns, customColumn(name))
+			found = true
+		}
+		if !found {
+			return nil, fmt.Errorf("%v is not a column we can export", name)
+		}
+	}
+	return columns, nil
+}
+
+func customColumn(name string) exportColumn {
+	return exportColumn{name, func(s *Syncer, r exportRecord, l string) string { return customValue(r.meta, r.release, name) }}
+}
+
+func csvLine(fields []string) (string, error) {
+	buf := &bytes.Buffer{}
+	w := csv.NewWriter(buf)
+	w.Write(fields)
+	w.Flush()
+	return buf.String(), w.Error()
+}
+
+// exportCSV hands each CSV line, header first, to send
+func (syncer *Syncer) exportCSV(in *pb.ExportRequest, send func(line string) error) error {
+	columns, err := syncer.exportColumns(in.Columns)
+	if err != nil {
+		return err
+	}
+	layout := in.DateFormat
+	if layout == "" {
+		layout = dateFormat
+	}
+
+	var header []string
+	for _, c := range columns {
+		header = append(header, c.name)
+	}
+	line, err := csvLine(header)
+	if err == nil {
+		err = send(line)
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, f := range syncer.collection.Folders {
+		for _, rel := range f.GetReleases().GetReleases() {
+			r := exportRecord{release: rel, meta: syncer.findMetadata(rel.Id), folder: f.GetFolder().GetName()}
+			var fields []string
+			for _, c := range columns {
+				fields = append(fields, c.value(syncer, r, layout))
+			}
+			line, err := csvLine(fields)
+			if err == nil {
+				err = send(line)
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// ExportCollection streams the collection out as CSV
+func (syncer *Syncer) ExportCollection(in *pb.ExportRequest, stream pb.DiscogsService_ExportCollectionServer) error {
+	t := time.Now()
+	err := syncer.exportCSV(in, func(line string) error {
+		return stream.Send(&pb.ExportRow{Line: line})
+	})
+	syncer.LogFunction("ExportCollection", t)
+	return err
+}
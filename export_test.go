@@ -0,0 +1,90 @@
+package main
+
+import (
+	"encoding/csv"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+
+	pb "github.com/brotherlogic/discogssyncer/server"
+	pbd "github.com/brotherlogic/godiscogs"
+)
+
+type testExportStream struct {
+	pb.DiscogsService_ExportCollectionServer
+	lines []string
+}
+
+func (s *testExportStream) Send(row *pb.ExportRow) error {
+	s.lines = append(s.lines, row.Line)
+	return nil
+}
+
+func readExport(t *testing.T, s *testExportStream) [][]string {
+	rows, err := csv.NewReader(strings.NewReader(strings.Join(s.lines, ""))).ReadAll()
+	if err != nil {
+		t.Fatalf("Export is not valid CSV: %v", err)
+	}
+	return rows
+}
+
+func TestExportCollection(t *testing.T) {
+	syncer := GetTestSyncer(".testexportcollection", true)
+	syncer.SaveCollection()
+	syncer.AddField(context.Background(), &pb.FieldDefinition{Name: "grade"})
+	syncer.saveRelease(&pbd.Release{FolderId: 23, Id: 25, InstanceId: 1234, Title: "Kind of Blue, \"Remastered\"", Artists: []*pbd.Artist{&pbd.Artist{Name: "Miles Davis"}}, Rating: 5}, 23)
+	syncer.UpdateMetadata(context.Background(), &pb.MetadataUpdate{Release: &pbd.Release{Id: 25, FolderId: 23}, Update: &pb.ReleaseMetadata{Cost: 1500, DateAdded: time.Date(2017, 3, 4, 12, 0, 0, 0, time.Local).Unix(), Custom: []*pb.CustomField{&pb.CustomField{Name: "grade", Value: "NM"}}}})
+
+	stream := &testExportStream{}
+	if err := syncer.ExportCollection(&pb.ExportRequest{}, stream); err != nil {
+		t.Fatalf("Error exporting: %v", err)
+	}
+
+	rows := readExport(t, stream)
+	if len(rows) != 6 {
+		t.Fatalf("Wrong number of rows: %v", rows)
+	}
+	header := rows[0]
+	if header[0] != "release_id" || header[len(header)-1] != "grade" {
+		t.Errorf("Bad header: %v", header)
+	}
+
+	found := false
+	for _, row := range rows[1:] {
+		if row[0] == "25" {
+			found = true
+			values := make(map[string]string)
+			for i, name := range header {
+				values[name] = row[i]
+			}
+			if values["title"] != "Kind of Blue, \"Remastered\"" || values["folder_name"] != "Testing" || values["rating"] != "5" || values["cost"] != "1500" || values["date_added"] != "2017-03-04" || values["grade"] != "NM" {
+				t.Errorf("Bad row: %v", values)
+			}
+		}
+	}
+	if !found {
+		t.Errorf("Release is missing from the export: %v", rows)
+	}
+}
+
+func TestExportColumns(t *testing.T) {
+	syncer := GetTestSyncer(".testexportcolumns", true)
+	syncer.SaveCollection()
+	syncer.findMetadata(32).DateAdded = time.Date(2017, 3, 4, 12, 0, 0, 0, time.Local).Unix()
+
+	stream := &testExportStream{}
+	if err := syncer.ExportCollection(&pb.ExportRequest{Columns: []string{"release_id", "date_added"}, DateFormat: "02/01/2006"}, stream); err != nil {
+		t.Fatalf("Error exporting: %v", err)
+	}
+
+	rows := readExport(t, stream)
+	if len(rows[0]) != 2 || rows[2][0] != "32" || rows[2][1] != "04/03/2017" {
+		t.Errorf("Bad column export: %v", rows)
+	}
+
+	if err := syncer.ExportCollection(&pb.ExportRequest{Columns: []string{"colour"}}, &testExportStream{}); err == nil {
+		t.Errorf("Unknown column has been exported")
+	}
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/brotherlogic/discogssyncer/server"
+	pbd "github.com/brotherlogic/godiscogs"
+)
+
+const dateFormat = "2006-01-02"
+
+func (syncer *Syncer) getSchema() *pb.FieldSchema {
+	if syncer.collection.Schema == nil {
+		syncer.collection.Schema = &pb.FieldSchema{}
+	}
+	return syncer.collection.Schema
+}
+
+func (syncer *Syncer) findField(name string) *pb.FieldDefinition {
+	for _, f := range syncer.getSchema().Fields {
+		if f.Name == name {
+			return f
+		}
+	}
+	return nil
+}
+
+// validateField checks a value against the schema, normalising it where the type allows
+func (syncer *Syncer) validateField(field *pb.CustomField) error {
+	def := syncer.findField(field.Name)
+	if def == nil {
+		return status.Errorf(codes.NotFound, "%v is not a known field", field.Name)
+	}
+
+	if field.Value == "" {
+		return nil
+	}
+
+	switch def.Type {
+	case pb.FieldType_INT:
+		if _, err := strconv.Atoi(field.Value); err != nil {
+			return status.Errorf(codes.InvalidArgument, "%v is not a valid int for %v", field.Value, field.Name)
+		}
+	case pb.FieldType_DATE:
+		if _, err := time.Parse(dateFormat, field.Value); err != nil {
+			return status.Errorf(codes.InvalidArgument, "%v is not a valid date for %v", field.Value, field.Name)
+		}
+	case pb.FieldType_BOOL:
+		b, err := strconv.ParseBool(field.Value)
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "%v is not a valid bool for %v", field.Value, field.Name)
+		}
+		field.Value = strconv.FormatBool(b)
+	case pb.FieldType_ENUM:
+		if !hasTag(def.Options, field.Value) {
+			return status.Errorf(codes.InvalidArgument, "%v is not one of %v for %v", field.Value, def.Options, field.Name)
+		}
+	}
+	return nil
+}
+
+// mergeFields applies a set of field updates, an empty value removes the field
+func mergeFields(current []*pb.CustomField, updates []*pb.CustomField) []*pb.CustomField {
+	for _, u := range updates {
+		for i, c := range current {
+			if c.Name == u.Name && c.InstanceId == u.InstanceId {
+				current = append(current[:i], current[i+1:]...)
+				break
+			}
+		}
+		if u.Value != "" {
+			current = append(current, &pb.CustomField{Name: u.Name, Value: u.Value, InstanceId: u.InstanceId})
+		}
+	}
+	return current
+}
+
+// matchFields checks that a copy of a release carries all of the given field values
+func matchFields(md *pb.ReleaseMetadata, rel *pbd.Release, filters []*pb.CustomField) bool {
+	for _, f := range filters {
+		found := false
+		for _, c := range md.GetCustom() {
+			if c.Name == f.Name && (c.InstanceId == 0 || c.InstanceId == rel.InstanceId) && strings.EqualFold(c.Value, f.Value) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// AddField adds a custom field to the schema
+func (syncer *Syncer) AddField(ctx context.Context, in *pb.FieldDefinition) (*pb.FieldSchema, error) {
+	t := time.Now()
+	if in.Name == "" {
+		return nil, status.Error(codes.InvalidArgument, "Fields need a name")
+	}
+	if syncer.findField(in.Name) != nil {
+		return nil, status.Errorf(codes.AlreadyExists, "%v is already defined", in.Name)
+	}
+	if in.Type == pb.FieldType_ENUM && len(in.Options) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "Enum field %v has no options", in.Name)
+	}
+
+	schema := syncer.getSchema()
+	schema.Fields = append(schema.Fields, in)
+	syncer.saveCollection()
+	syncer.LogFunction("AddField", t)
+	return schema, nil
+}
+
+// DeleteField removes a custom field from the schema along with any values it holds
+func (syncer *Syncer) DeleteField(ctx context.Context, in *pb.FieldDefinition) (*pb.FieldSchema, error) {
+	t := time.Now()
+	schema := syncer.getSchema()
+	for i, f := range schema.Fields {
+		if f.Name == in.Name {
+			schema.Fields = append(schema.Fields[:i], schema.Fields[i+1:]...)
+
+			for _, m := range syncer.collection.Metadata {
+				var kept []*pb.CustomField
+				for _, c := range m.Custom {
+					if c.Name != in.Name {
+						kept = append(kept, c)
+					}
+				}
+				m.Custom = kept
+			}
+
+			syncer.saveCollection()
+			syncer.LogFunction("DeleteField", t)
+			return schema, nil
+		}
+	}
+
+	return nil, status.Errorf(codes.NotFound, "%v is not a known field", in.Name)
+}
+
+// GetFields returns the custom field schema
+func (syncer *Syncer) GetFields(ctx context.Context, in *pb.Empty) (*pb.FieldSchema, error) {
+	return syncer.getSchema(), nil
+}
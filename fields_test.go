@@ -0,0 +1,126 @@
+package main
+
+import (
+	"testing"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+
+	pb "github.com/brotherlogic/discogssyncer/server"
+	pbd "github.com/brotherlogic/godiscogs"
+)
+
+func addTestFields(syncer *Syncer) {
+	syncer.AddField(context.Background(), &pb.FieldDefinition{Name: "location"})
+	syncer.AddField(context.Background(), &pb.FieldDefinition{Name: "cleaned", Type: pb.FieldType_DATE})
+	syncer.AddField(context.Background(), &pb.FieldDefinition{Name: "grade", Type: pb.FieldType_ENUM, Options: []string{"VG", "VG+", "NM"}})
+	syncer.AddField(context.Background(), &pb.FieldDefinition{Name: "sealed", Type: pb.FieldType_BOOL})
+}
+
+func TestAddField(t *testing.T) {
+	syncer := GetTestSyncer(".testaddfield", true)
+	addTestFields(syncer)
+
+	schema, _ := syncer.GetFields(context.Background(), &pb.Empty{})
+	if len(schema.Fields) != 4 {
+		t.Errorf("Fields have not been added: %v", schema)
+	}
+
+	_, err := syncer.AddField(context.Background(), &pb.FieldDefinition{Name: "location", Type: pb.FieldType_INT})
+	if errorCode(err) != codes.AlreadyExists {
+		t.Errorf("Field has been defined twice: %v", err)
+	}
+
+	_, err = syncer.AddField(context.Background(), &pb.FieldDefinition{Name: "mood", Type: pb.FieldType_ENUM})
+	if errorCode(err) != codes.InvalidArgument {
+		t.Errorf("Enum field without options has been added: %v", err)
+	}
+}
+
+func TestUpdateCustomFields(t *testing.T) {
+	syncer := GetTestSyncer(".testupdatecustomfields", true)
+	addTestFields(syncer)
+	syncer.saveRelease(&pbd.Release{FolderId: 23, Id: 25, InstanceId: 37}, 23)
+
+	rel := &pbd.Release{Id: 25, FolderId: 23}
+	md, err := syncer.UpdateMetadata(context.Background(), &pb.MetadataUpdate{Release: rel, Update: &pb.ReleaseMetadata{Custom: []*pb.CustomField{
+		&pb.CustomField{Name: "location", Value: "Shelf A"},
+		&pb.CustomField{Name: "sealed", Value: "T", InstanceId: 37},
+	}}})
+	if err != nil {
+		t.Fatalf("Error updating fields: %v", err)
+	}
+	if len(md.Custom) != 2 || md.Custom[1].Value != "true" {
+		t.Errorf("Fields have been badly stored: %v", md)
+	}
+
+	md, err = syncer.UpdateMetadata(context.Background(), &pb.MetadataUpdate{Release: rel, Update: &pb.ReleaseMetadata{Cost: 100, Custom: []*pb.CustomField{
+		&pb.CustomField{Name: "location", Value: "Shelf B"},
+		&pb.CustomField{Name: "sealed", InstanceId: 37},
+	}}})
+	if err != nil {
+		t.Fatalf("Error updating fields: %v", err)
+	}
+	if len(md.Custom) != 1 || md.Custom[0].Value != "Shelf B" || md.Cost != 100 {
+		t.Errorf("Fields have been badly updated: %v", md)
+	}
+}
+
+func TestUpdateCustomFieldsValidates(t *testing.T) {
+	syncer := GetTestSyncer(".testupdatecustomfieldsvalidates", true)
+	addTestFields(syncer)
+	syncer.saveRelease(&pbd.Release{FolderId: 23, Id: 25, InstanceId: 37}, 23)
+
+	var bad = []struct {
+		field *pb.CustomField
+		code  codes.Code
+	}{
+		{&pb.CustomField{Name: "colour", Value: "red"}, codes.NotFound},
+		{&pb.CustomField{Name: "cleaned", Value: "last week"}, codes.InvalidArgument},
+		{&pb.CustomField{Name: "grade", Value: "Mint"}, codes.InvalidArgument},
+		{&pb.CustomField{Name: "sealed", Value: "maybe"}, codes.InvalidArgument},
+	}
+	for _, test := range bad {
+		_, err := syncer.UpdateMetadata(context.Background(), &pb.MetadataUpdate{Release: &pbd.Release{Id: 25, FolderId: 23}, Update: &pb.ReleaseMetadata{Cost: 100, Custom: []*pb.CustomField{test.field}}})
+		if errorCode(err) != test.code {
+			t.Errorf("Bad field has been accepted: %v (%v)", test.field, err)
+		}
+	}
+
+	if syncer.findMetadata(25).Cost != 0 {
+		t.Errorf("Failed update has been applied: %v", syncer.findMetadata(25))
+	}
+}
+
+func TestSearchCustomFields(t *testing.T) {
+	syncer := GetTestSyncer(".testsearchcustomfields", true)
+	addTestFields(syncer)
+	syncer.saveRelease(&pbd.Release{FolderId: 23, Id: 25, InstanceId: 37, Title: "Kind of Blue"}, 23)
+	syncer.saveRelease(&pbd.Release{FolderId: 23, Id: 27, InstanceId: 39, Title: "Blue Train"}, 23)
+	syncer.UpdateMetadata(context.Background(), &pb.MetadataUpdate{Release: &pbd.Release{Id: 25, FolderId: 23}, Update: &pb.ReleaseMetadata{Custom: []*pb.CustomField{&pb.CustomField{Name: "grade", Value: "NM"}}}})
+
+	res, err := syncer.Search(context.Background(), &pb.SearchRequest{Query: "blue", Fields: []*pb.CustomField{&pb.CustomField{Name: "grade", Value: "nm"}}})
+	if err != nil || len(res.Releases) != 1 || res.Releases[0].Id != 25 {
+		t.Errorf("Field search has failed: %v (%v)", res, err)
+	}
+}
+
+func TestDeleteField(t *testing.T) {
+	syncer := GetTestSyncer(".testdeletefield", true)
+	addTestFields(syncer)
+	syncer.saveRelease(&pbd.Release{FolderId: 23, Id: 25, InstanceId: 37}, 23)
+	syncer.UpdateMetadata(context.Background(), &pb.MetadataUpdate{Release: &pbd.Release{Id: 25, FolderId: 23}, Update: &pb.ReleaseMetadata{Custom: []*pb.CustomField{&pb.CustomField{Name: "location", Value: "Shelf A"}}}})
+
+	schema, err := syncer.DeleteField(context.Background(), &pb.FieldDefinition{Name: "location"})
+	if err != nil || len(schema.Fields) != 3 {
+		t.Fatalf("Field has not been deleted: %v (%v)", schema, err)
+	}
+	if len(syncer.findMetadata(25).Custom) != 0 {
+		t.Errorf("Field values remain: %v", syncer.findMetadata(25))
+	}
+
+	_, err = syncer.DeleteField(context.Background(), &pb.FieldDefinition{Name: "location"})
+	if err == nil {
+		t.Errorf("Missing field has been deleted")
+	}
+}
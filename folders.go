@@ -0,0 +1,143 @@
+package main
+
+import (
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/brotherlogic/discogssyncer/server"
+	pbd "github.com/brotherlogic/godiscogs"
+)
+
+// Discogs won't let us change the All (0) or Uncategorized (1) folders
+func protectedFolder(id int32) bool {
+	return id == 0 || id == 1
+}
+
+func (syncer *Syncer) findFolder(id int32) *pb.CollectionFolder {
+	for _, f := range syncer.collection.Folders {
+		if f.Folder.Id == id {
+			return f
+		}
+	}
+	return nil
+}
+
+func (syncer *Syncer) folderNameUsed(name string) bool {
+	for _, f := range syncer.collection.Folders {
+		if f.Folder.Name == name {
+			return true
+		}
+	}
+	for _, sf := range syncer.collection.SmartFolders {
+		if sf.Folder.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// CreateFolder creates a new folder on discogs
+func (syncer *Syncer) CreateFolder(ctx context.Context, in *pbd.Folder) (*pbd.Folder, error) {
+	t := time.Now()
+	if in.Name == "" {
+		return nil, status.Error(codes.InvalidArgument, "Folders need a name")
+	}
+	if syncer.folderNameUsed(in.Name) {
+		return nil, status.Errorf(codes.AlreadyExists, "There is already a folder called %v", in.Name)
+	}
+
+	folder, err := syncer.retr.CreateFolder(in.Name)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "Discogs failed to create %v: %v", in.Name, err)
+	}
+
+	syncer.collection.Folders = append(syncer.collection.Folders, &pb.CollectionFolder{Folder: &folder, Releases: &pb.ReleaseList{}})
+	syncer.saveCollection()
+	syncer.LogFunction("CreateFolder", t)
+	return &folder, nil
+}
+
+// RenameFolder renames a folder
+func (syncer *Syncer) RenameFolder(ctx context.Context, in *pbd.Folder) (*pbd.Folder, error) {
+	t := time.Now()
+	if in.Name == "" {
+		return nil, status.Error(codes.InvalidArgument, "Folders need a name")
+	}
+	if protectedFolder(in.Id) {
+		return nil, status.Errorf(codes.FailedPrecondition, "Folder %v cannot be renamed", in.Id)
+	}
+
+	folder := syncer.findFolder(in.Id)
+	if folder == nil {
+		return nil, status.Errorf(codes.NotFound, "Unable to locate folder with id %v", in.Id)
+	}
+	if folder.Folder.Name != in.Name && syncer.folderNameUsed(in.Name) {
+		return nil, status.Errorf(codes.AlreadyExists, "There is already a folder called %v", in.Name)
+	}
+
+	if err := syncer.retr.RenameFolder(int(in.Id), in.Name); err != nil {
+		return nil, status.Errorf(codes.Unavailable, "Discogs failed to rename %v: %v", in.Id, err)
+	}
+
+	folder.Folder.Name = in.Name
+	syncer.saveCollection()
+	syncer.LogFunction("RenameFolder", t)
+	return folder.Folder, nil
+}
+
+// DeleteFolder deletes a folder, moving anything left in it to the destination
+func (syncer *Syncer) DeleteFolder(ctx context.Context, in *pb.FolderDelete) (*pb.Empty, error) {
+	t := time.Now()
+	if in.Folder == nil {
+		return nil, status.Error(codes.InvalidArgument, "No folder to delete")
+	}
+	if protectedFolder(in.Folder.Id) {
+		return nil, status.Errorf(codes.FailedPrecondition, "Folder %v cannot be deleted", in.Folder.Id)
+	}
+
+	folder := syncer.findFolder(in.Folder.Id)
+	if folder == nil {
+		return nil, status.Errorf(codes.NotFound, "Unable to locate folder with id %v", in.Folder.Id)
+	}
+
+	releases := folder.GetReleases().GetReleases()
+	if len(releases) > 0 {
+		if in.DestinationId == 0 {
+			return nil, status.Errorf(codes.FailedPrecondition, "Folder %v still holds %v releases", in.Folder.Id, len(releases))
+		}
+		dest := syncer.findFolder(in.DestinationId)
+		if dest == nil || dest == folder {
+			return nil, status.Errorf(codes.InvalidArgument, "Unable to move releases to folder %v", in.DestinationId)
+		}
+
+		if dest.Releases == nil {
+			dest.Releases = &pb.ReleaseList{}
+		}
+		for _, rel := range releases {
+			syncer.throttle()
+			syncer.retr.MoveToFolder(int(rel.FolderId), int(rel.Id), int(rel.InstanceId), int(in.DestinationId))
+			rel.FolderId = in.DestinationId
+			dest.Releases.Releases = append(dest.Releases.Releases, rel)
+		}
+		folder.Releases.Releases = nil
+		syncer.saveCollection()
+	}
+
+	if err := syncer.retr.DeleteFolder(int(in.Folder.Id)); err != nil {
+		return nil, status.Errorf(codes.Unavailable, "Discogs failed to delete %v: %v", in.Folder.Id, err)
+	}
+
+	for i, f := range syncer.collection.Folders {
+		if f == folder {
+			syncer.collection.Folders = append(syncer.collection.Folders[:i], syncer.collection.Folders[i+1:]...)
+			break
+		}
+	}
+
+	syncer.saveCollection()
+	syncer.LogFunction("DeleteFolder", t)
+	return &pb.Empty{}, nil
+}
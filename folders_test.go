@@ -0,0 +1,104 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+
+	pb "github.com/brotherlogic/discogssyncer/server"
+	pbd "github.com/brotherlogic/godiscogs"
+)
+
+func TestCreateFolder(t *testing.T) {
+	syncer := GetTestSyncer(".testcreatefolder", true)
+	syncer.SaveCollection()
+
+	folder, err := syncer.CreateFolder(context.Background(), &pbd.Folder{Name: "New Arrivals"})
+	if err != nil {
+		t.Fatalf("Error creating folder: %v", err)
+	}
+	if folder.Id != 40 || syncer.findFolder(40) == nil {
+		t.Errorf("Folder has not been stored: %v", syncer.getFolders())
+	}
+
+	_, err = syncer.MoveToFolder(context.Background(), &pb.ReleaseMove{Release: &pbd.Release{Id: 25, FolderId: 23, InstanceId: 1234}, NewFolderId: 40})
+	if err != nil {
+		t.Errorf("Unable to move into the new folder: %v", err)
+	}
+
+	if _, err = syncer.CreateFolder(context.Background(), &pbd.Folder{Name: "Testing"}); err == nil {
+		t.Errorf("Duplicate folder has been created")
+	}
+}
+
+func TestRenameFolder(t *testing.T) {
+	syncer := GetTestSyncer(".testrenamefolder", true)
+	syncer.SaveCollection()
+
+	folder, err := syncer.RenameFolder(context.Background(), &pbd.Folder{Id: 23, Name: "Renamed"})
+	if err != nil || folder.Name != "Renamed" || syncer.findFolder(23).Folder.Name != "Renamed" {
+		t.Errorf("Folder has not been renamed: %v (%v)", folder, err)
+	}
+
+	if _, err = syncer.RenameFolder(context.Background(), &pbd.Folder{Id: 25, Name: "Renamed"}); err == nil {
+		t.Errorf("Folder has been renamed onto an existing name")
+	}
+
+	if _, err = syncer.RenameFolder(context.Background(), &pbd.Folder{Id: 1, Name: "Other"}); err == nil {
+		t.Errorf("Uncategorized has been renamed")
+	}
+
+	if _, err = syncer.RenameFolder(context.Background(), &pbd.Folder{Id: 99, Name: "Other"}); err == nil {
+		t.Errorf("Missing folder has been renamed")
+	}
+}
+
+func TestDeleteFolder(t *testing.T) {
+	syncer := GetTestSyncer(".testdeletefolder", true)
+	syncer.SaveCollection()
+
+	if _, err := syncer.DeleteFolder(context.Background(), &pb.FolderDelete{Folder: &pbd.Folder{Id: 23}}); err == nil {
+		t.Fatalf("Non-empty folder has been deleted")
+	}
+
+	// Each release moved is a discogs call, so they're spaced out like any bulk work
+	syncer.discogsDelay = time.Millisecond * 30
+	start := time.Now()
+	if _, err := syncer.DeleteFolder(context.Background(), &pb.FolderDelete{Folder: &pbd.Folder{Id: 23}, DestinationId: 25}); err != nil {
+		t.Fatalf("Error deleting folder: %v", err)
+	}
+	if time.Since(start) < time.Millisecond*30 {
+		t.Errorf("Moves out of the folder have not been throttled: %v", time.Since(start))
+	}
+
+	if syncer.findFolder(23) != nil {
+		t.Errorf("Folder has not been removed: %v", syncer.getFolders())
+	}
+
+	moved := syncer.getReleases(25).Releases
+	if len(moved) != 2 || moved[0].FolderId != 25 {
+		t.Errorf("Releases have not been moved: %v", moved)
+	}
+
+	if _, err := syncer.DeleteFolder(context.Background(), &pb.FolderDelete{Folder: &pbd.Folder{Id: 22}, DestinationId: 23}); errorCode(err) != codes.InvalidArgument {
+		t.Errorf("Releases have been moved to a deleted folder: %v", err)
+	}
+}
+
+func TestFolderDiscogsFailures(t *testing.T) {
+	syncer := gatewaySyncer(".testfolderdiscogsfailures")
+	// Discogs knows nothing of this folder, so every call it makes about it fails
+	syncer.collection.Folders = append(syncer.collection.Folders, &pb.CollectionFolder{Folder: &pbd.Folder{Id: 9999, Name: "Local"}, Releases: &pb.ReleaseList{}})
+
+	if _, err := syncer.RenameFolder(context.Background(), &pbd.Folder{Id: 9999, Name: "Elsewhere"}); errorCode(err) != codes.Unavailable {
+		t.Errorf("Failed rename gave %v", err)
+	}
+	if _, err := syncer.DeleteFolder(context.Background(), &pb.FolderDelete{Folder: &pbd.Folder{Id: 9999}}); errorCode(err) != codes.Unavailable {
+		t.Errorf("Failed delete gave %v", err)
+	}
+	if syncer.findFolder(9999) == nil || syncer.findFolder(9999).Folder.Name != "Local" {
+		t.Errorf("Folder has changed despite discogs failing: %v", syncer.findFolder(9999))
+	}
+}
@@ -0,0 +1,493 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"log"
+	"mime"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/brotherlogic/discogssyncer/server"
+	pbd "github.com/brotherlogic/godiscogs"
+)
+
+// httpCodes maps gRPC status codes onto HTTP ones, anything missing is a 500
+var httpCodes = map[codes.Code]int{
+	codes.OK:                 http.StatusOK,
+	codes.Canceled:           http.StatusRequestTimeout,
+	codes.InvalidArgument:    http.StatusBadRequest,
+	codes.OutOfRange:         http.StatusBadRequest,
+	codes.DeadlineExceeded:   http.StatusGatewayTimeout,
+	codes.NotFound:           http.StatusNotFound,
+	codes.AlreadyExists:      http.StatusConflict,
+	codes.Aborted:            http.StatusConflict,
+	codes.PermissionDenied:   http.StatusForbidden,
+	codes.Unauthenticated:    http.StatusUnauthorized,
+	codes.ResourceExhausted:  http.StatusTooManyRequests,
+	codes.FailedPrecondition: http.StatusPreconditionFailed,
+	codes.Unimplemented:      http.StatusNotImplemented,
+	codes.Unavailable:        http.StatusServiceUnavailable,
+}
+
+func httpCode(c codes.Code) int {
+	if code, ok := httpCodes[c]; ok {
+		return code
+	}
+	return http.StatusInternalServerError
+}
+
+// gatewayError is the body we send back for any failed call
+type gatewayError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func writeStatus(w http.ResponseWriter, code int, name, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(gatewayError{Code: name, Message: message})
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	st, _ := status.FromError(err)
+	writeStatus(w, httpCode(st.Code()), st.Code().String(), st.Message())
+}
+
+func writeProto(w http.ResponseWriter, m proto.Message) {
+	marshaler := &jsonpb.Marshaler{OrigName: true}
+	data, err := marshaler.MarshalToString(m)
+	if err != nil {
+		writeError(w, status.Errorf(codes.Internal, "Unable to encode response: %v", err))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write([]byte(data))
+}
+
+func readBody(r *http.Request) ([]byte, error) {
+	data, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Unable to read request: %v", err)
+	}
+	return data, nil
+}
+
+func readProto(data []byte, m proto.Message) error {
+	if err := jsonpb.Unmarshal(bytes.NewReader(data), m); err != nil {
+		return status.Errorf(codes.InvalidArgument, "Unable to parse request: %v", err)
+	}
+	return nil
+}
+
+func parseID(value, name string) (int32, error) {
+	id, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, status.Errorf(codes.InvalidArgument, "Bad %v: %v", name, value)
+	}
+	return int32(id), nil
+}
+
+// queryID reads an optional numeric query parameter, zero if it's missing
+func queryID(r *http.Request, name string) (int32, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return 0, nil
+	}
+	return parseID(value, name)
+}
+
+// route binds a method and a path pattern to a call; pattern segments like {id} are
+// handed to the call in order
+type route struct {
+	method  string
+	pattern string
+	call    func(syncer *Syncer, r *http.Request, params []string) (proto.Message, error)
+}
+
+// matchPath matches path against a pattern, returning the values of any {param} segments
+func matchPath(pattern, path string) ([]string, bool) {
+	want := strings.Split(strings.Trim(pattern, "/"), "/")
+	have := strings.Split(strings.Trim(path, "/"), "/")
+	if len(want) != len(have) {
+		return nil, false
+	}
+	var params []string
+	for i, seg := range want {
+		if strings.HasPrefix(seg, "{") {
+			if have[i] == "" {
+				return nil, false
+			}
+			params = append(params, have[i])
+		} else if seg != have[i] {
+			return nil, false
+		}
+	}
+	return params, true
+}
+
+var routes = []route{
+	{"GET", "/collection", func(syncer *Syncer, r *http.Request, params []string) (proto.Message, error) {
+		return syncer.GetCollection(r.Context(), &pb.Empty{})
+	}},
+	{"GET", "/folders", func(syncer *Syncer, r *http.Request, params []string) (proto.Message, error) {
+		return syncer.getFolders(), nil
+	}},
+	{"POST", "/folders", func(syncer *Syncer, r *http.Request, params []string) (proto.Message, error) {
+		folder := &pbd.Folder{}
+		if err := readRequest(r, folder); err != nil {
+			return nil, err
+		}
+		return syncer.CreateFolder(r.Context(), folder)
+	}},
+	{"GET", "/folders/{id}", func(syncer *Syncer, r *http.Request, params []string) (proto.Message, error) {
+		folder := folderSpec(params[0])
+		if !syncer.knownFolder(folder) {
+			return nil, status.Errorf(codes.NotFound, "Unable to locate folder %v", params[0])
+		}
+		return syncer.GetReleasesInFolder(r.Context(), &pb.FolderList{Folders: []*pbd.Folder{folder}})
+	}},
+	{"GET", "/releases/{id}", func(syncer *Syncer, r *http.Request, params []string) (proto.Message, error) {
+		id, err := parseID(params[0], "release id")
+		if err != nil {
+			return nil, err
+		}
+		// Only serve what we hold, a GET shouldn't have us calling out to discogs
+		rel := syncer.ownedRelease(id, 0)
+		if rel == nil {
+			rel = syncer.cachedRelease(id)
+		}
+		if rel == nil {
+			return nil, status.Errorf(codes.NotFound, "Release %v is not in the collection or the cache", id)
+		}
+		return rel, nil
+	}},
+	{"GET", "/releases/{id}/metadata", func(syncer *Syncer, r *http.Request, params []string) (proto.Message, error) {
+		rel, err := syncer.gatewayRelease(r, params[0])
+		if err != nil {
+			return nil, err
+		}
+		return syncer.GetMetadata(r.Context(), rel)
+	}},
+	{"PATCH", "/releases/{id}/metadata", func(syncer *Syncer, r *http.Request, params []string) (proto.Message, error) {
+		rel, err := syncer.gatewayRelease(r, params[0])
+		if err != nil {
+			return nil, err
+		}
+		data, err := readBody(r)
+		if err != nil {
+			return nil, err
+		}
+		update := &pb.ReleaseMetadata{}
+		if err := readProto(data, update); err != nil {
+			return nil, err
+		}
+
+		// Others is cleared by any update which doesn't set it, so carry it across unless
+		// the caller has asked for it
+		var fields map[string]json.RawMessage
+		json.Unmarshal(data, &fields)
+		if _, ok := fields["others"]; !ok {
+			if current := syncer.findMetadata(rel.Id); current != nil {
+				update.Others = current.Others
+			}
+		}
+		return syncer.UpdateMetadata(r.Context(), &pb.MetadataUpdate{Release: rel, Update: update})
+	}},
+	{"PUT", "/releases/{id}/rating", func(syncer *Syncer, r *http.Request, params []string) (proto.Message, error) {
+		rel, err := syncer.gatewayRelease(r, params[0])
+		if err != nil {
+			return nil, err
+		}
+		rating := &pbd.Release{}
+		if err := readRequest(r, rating); err != nil {
+			return nil, err
+		}
+		if rating.Rating < 0 || rating.Rating > 5 {
+			return nil, status.Errorf(codes.InvalidArgument, "Ratings run from 0 to 5, not %v", rating.Rating)
+		}
+		return syncer.UpdateRating(r.Context(), &pbd.Release{Id: rel.Id, InstanceId: rel.InstanceId, FolderId: rel.FolderId, Rating: rating.Rating})
+	}},
+	{"POST", "/moves", func(syncer *Syncer, r *http.Request, params []string) (proto.Message, error) {
+		move := &pb.ReleaseMove{}
+		if err := readRequest(r, move); err != nil {
+			return nil, err
+		}
+		if move.Release == nil {
+			return nil, status.Error(codes.InvalidArgument, "Moves need a release")
+		}
+		rel := syncer.ownedRelease(move.Release.Id, move.Release.InstanceId)
+		if rel == nil {
+			return nil, status.Errorf(codes.NotFound, "Release %v is not in the collection", move.Release.Id)
+		}
+		return syncer.MoveToFolder(r.Context(), &pb.ReleaseMove{Release: &pbd.Release{Id: rel.Id, InstanceId: rel.InstanceId, FolderId: rel.FolderId}, NewFolderId: move.NewFolderId})
+	}},
+	{"GET", "/search", func(syncer *Syncer, r *http.Request, params []string) (proto.Message, error) {
+		req := &pb.SearchRequest{Query: r.URL.Query().Get("q"), Tags: r.URL.Query()["tag"]}
+		for _, f := range r.URL.Query()["field"] {
+			parts := strings.SplitN(f, "=", 2)
+			if len(parts) != 2 {
+				return nil, status.Errorf(codes.InvalidArgument, "Fields are name=value, not %v", f)
+			}
+			req.Fields = append(req.Fields, &pb.CustomField{Name: parts[0], Value: parts[1]})
+		}
+		return syncer.Search(r.Context(), req)
+	}},
+	{"GET", "/spend", func(syncer *Syncer, r *http.Request, params []string) (proto.Message, error) {
+		year, err := queryID(r, "year")
+		if err != nil {
+			return nil, err
+		}
+		month, err := queryID(r, "month")
+		if err != nil {
+			return nil, err
+		}
+		return syncer.GetSpend(r.Context(), &pb.SpendRequest{Year: year, Month: month, Tags: r.URL.Query()["tag"]})
+	}},
+	{"GET", "/wantlist", func(syncer *Syncer, r *http.Request, params []string) (proto.Message, error) {
+		priority, err := queryID(r, "min_priority")
+		if err != nil {
+			return nil, err
+		}
+		return syncer.GetWantlist(r.Context(), &pb.WantlistRequest{MinPriority: priority, Tags: r.URL.Query()["tag"], WantedOnly: r.URL.Query().Get("wanted_only") == "true"})
+	}},
+	{"POST", "/wantlist", func(syncer *Syncer, r *http.Request, params []string) (proto.Message, error) {
+		want := &pb.Want{}
+		if err := readRequest(r, want); err != nil {
+			return nil, err
+		}
+		if want.ReleaseId == 0 && want.MasterId == 0 {
+			return nil, status.Error(codes.InvalidArgument, "Wants need a release or a master")
+		}
+		return syncer.AddWant(r.Context(), want)
+	}},
+	{"PUT", "/wantlist/{id}", func(syncer *Syncer, r *http.Request, params []string) (proto.Message, error) {
+		return syncer.gatewayEditWant(r, params[0], false)
+	}},
+	{"DELETE", "/wantlist/{id}", func(syncer *Syncer, r *http.Request, params []string) (proto.Message, error) {
+		return syncer.gatewayDeleteWant(r, params[0], false)
+	}},
+	{"PUT", "/wantlist/master/{id}", func(syncer *Syncer, r *http.Request, params []string) (proto.Message, error) {
+		return syncer.gatewayEditWant(r, params[0], true)
+	}},
+	{"DELETE", "/wantlist/master/{id}", func(syncer *Syncer, r *http.Request, params []string) (proto.Message, error) {
+		return syncer.gatewayDeleteWant(r, params[0], true)
+	}},
+	{"POST", "/sync", func(syncer *Syncer, r *http.Request, params []string) (proto.Message, error) {
+		return syncer.SyncWithDiscogs(r.Context(), &pb.Empty{})
+	}},
+}
+
+// wantFor builds the want an id in the path refers to, either a release or a master
+func wantFor(value string, master bool) (*pb.Want, error) {
+	if master {
+		id, err := parseID(value, "master id")
+		return &pb.Want{MasterId: id}, err
+	}
+	id, err := parseID(value, "release id")
+	return &pb.Want{ReleaseId: id}, err
+}
+
+func (syncer *Syncer) gatewayEditWant(r *http.Request, value string, master bool) (proto.Message, error) {
+	key, err := wantFor(value, master)
+	if err != nil {
+		return nil, err
+	}
+	data, err := readBody(r)
+	if err != nil {
+		return nil, err
+	}
+	want := &pb.Want{}
+	if err := readProto(data, want); err != nil {
+		return nil, err
+	}
+	want.ReleaseId = key.ReleaseId
+	want.MasterId = key.MasterId
+
+	// Set whatever is in the body, so fields can be cleared by sending them empty
+	if len(want.UpdateMask) == 0 {
+		mask, err := bodyMask(data)
+		if err != nil {
+			return nil, err
+		}
+		want.UpdateMask = mask
+	}
+	return syncer.EditWant(r.Context(), want)
+}
+
+func (syncer *Syncer) gatewayDeleteWant(r *http.Request, value string, master bool) (proto.Message, error) {
+	want, err := wantFor(value, master)
+	if err != nil {
+		return nil, err
+	}
+	return syncer.DeleteWant(r.Context(), want)
+}
+
+// lowerCamel gives the other name jsonpb accepts for a field, update_mask as updateMask
+func lowerCamel(name string) string {
+	parts := strings.Split(name, "_")
+	for i := 1; i < len(parts); i++ {
+		if len(parts[i]) > 0 {
+			parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
+
+// bodyMask lists the want fields a JSON body sets, under either of their names, failing
+// on anything we wouldn't edit rather than silently dropping it
+func bodyMask(data []byte) ([]string, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Unable to parse request: %v", err)
+	}
+
+	var mask []string
+	for key := range fields {
+		found := false
+		for _, f := range wantFields {
+			if key == f.name || key == lowerCamel(f.name) {
+				mask = append(mask, f.name)
+				found = true
+			}
+		}
+		if !found && key != "release_id" && key != "releaseId" && key != "master_id" && key != "masterId" {
+			return nil, status.Errorf(codes.InvalidArgument, "%v cannot be edited", key)
+		}
+	}
+	if len(mask) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "Nothing to edit")
+	}
+	sort.Strings(mask)
+	return mask, nil
+}
+
+func readRequest(r *http.Request, m proto.Message) error {
+	data, err := readBody(r)
+	if err != nil {
+		return err
+	}
+	return readProto(data, m)
+}
+
+// folderSpec treats a numeric folder as an id and anything else as a name
+func folderSpec(value string) *pbd.Folder {
+	if id, err := strconv.Atoi(value); err == nil {
+		return &pbd.Folder{Id: int32(id)}
+	}
+	return &pbd.Folder{Name: value}
+}
+
+func (syncer *Syncer) knownFolder(folder *pbd.Folder) bool {
+	if syncer.findSmartFolder(folder) != nil {
+		return true
+	}
+	if len(folder.Name) > 0 {
+		return syncer.folderByName(folder.Name) != nil
+	}
+	return syncer.findFolder(folder.Id) != nil
+}
+
+// ownedRelease finds a copy of the release in the collection, the given instance if
+// that's set
+func (syncer *Syncer) ownedRelease(id, instance int32) *pbd.Release {
+	for _, f := range syncer.collection.Folders {
+		for _, rel := range f.GetReleases().GetReleases() {
+			if rel.Id == id && (instance == 0 || rel.InstanceId == instance) {
+				return rel
+			}
+		}
+	}
+	return nil
+}
+
+func (syncer *Syncer) cachedRelease(id int32) *pbd.Release {
+	for _, rel := range syncer.collection.GetCache().GetReleases() {
+		if rel.Id == id {
+			return rel
+		}
+	}
+	return nil
+}
+
+// gatewayRelease finds the copy a release path refers to, picked with ?instance= when
+// we own more than one
+func (syncer *Syncer) gatewayRelease(r *http.Request, value string) (*pbd.Release, error) {
+	id, err := parseID(value, "release id")
+	if err != nil {
+		return nil, err
+	}
+	instance, err := queryID(r, "instance")
+	if err != nil {
+		return nil, err
+	}
+	rel := syncer.ownedRelease(id, instance)
+	if rel == nil {
+		return nil, status.Errorf(codes.NotFound, "Release %v is not in the collection", id)
+	}
+	return rel, nil
+}
+
+// jsonRequest checks a request says it's sending JSON; browsers can't send that cross site
+// without asking first, which keeps forms on other sites away from the write routes
+func jsonRequest(r *http.Request) bool {
+	t, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	return err == nil && t == "application/json"
+}
+
+// gateway serves the DiscogsService as JSON over HTTP
+type gateway struct {
+	syncer *Syncer
+}
+
+func (g gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var allowed []string
+	for _, rt := range routes {
+		params, ok := matchPath(rt.pattern, r.URL.Path)
+		if !ok {
+			continue
+		}
+		if rt.method != r.Method {
+			allowed = append(allowed, rt.method)
+			continue
+		}
+		if r.Method != "GET" && r.Method != "DELETE" && !jsonRequest(r) {
+			writeStatus(w, http.StatusUnsupportedMediaType, "UnsupportedMediaType", r.Method+" "+r.URL.Path+" needs a Content-Type of application/json")
+			return
+		}
+
+		resp, err := rt.call(g.syncer, r, params)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		writeProto(w, resp)
+		return
+	}
+
+	if len(allowed) > 0 {
+		w.Header().Set("Allow", strings.Join(allowed, ", "))
+		writeStatus(w, http.StatusMethodNotAllowed, "MethodNotAllowed", r.Method+" is not supported for "+r.URL.Path)
+		return
+	}
+	writeError(w, status.Errorf(codes.NotFound, "Nothing is served at %v", r.URL.Path))
+}
+
+// serveHTTP returns a serving task running the JSON gateway and the web UI on addr
+func (syncer *Syncer) serveHTTP(addr string) func() {
+	return func() {
+		mux := http.NewServeMux()
+		mux.Handle("/ui/", webUI{syncer: syncer})
+		mux.Handle("/", gateway{syncer: syncer})
+		err := http.ListenAndServe(addr, mux)
+		log.Printf("HTTP server has stopped: %v", err)
+	}
+}
@@ -0,0 +1,314 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/jsonpb"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/brotherlogic/discogssyncer/server"
+	pbd "github.com/brotherlogic/godiscogs"
+)
+
+func gatewaySyncer(foldername string) *Syncer {
+	os.RemoveAll(foldername)
+	return offlineSyncer(foldername, generate(syntheticConfig{releases: 100, wants: 10, seed: 1}))
+}
+
+func serve(syncer *Syncer, method, path, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(method, path, strings.NewReader(body))
+	if method != "GET" && method != "DELETE" {
+		r.Header.Set("Content-Type", "application/json")
+	}
+	gateway{syncer: syncer}.ServeHTTP(w, r)
+	return w
+}
+
+func TestGatewayCollection(t *testing.T) {
+	syncer := gatewaySyncer(".testgatewaycollection")
+	w := serve(syncer, "GET", "/collection", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("Bad response: %v %v", w.Code, w.Body.String())
+	}
+
+	releases := &pb.ReleaseList{}
+	if err := jsonpb.Unmarshal(w.Body, releases); err != nil {
+		t.Fatalf("Unable to read response: %v", err)
+	}
+	if len(releases.Releases) != countInstances(syncer.collection) {
+		t.Errorf("Wrong number of releases: %v vs %v", len(releases.Releases), countInstances(syncer.collection))
+	}
+}
+
+func TestGatewayFolder(t *testing.T) {
+	syncer := gatewaySyncer(".testgatewayfolder")
+	folder := syncer.collection.Folders[1].Folder
+	for _, name := range []string{fmt.Sprintf("%v", folder.Id), folder.Name} {
+		w := serve(syncer, "GET", "/folders/"+name, "")
+		records := &pb.RecordList{}
+		if w.Code != http.StatusOK || jsonpb.Unmarshal(w.Body, records) != nil {
+			t.Fatalf("Bad response for %v: %v %v", name, w.Code, w.Body.String())
+		}
+		if len(records.Records) != len(syncer.getReleases(folder.Id).Releases) {
+			t.Errorf("Wrong releases for %v: %v", name, len(records.Records))
+		}
+	}
+
+	w := serve(syncer, "GET", "/folders/12345678", "")
+	e := gatewayError{}
+	json.NewDecoder(w.Body).Decode(&e)
+	if w.Code != http.StatusNotFound || e.Code != "NotFound" {
+		t.Errorf("Missing folder has not been reported: %v %v", w.Code, e)
+	}
+}
+
+func TestGatewayUpdateMetadata(t *testing.T) {
+	syncer := gatewaySyncer(".testgatewaymetadata")
+	rel := syncer.collection.Folders[1].Releases.Releases[0]
+	syncer.findMetadata(rel.Id).Others = true
+	path := fmt.Sprintf("/releases/%v/metadata", rel.Id)
+
+	w := serve(syncer, "PATCH", path, `{"cost": 1234}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Bad update: %v %v", w.Code, w.Body.String())
+	}
+
+	w = serve(syncer, "GET", path, "")
+	md := &pb.ReleaseMetadata{}
+	if err := jsonpb.Unmarshal(w.Body, md); err != nil {
+		t.Fatalf("Unable to read metadata: %v", err)
+	}
+	if md.Cost != 1234 || !md.Others {
+		t.Errorf("Update has not been applied: %v", md)
+	}
+
+	w = serve(syncer, "PATCH", path, `{"others": false}`)
+	if w.Code != http.StatusOK || syncer.findMetadata(rel.Id).Others {
+		t.Errorf("Others has not been cleared: %v %v", w.Code, w.Body.String())
+	}
+}
+
+func TestGatewayMoveAndRate(t *testing.T) {
+	syncer := gatewaySyncer(".testgatewaymove")
+	rel := syncer.collection.Folders[1].Releases.Releases[0]
+	dest := syncer.collection.Folders[2].Folder.Id
+
+	w := serve(syncer, "POST", "/moves", fmt.Sprintf(`{"release": {"id": %v, "instance_id": %v}, "new_folder_id": %v}`, rel.Id, rel.InstanceId, dest))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Bad move: %v %v", w.Code, w.Body.String())
+	}
+	moved := syncer.ownedRelease(rel.Id, 0)
+	if moved == nil || moved.FolderId != dest {
+		t.Errorf("Release has not moved: %v", moved)
+	}
+
+	w = serve(syncer, "PUT", fmt.Sprintf("/releases/%v/rating", rel.Id), `{"rating": 4}`)
+	if w.Code != http.StatusOK || syncer.ownedRelease(rel.Id, 0).Rating != 4 {
+		t.Errorf("Rating has not been set: %v %v", w.Code, w.Body.String())
+	}
+}
+
+func TestGatewayWantlist(t *testing.T) {
+	syncer := gatewaySyncer(".testgatewaywantlist")
+	before := len(syncer.collection.Wantlist.Want)
+
+	w := serve(syncer, "POST", "/wantlist", `{"release_id": 4242}`)
+	if w.Code != http.StatusOK || len(syncer.collection.Wantlist.Want) != before+1 {
+		t.Fatalf("Want has not been added: %v %v", w.Code, w.Body.String())
+	}
+
+	syncer.collection.Wantlist.Want[before].Valued = true
+	w = serve(syncer, "PUT", "/wantlist/4242", `{"priority": 3, "note": ""}`)
+	if w.Code != http.StatusOK || !syncer.collection.Wantlist.Want[before].Valued || syncer.collection.Wantlist.Want[before].Priority != 3 {
+		t.Errorf("Want has been badly edited: %v %v", w.Code, w.Body.String())
+	}
+
+	w = serve(syncer, "DELETE", "/wantlist/4242", "")
+	if w.Code != http.StatusOK || len(syncer.collection.Wantlist.Want) != before {
+		t.Errorf("Want has not been deleted: %v %v", w.Code, w.Body.String())
+	}
+
+	for _, method := range []string{"PUT", "DELETE"} {
+		if w = serve(syncer, method, "/wantlist/4242", `{"priority": 1}`); w.Code != http.StatusNotFound {
+			t.Errorf("%v of a missing want gave %v: %v", method, w.Code, w.Body.String())
+		}
+	}
+}
+
+func TestGatewayMasterWants(t *testing.T) {
+	syncer := gatewaySyncer(".testgatewaymasterwants")
+	before := len(syncer.collection.Wantlist.Want)
+
+	w := serve(syncer, "POST", "/wantlist", `{"master_id": 4545}`)
+	if w.Code != http.StatusOK || len(syncer.collection.Wantlist.Want) != before+1 {
+		t.Fatalf("Master want has not been added: %v %v", w.Code, w.Body.String())
+	}
+
+	// A release with the same id as the master is a different want
+	if w = serve(syncer, "PUT", "/wantlist/4545", `{"priority": 2}`); w.Code != http.StatusNotFound {
+		t.Errorf("Master want has been edited as a release: %v %v", w.Code, w.Body.String())
+	}
+	w = serve(syncer, "PUT", "/wantlist/master/4545", `{"priority": 2, "country": "UK"}`)
+	want := syncer.collection.Wantlist.Want[before]
+	if w.Code != http.StatusOK || want.Priority != 2 || want.Country != "UK" || want.ReleaseId != 0 {
+		t.Errorf("Master want has been badly edited: %v %v", w.Code, want)
+	}
+
+	w = serve(syncer, "DELETE", "/wantlist/master/4545", "")
+	if w.Code != http.StatusOK || len(syncer.collection.Wantlist.Want) != before {
+		t.Errorf("Master want has not been deleted: %v %v", w.Code, w.Body.String())
+	}
+	if w = serve(syncer, "DELETE", "/wantlist/master/abc", ""); w.Code != http.StatusBadRequest {
+		t.Errorf("Bad master id gave %v", w.Code)
+	}
+}
+
+func TestBodyMask(t *testing.T) {
+	mask, err := bodyMask([]byte(`{"note": "hi", "maxPrice": 4321, "min_year": 0}`))
+	if err != nil || strings.Join(mask, ",") != "max_price,min_year,note" {
+		t.Errorf("Bad mask: %v (%v)", mask, err)
+	}
+
+	for _, body := range []string{`{"maxprice": 1}`, `{}`, `[]`} {
+		if mask, err := bodyMask([]byte(body)); err == nil {
+			t.Errorf("%v has not been refused: %v", body, mask)
+		}
+	}
+}
+
+func TestGatewaySearchFields(t *testing.T) {
+	syncer := GetTestSyncer(".testgatewaysearchfields", true)
+	addTestFields(syncer)
+	syncer.saveRelease(&pbd.Release{FolderId: 23, Id: 25, InstanceId: 37, Title: "Kind of Blue"}, 23)
+	syncer.saveRelease(&pbd.Release{FolderId: 23, Id: 27, InstanceId: 39, Title: "Blue Train"}, 23)
+	syncer.UpdateMetadata(context.Background(), &pb.MetadataUpdate{Release: &pbd.Release{Id: 25, FolderId: 23}, Update: &pb.ReleaseMetadata{Custom: []*pb.CustomField{&pb.CustomField{Name: "grade", Value: "NM"}}}})
+
+	w := serve(syncer, "GET", "/search?q=blue&field=grade%3DNM", "")
+	releases := &pb.ReleaseList{}
+	if w.Code != http.StatusOK || jsonpb.Unmarshal(w.Body, releases) != nil || len(releases.Releases) != 1 || releases.Releases[0].Id != 25 {
+		t.Errorf("Field search has failed: %v %v", w.Code, releases)
+	}
+}
+
+func TestGatewayBadRequests(t *testing.T) {
+	syncer := gatewaySyncer(".testgatewaybad")
+	addTestFields(syncer)
+	metadata := fmt.Sprintf("/releases/%v/metadata", syncer.collection.Folders[1].Releases.Releases[0].Id)
+	var tests = []struct {
+		method, path, body string
+		code               int
+	}{
+		{"GET", "/releases/abc/metadata", "", http.StatusBadRequest},
+		{"GET", "/releases/1/metadata", "", http.StatusNotFound},
+		{"POST", "/moves", "not json", http.StatusBadRequest},
+		{"POST", "/moves", `{"new_folder_id": 3}`, http.StatusBadRequest},
+		{"PUT", fmt.Sprintf("/releases/%v/rating", syncer.collection.Folders[1].Releases.Releases[0].Id), `{"rating": 9}`, http.StatusBadRequest},
+		{"POST", "/folders", `{"name": "Uncategorized"}`, http.StatusConflict},
+		{"DELETE", "/collection", "", http.StatusMethodNotAllowed},
+		{"GET", "/nothing/here", "", http.StatusNotFound},
+		{"GET", "/search?field=grade", "", http.StatusBadRequest},
+		{"PATCH", metadata, `{"custom": [{"name": "grade", "value": "Mint"}]}`, http.StatusBadRequest},
+		{"PATCH", metadata, `{"custom": [{"name": "cleaned", "value": "yesterday"}]}`, http.StatusBadRequest},
+		{"PATCH", metadata, `{"custom": [{"name": "colour", "value": "red"}]}`, http.StatusNotFound},
+	}
+
+	for _, test := range tests {
+		w := serve(syncer, test.method, test.path, test.body)
+		if w.Code != test.code {
+			t.Errorf("%v %v gave %v, expected %v: %v", test.method, test.path, w.Code, test.code, w.Body.String())
+		}
+	}
+
+	if serve(syncer, "DELETE", "/collection", "").Header().Get("Allow") != "GET" {
+		t.Errorf("Allowed methods have not been reported")
+	}
+}
+
+func TestGatewayNeedsJSON(t *testing.T) {
+	syncer := gatewaySyncer(".testgatewayjson")
+	var tests = []struct {
+		contentType string
+		code        int
+	}{
+		{"", http.StatusUnsupportedMediaType},
+		{"text/plain", http.StatusUnsupportedMediaType},
+		{"application/x-www-form-urlencoded", http.StatusUnsupportedMediaType},
+		{"application/json; charset=utf-8", http.StatusOK},
+	}
+
+	for _, test := range tests {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest("POST", "/wantlist", strings.NewReader(`{"release_id": 4343}`))
+		if len(test.contentType) > 0 {
+			r.Header.Set("Content-Type", test.contentType)
+		}
+		gateway{syncer: syncer}.ServeHTTP(w, r)
+		if w.Code != test.code {
+			t.Errorf("%q gave %v, expected %v: %v", test.contentType, w.Code, test.code, w.Body.String())
+		}
+	}
+
+	if len(syncer.collection.Wantlist.Want) != 11 {
+		t.Errorf("Wants have been added without JSON: %v", syncer.collection.Wantlist)
+	}
+}
+
+func TestGatewayReleaseIsReadOnly(t *testing.T) {
+	syncer := gatewaySyncer(".testgatewayrelease")
+	rel := syncer.collection.Folders[1].Releases.Releases[0]
+	syncer.cacheRelease(&pbd.Release{Id: 4444, Title: "Wanted"})
+
+	for _, id := range []int32{rel.Id, 4444} {
+		if w := serve(syncer, "GET", fmt.Sprintf("/releases/%v", id), ""); w.Code != http.StatusOK {
+			t.Errorf("Release %v has not been served: %v %v", id, w.Code, w.Body.String())
+		}
+	}
+
+	cached := len(syncer.collection.Cache.Releases)
+	syncer.retr = &testDiscogsRetriever{}
+	w := serve(syncer, "GET", "/releases/25", "")
+	if w.Code != http.StatusNotFound || len(syncer.collection.Cache.Releases) != cached {
+		t.Errorf("Unknown release has been looked up: %v %v, %v", w.Code, w.Body.String(), syncer.collection.Cache)
+	}
+}
+
+func TestWriteError(t *testing.T) {
+	var tests = []struct {
+		err  error
+		code int
+		name string
+	}{
+		{status.Error(codes.AlreadyExists, "Twice"), http.StatusConflict, "AlreadyExists"},
+		{status.Error(codes.Unavailable, "Down"), http.StatusServiceUnavailable, "Unavailable"},
+		{errors.New("Plain"), http.StatusInternalServerError, "Unknown"},
+	}
+
+	for _, test := range tests {
+		w := httptest.NewRecorder()
+		writeError(w, test.err)
+		e := gatewayError{}
+		json.NewDecoder(w.Body).Decode(&e)
+		if w.Code != test.code || e.Code != test.name || len(e.Message) == 0 {
+			t.Errorf("%v has been written as %v %v", test.err, w.Code, e)
+		}
+	}
+}
+
+func TestFolderSpec(t *testing.T) {
+	if folderSpec("12").Id != 12 || folderSpec("Jazz").Name != "Jazz" {
+		t.Errorf("Bad folder specs: %v, %v", folderSpec("12"), folderSpec("Jazz"))
+	}
+	if folderSpec("12").Name != "" || folderSpec("Jazz").Id != 0 {
+		t.Errorf("Folder specs have been mixed up")
+	}
+}
@@ -0,0 +1,382 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"math/rand"
+	"os"
+	"sort"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/brotherlogic/goserver"
+	"github.com/brotherlogic/keystore/client"
+	"github.com/golang/protobuf/proto"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+
+	pb "github.com/brotherlogic/discogssyncer/server"
+	pbd "github.com/brotherlogic/godiscogs"
+)
+
+// loadService is the part of the service the load test drives, served in process by the
+// Syncer itself or remotely through remoteService
+type loadService interface {
+	GetCollection(ctx context.Context, in *pb.Empty) (*pb.ReleaseList, error)
+	GetReleasesInFolder(ctx context.Context, in *pb.FolderList) (*pb.RecordList, error)
+	Search(ctx context.Context, in *pb.SearchRequest) (*pb.ReleaseList, error)
+	GetMetadata(ctx context.Context, in *pbd.Release) (*pb.ReleaseMetadata, error)
+	UpdateMetadata(ctx context.Context, in *pb.MetadataUpdate) (*pb.ReleaseMetadata, error)
+	UpdateRating(ctx context.Context, in *pbd.Release) (*pb.Empty, error)
+	GetSpend(ctx context.Context, in *pb.SpendRequest) (*pb.SpendResponse, error)
+	GetWantlist(ctx context.Context, in *pb.WantlistRequest) (*pb.Wantlist, error)
+}
+
+type remoteService struct {
+	client pb.DiscogsServiceClient
+}
+
+func (r remoteService) GetCollection(ctx context.Context, in *pb.Empty) (*pb.ReleaseList, error) {
+	return r.client.GetCollection(ctx, in)
+}
+func (r remoteService) GetReleasesInFolder(ctx context.Context, in *pb.FolderList) (*pb.RecordList, error) {
+	return r.client.GetReleasesInFolder(ctx, in)
+}
+func (r remoteService) Search(ctx context.Context, in *pb.SearchRequest) (*pb.ReleaseList, error) {
+	return r.client.Search(ctx, in)
+}
+func (r remoteService) GetMetadata(ctx context.Context, in *pbd.Release) (*pb.ReleaseMetadata, error) {
+	return r.client.GetMetadata(ctx, in)
+}
+func (r remoteService) UpdateMetadata(ctx context.Context, in *pb.MetadataUpdate) (*pb.ReleaseMetadata, error) {
+	return r.client.UpdateMetadata(ctx, in)
+}
+func (r remoteService) UpdateRating(ctx context.Context, in *pbd.Release) (*pb.Empty, error) {
+	return r.client.UpdateRating(ctx, in)
+}
+func (r remoteService) GetSpend(ctx context.Context, in *pb.SpendRequest) (*pb.SpendResponse, error) {
+	return r.client.GetSpend(ctx, in)
+}
+func (r remoteService) GetWantlist(ctx context.Context, in *pb.WantlistRequest) (*pb.Wantlist, error) {
+	return r.client.GetWantlist(ctx, in)
+}
+
+// localService serves the load test from an in process syncer. The syncer leaves locking
+// to its callers, so writes are serialised against everything else and replies are copied
+// out under the lock, as they would be on the wire.
+type localService struct {
+	syncer *Syncer
+	lock   *sync.RWMutex
+}
+
+func (l localService) read(get func() (proto.Message, error)) (proto.Message, error) {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+	m, err := get()
+	if err != nil {
+		return nil, err
+	}
+	return proto.Clone(m), nil
+}
+
+func (l localService) write(set func() (proto.Message, error)) (proto.Message, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	m, err := set()
+	if err != nil {
+		return nil, err
+	}
+	return proto.Clone(m), nil
+}
+
+func (l localService) GetCollection(ctx context.Context, in *pb.Empty) (*pb.ReleaseList, error) {
+	m, err := l.read(func() (proto.Message, error) { return l.syncer.GetCollection(ctx, in) })
+	if err != nil {
+		return nil, err
+	}
+	return m.(*pb.ReleaseList), nil
+}
+func (l localService) GetReleasesInFolder(ctx context.Context, in *pb.FolderList) (*pb.RecordList, error) {
+	m, err := l.read(func() (proto.Message, error) { return l.syncer.GetReleasesInFolder(ctx, in) })
+	if err != nil {
+		return nil, err
+	}
+	return m.(*pb.RecordList), nil
+}
+func (l localService) Search(ctx context.Context, in *pb.SearchRequest) (*pb.ReleaseList, error) {
+	m, err := l.read(func() (proto.Message, error) { return l.syncer.Search(ctx, in) })
+	if err != nil {
+		return nil, err
+	}
+	return m.(*pb.ReleaseList), nil
+}
+func (l localService) GetMetadata(ctx context.Context, in *pbd.Release) (*pb.ReleaseMetadata, error) {
+	m, err := l.read(func() (proto.Message, error) { return l.syncer.GetMetadata(ctx, in) })
+	if err != nil {
+		return nil, err
+	}
+	return m.(*pb.ReleaseMetadata), nil
+}
+func (l localService) UpdateMetadata(ctx context.Context, in *pb.MetadataUpdate) (*pb.ReleaseMetadata, error) {
+	m, err := l.write(func() (proto.Message, error) { return l.syncer.UpdateMetadata(ctx, in) })
+	if err != nil {
+		return nil, err
+	}
+	return m.(*pb.ReleaseMetadata), nil
+}
+func (l localService) UpdateRating(ctx context.Context, in *pbd.Release) (*pb.Empty, error) {
+	m, err := l.write(func() (proto.Message, error) { return l.syncer.UpdateRating(ctx, in) })
+	if err != nil {
+		return nil, err
+	}
+	return m.(*pb.Empty), nil
+}
+func (l localService) GetSpend(ctx context.Context, in *pb.SpendRequest) (*pb.SpendResponse, error) {
+	m, err := l.read(func() (proto.Message, error) { return l.syncer.GetSpend(ctx, in) })
+	if err != nil {
+		return nil, err
+	}
+	return m.(*pb.SpendResponse), nil
+}
+func (l localService) GetWantlist(ctx context.Context, in *pb.WantlistRequest) (*pb.Wantlist, error) {
+	m, err := l.read(func() (proto.Message, error) { return l.syncer.GetWantlist(ctx, in) })
+	if err != nil {
+		return nil, err
+	}
+	return m.(*pb.Wantlist), nil
+}
+
+type loadConfig struct {
+	workers  int
+	duration time.Duration
+	seed     int64
+
+	// Include writes in the workload
+	writes bool
+}
+
+// loadOp is one kind of call in the workload, picked in proportion to its weight
+type loadOp struct {
+	name   string
+	weight int
+	writes bool
+	run    func(ctx context.Context, s loadService, r *rand.Rand, releases []*pbd.Release) error
+}
+
+func pickRelease(r *rand.Rand, releases []*pbd.Release) *pbd.Release {
+	return releases[r.Intn(len(releases))]
+}
+
+var loadOps = []loadOp{
+	{"GetCollection", 1, false, func(ctx context.Context, s loadService, r *rand.Rand, releases []*pbd.Release) error {
+		_, err := s.GetCollection(ctx, &pb.Empty{})
+		return err
+	}},
+	{"GetReleasesInFolder", 4, false, func(ctx context.Context, s loadService, r *rand.Rand, releases []*pbd.Release) error {
+		_, err := s.GetReleasesInFolder(ctx, &pb.FolderList{Folders: []*pbd.Folder{&pbd.Folder{Id: pickRelease(r, releases).FolderId}}})
+		return err
+	}},
+	{"Search", 3, false, func(ctx context.Context, s loadService, r *rand.Rand, releases []*pbd.Release) error {
+		_, err := s.Search(ctx, &pb.SearchRequest{Query: pickRelease(r, releases).Title})
+		return err
+	}},
+	{"GetMetadata", 6, false, func(ctx context.Context, s loadService, r *rand.Rand, releases []*pbd.Release) error {
+		rel := pickRelease(r, releases)
+		_, err := s.GetMetadata(ctx, &pbd.Release{Id: rel.Id, FolderId: rel.FolderId})
+		return err
+	}},
+	{"GetSpend", 1, false, func(ctx context.Context, s loadService, r *rand.Rand, releases []*pbd.Release) error {
+		_, err := s.GetSpend(ctx, &pb.SpendRequest{Year: int32(2000 + r.Intn(18))})
+		return err
+	}},
+	{"GetWantlist", 2, false, func(ctx context.Context, s loadService, r *rand.Rand, releases []*pbd.Release) error {
+		_, err := s.GetWantlist(ctx, &pb.WantlistRequest{})
+		return err
+	}},
+	{"UpdateMetadata", 2, true, func(ctx context.Context, s loadService, r *rand.Rand, releases []*pbd.Release) error {
+		rel := pickRelease(r, releases)
+		md, err := s.GetMetadata(ctx, &pbd.Release{Id: rel.Id, FolderId: rel.FolderId})
+		if err != nil {
+			return err
+		}
+		_, err = s.UpdateMetadata(ctx, &pb.MetadataUpdate{Release: &pbd.Release{Id: rel.Id, FolderId: rel.FolderId}, Update: &pb.ReleaseMetadata{Cost: int32(500 + r.Intn(4000)), Others: md.Others}})
+		return err
+	}},
+	{"UpdateRating", 1, true, func(ctx context.Context, s loadService, r *rand.Rand, releases []*pbd.Release) error {
+		rel := pickRelease(r, releases)
+		_, err := s.UpdateRating(ctx, &pbd.Release{Id: rel.Id, InstanceId: rel.InstanceId, FolderId: rel.FolderId, Rating: int32(r.Intn(6))})
+		return err
+	}},
+}
+
+// opStats holds the latencies we saw for one kind of call
+type opStats struct {
+	latencies []time.Duration
+	errors    int
+}
+
+type durations []time.Duration
+
+func (d durations) Len() int           { return len(d) }
+func (d durations) Less(i, j int) bool { return d[i] < d[j] }
+func (d durations) Swap(i, j int)      { d[i], d[j] = d[j], d[i] }
+
+// percentile is the nearest rank percentile of sorted latencies
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	i := int(math.Ceil(p/100*float64(len(sorted)))) - 1
+	if i < 0 {
+		i = 0
+	}
+	return sorted[i]
+}
+
+type loadReport struct {
+	elapsed time.Duration
+	stats   map[string]*opStats
+}
+
+// runLoad drives the workload against s from cfg.workers goroutines until cfg.duration is up
+func runLoad(s loadService, cfg loadConfig) (*loadReport, error) {
+	col, err := s.GetCollection(context.Background(), &pb.Empty{})
+	if err != nil {
+		return nil, err
+	}
+	if len(col.GetReleases()) == 0 {
+		return nil, errors.New("Nothing in the collection to load test against")
+	}
+
+	var ops []loadOp
+	total := 0
+	for _, op := range loadOps {
+		if !op.writes || cfg.writes {
+			ops = append(ops, op)
+			total += op.weight
+		}
+	}
+
+	results := make([]map[string]*opStats, cfg.workers)
+	var wg sync.WaitGroup
+	start := time.Now()
+	deadline := start.Add(cfg.duration)
+	for w := 0; w < cfg.workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			r := rand.New(rand.NewSource(cfg.seed + int64(w)))
+			stats := make(map[string]*opStats)
+			for time.Now().Before(deadline) {
+				pick := r.Intn(total)
+				op := ops[0]
+				for _, o := range ops {
+					if pick < o.weight {
+						op = o
+						break
+					}
+					pick -= o.weight
+				}
+
+				if stats[op.name] == nil {
+					stats[op.name] = &opStats{}
+				}
+				t := time.Now()
+				err := op.run(context.Background(), s, r, col.Releases)
+				stats[op.name].latencies = append(stats[op.name].latencies, time.Since(t))
+				if err != nil {
+					stats[op.name].errors++
+				}
+			}
+			results[w] = stats
+		}(w)
+	}
+	wg.Wait()
+
+	report := &loadReport{elapsed: time.Since(start), stats: make(map[string]*opStats)}
+	for _, stats := range results {
+		for name, st := range stats {
+			if report.stats[name] == nil {
+				report.stats[name] = &opStats{}
+			}
+			report.stats[name].latencies = append(report.stats[name].latencies, st.latencies...)
+			report.stats[name].errors += st.errors
+		}
+	}
+	for _, st := range report.stats {
+		sort.Sort(durations(st.latencies))
+	}
+	return report, nil
+}
+
+func (r *loadReport) print(w io.Writer) {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "RPC\tCALLS\tERRORS\tP50\tP90\tP99\tMAX\t")
+	var names []string
+	for name := range r.stats {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	calls := 0
+	for _, name := range names {
+		st := r.stats[name]
+		calls += len(st.latencies)
+		fmt.Fprintf(tw, "%v\t%v\t%v\t%v\t%v\t%v\t%v\t\n", name, len(st.latencies), st.errors,
+			percentile(st.latencies, 50), percentile(st.latencies, 90), percentile(st.latencies, 99), percentile(st.latencies, 100))
+	}
+	tw.Flush()
+	fmt.Fprintf(w, "%v calls in %v, %.1f calls/s\n", calls, r.elapsed, float64(calls)/r.elapsed.Seconds())
+}
+
+// offlineSyncer builds a syncer which keeps its collection under dir and never talks to discogs
+func offlineSyncer(dir string, data *synthetic) *Syncer {
+	syncer := &Syncer{
+		GoServer:    &goserver.GoServer{},
+		retr:        newOfflineRetriever(data),
+		collection:  data.collection,
+		rMap:        make(map[int]*pbd.Release),
+		mMap:        make(map[int32]*pb.ReleaseMetadata),
+		recacheList: make(map[int]*pbd.Release),
+		mapM:        &sync.Mutex{},
+		throttleM:   &sync.Mutex{},
+	}
+	syncer.SkipLog = true
+	syncer.Register = syncer
+	syncer.GoServer.KSclient = *keystoreclient.GetTestClient(dir)
+	return syncer
+}
+
+// loadTest runs a load test against target, or an in process syncer holding a synthetic
+// collection of size releases if target is empty
+func loadTest(target string, size int, cfg loadConfig, w io.Writer) error {
+	var s loadService
+	if target == "" {
+		dir, err := ioutil.TempDir("", "discogssyncer-load")
+		if err != nil {
+			return err
+		}
+		defer os.RemoveAll(dir)
+		s = localService{syncer: offlineSyncer(dir, generate(syntheticConfig{releases: size, wants: size / 10, seed: cfg.seed})), lock: &sync.RWMutex{}}
+		fmt.Fprintf(w, "Load testing in process with %v releases\n", size)
+	} else {
+		conn, err := grpc.Dial(target, grpc.WithInsecure())
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+		s = remoteService{client: pb.NewDiscogsServiceClient(conn)}
+		fmt.Fprintf(w, "Load testing %v\n", target)
+	}
+
+	fmt.Fprintf(w, "%v workers for %v, writes: %v\n", cfg.workers, cfg.duration, cfg.writes)
+	report, err := runLoad(s, cfg)
+	if err != nil {
+		return err
+	}
+	report.print(w)
+	return nil
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPercentile(t *testing.T) {
+	var latencies []time.Duration
+	for i := 1; i <= 100; i++ {
+		latencies = append(latencies, time.Duration(i)*time.Millisecond)
+	}
+
+	if percentile(latencies, 50) != 50*time.Millisecond || percentile(latencies, 99) != 99*time.Millisecond || percentile(latencies, 100) != 100*time.Millisecond {
+		t.Errorf("Bad percentiles: %v, %v, %v", percentile(latencies, 50), percentile(latencies, 99), percentile(latencies, 100))
+	}
+	if percentile(nil, 50) != 0 || percentile(latencies[:1], 0) != time.Millisecond {
+		t.Errorf("Bad percentiles for short lists")
+	}
+}
+
+func TestLoadTestInProcess(t *testing.T) {
+	out := &bytes.Buffer{}
+	err := loadTest("", 200, loadConfig{workers: 4, duration: time.Millisecond * 200, seed: 1, writes: true}, out)
+	if err != nil {
+		t.Fatalf("Error running load test: %v", err)
+	}
+
+	for _, rpc := range []string{"GetMetadata", "Search", "UpdateMetadata", "calls/s"} {
+		if !strings.Contains(out.String(), rpc) {
+			t.Errorf("Report is missing %v: %v", rpc, out.String())
+		}
+	}
+}
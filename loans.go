@@ -0,0 +1,98 @@
+package main
+
+import (
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/brotherlogic/discogssyncer/server"
+	pbd "github.com/brotherlogic/godiscogs"
+)
+
+// currentLoan finds the open loan on a copy of a release
+func (syncer *Syncer) currentLoan(rel *pbd.Release) *pb.Loan {
+	for _, loan := range syncer.collection.Loans {
+		if loan.Returned == 0 && loan.ReleaseId == rel.Id && loan.InstanceId == rel.InstanceId {
+			return loan
+		}
+	}
+	return nil
+}
+
+func overdue(loan *pb.Loan, now time.Time) bool {
+	return loan.Returned == 0 && loan.Due > 0 && loan.Due < now.Unix()
+}
+
+func matchLoan(loan *pb.Loan, req *pb.LoanRequest, now time.Time) bool {
+	if req.ReleaseId > 0 && loan.ReleaseId != req.ReleaseId {
+		return false
+	}
+	if req.InstanceId > 0 && loan.InstanceId != req.InstanceId {
+		return false
+	}
+	if req.Borrower != "" && loan.Borrower != req.Borrower {
+		return false
+	}
+	if req.OverdueOnly && !overdue(loan, now) {
+		return false
+	}
+	return req.IncludeReturned || loan.Returned == 0
+}
+
+// LendRecord lends a copy of a record out
+func (syncer *Syncer) LendRecord(ctx context.Context, in *pb.Loan) (*pb.Loan, error) {
+	t := time.Now()
+	if in.Borrower == "" {
+		return nil, status.Error(codes.InvalidArgument, "Loans need a borrower")
+	}
+	rel := syncer.findInstance(in.ReleaseId, in.InstanceId)
+	if rel == nil {
+		return nil, status.Errorf(codes.NotFound, "Unable to find %v (instance %v) in the collection", in.ReleaseId, in.InstanceId)
+	}
+	if current := syncer.currentLoan(rel); current != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "%v is already out with %v", in.ReleaseId, current.Borrower)
+	}
+
+	loan := &pb.Loan{ReleaseId: rel.Id, InstanceId: rel.InstanceId, Borrower: in.Borrower, Lent: in.Lent, Due: in.Due}
+	if loan.Lent == 0 {
+		loan.Lent = t.Unix()
+	}
+
+	syncer.collection.Loans = append(syncer.collection.Loans, loan)
+	syncer.saveCollection()
+	syncer.LogFunction("LendRecord", t)
+	return loan, nil
+}
+
+// ReturnRecord marks a loan as returned
+func (syncer *Syncer) ReturnRecord(ctx context.Context, in *pb.Loan) (*pb.Loan, error) {
+	t := time.Now()
+	for _, loan := range syncer.collection.Loans {
+		if loan.Returned == 0 && loan.ReleaseId == in.ReleaseId && (in.InstanceId == 0 || loan.InstanceId == in.InstanceId) {
+			loan.Returned = in.Returned
+			if loan.Returned == 0 {
+				loan.Returned = t.Unix()
+			}
+			syncer.saveCollection()
+			syncer.LogFunction("ReturnRecord", t)
+			return loan, nil
+		}
+	}
+
+	return nil, status.Errorf(codes.NotFound, "%v is not out on loan", in.ReleaseId)
+}
+
+// ListLoans lists loans, by default those which are still out
+func (syncer *Syncer) ListLoans(ctx context.Context, in *pb.LoanRequest) (*pb.LoanList, error) {
+	t := time.Now()
+	list := &pb.LoanList{}
+	for _, loan := range syncer.collection.Loans {
+		if matchLoan(loan, in, t) {
+			list.Loans = append(list.Loans, loan)
+		}
+	}
+	syncer.LogFunction("ListLoans", t)
+	return list, nil
+}
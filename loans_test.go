@@ -0,0 +1,84 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+
+	pb "github.com/brotherlogic/discogssyncer/server"
+	pbd "github.com/brotherlogic/godiscogs"
+)
+
+func TestLendAndReturn(t *testing.T) {
+	syncer := GetTestSyncer(".testlendreturn", true)
+	syncer.SaveCollection()
+
+	loan, err := syncer.LendRecord(context.Background(), &pb.Loan{ReleaseId: 25, Borrower: "Sam"})
+	if err != nil {
+		t.Fatalf("Error lending record: %v", err)
+	}
+	if loan.InstanceId != 1234 || loan.Lent == 0 {
+		t.Errorf("Loan has not been filled in: %v", loan)
+	}
+
+	if _, err = syncer.LendRecord(context.Background(), &pb.Loan{ReleaseId: 25, Borrower: "Alex"}); errorCode(err) != codes.FailedPrecondition {
+		t.Errorf("Record has been lent out twice: %v", err)
+	}
+
+	recs, _ := syncer.GetReleasesInFolder(context.Background(), &pb.FolderList{Folders: []*pbd.Folder{&pbd.Folder{Id: 23}}})
+	for _, rec := range recs.Records {
+		if (rec.Release.Id == 25) != (rec.Loan != nil) {
+			t.Errorf("Loan has been badly reported: %v", rec)
+		}
+	}
+
+	loan, err = syncer.ReturnRecord(context.Background(), &pb.Loan{ReleaseId: 25})
+	if err != nil || loan.Returned == 0 {
+		t.Fatalf("Record has not been returned: %v (%v)", loan, err)
+	}
+
+	if _, err = syncer.ReturnRecord(context.Background(), &pb.Loan{ReleaseId: 25}); errorCode(err) != codes.NotFound {
+		t.Errorf("Record has been returned twice: %v", err)
+	}
+
+	if _, err = syncer.LendRecord(context.Background(), &pb.Loan{ReleaseId: 25, Borrower: "Alex"}); err != nil {
+		t.Errorf("Returned record could not be lent again: %v", err)
+	}
+
+	history, _ := syncer.ListLoans(context.Background(), &pb.LoanRequest{InstanceId: 1234, IncludeReturned: true})
+	if len(history.Loans) != 2 || history.Loans[0].Borrower != "Sam" {
+		t.Errorf("Bad loan history: %v", history)
+	}
+}
+
+func TestLendBadRecord(t *testing.T) {
+	syncer := GetTestSyncer(".testlendbadrecord", true)
+	syncer.SaveCollection()
+
+	if _, err := syncer.LendRecord(context.Background(), &pb.Loan{ReleaseId: 999, Borrower: "Sam"}); errorCode(err) != codes.NotFound {
+		t.Errorf("Missing record has been lent: %v", err)
+	}
+	if _, err := syncer.LendRecord(context.Background(), &pb.Loan{ReleaseId: 25}); errorCode(err) != codes.InvalidArgument {
+		t.Errorf("Record has been lent to nobody: %v", err)
+	}
+}
+
+func TestListOverdueLoans(t *testing.T) {
+	syncer := GetTestSyncer(".testoverdueloans", true)
+	syncer.SaveCollection()
+	syncer.LendRecord(context.Background(), &pb.Loan{ReleaseId: 25, Borrower: "Sam", Due: time.Now().AddDate(0, 0, -1).Unix()})
+	syncer.LendRecord(context.Background(), &pb.Loan{ReleaseId: 32, Borrower: "Sam", Due: time.Now().AddDate(0, 0, 7).Unix()})
+	syncer.LendRecord(context.Background(), &pb.Loan{ReleaseId: 29, Borrower: "Alex"})
+
+	loans, err := syncer.ListLoans(context.Background(), &pb.LoanRequest{OverdueOnly: true})
+	if err != nil || len(loans.Loans) != 1 || loans.Loans[0].ReleaseId != 25 {
+		t.Errorf("Bad overdue loans: %v (%v)", loans, err)
+	}
+
+	loans, err = syncer.ListLoans(context.Background(), &pb.LoanRequest{Borrower: "Sam"})
+	if err != nil || len(loans.Loans) != 2 {
+		t.Errorf("Bad borrower loans: %v (%v)", loans, err)
+	}
+}
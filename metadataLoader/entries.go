@@ -0,0 +1,124 @@
+package main
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// entry is a single file to import, err is set if we couldn't read it
+type entry struct {
+	name string
+	data []byte
+	err  error
+}
+
+// readEntries pulls every file out of a directory or archive
+func readEntries(path string) ([]entry, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if info.IsDir() {
+		return readDir(path)
+	}
+
+	lower := strings.ToLower(path)
+	switch {
+	case strings.HasSuffix(lower, ".zip"):
+		return readZip(path)
+	case strings.HasSuffix(lower, ".tar.gz") || strings.HasSuffix(lower, ".tgz"):
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			return nil, err
+		}
+		return readTar(gz)
+	case strings.HasSuffix(lower, ".tar"):
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		return readTar(f)
+	}
+
+	// A lone file
+	data, err := ioutil.ReadFile(path)
+	return []entry{entry{name: path, data: data, err: err}}, nil
+}
+
+func hidden(name string) bool {
+	return strings.HasPrefix(filepath.Base(name), ".")
+}
+
+func readDir(dir string) ([]entry, error) {
+	var entries []entry
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			entries = append(entries, entry{name: path, err: err})
+			return nil
+		}
+		if info.IsDir() || hidden(path) {
+			return nil
+		}
+		data, err := ioutil.ReadFile(path)
+		entries = append(entries, entry{name: path, data: data, err: err})
+		return nil
+	})
+	return entries, err
+}
+
+func readZip(path string) ([]entry, error) {
+	r, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	var entries []entry
+	for _, f := range r.File {
+		if f.FileInfo().IsDir() || hidden(f.Name) {
+			continue
+		}
+		e := entry{name: f.Name}
+		rc, err := f.Open()
+		if err == nil {
+			e.data, e.err = ioutil.ReadAll(rc)
+			rc.Close()
+		} else {
+			e.err = err
+		}
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
+
+func readTar(r io.Reader) ([]entry, error) {
+	var entries []entry
+	tr := tar.NewReader(r)
+	for {
+		h, err := tr.Next()
+		if err == io.EOF {
+			return entries, nil
+		}
+		if err != nil {
+			// We can't find the next file in a broken archive, so stop here
+			return entries, err
+		}
+		if h.Typeflag != tar.TypeReg || hidden(h.Name) {
+			continue
+		}
+		data, err := ioutil.ReadAll(tr)
+		entries = append(entries, entry{name: h.Name, data: data, err: err})
+	}
+}
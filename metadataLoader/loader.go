@@ -2,87 +2,151 @@ package main
 
 import (
 	"context"
-	"io/ioutil"
+	"flag"
+	"fmt"
 	"log"
+	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
-	"google.golang.org/grpc"
+	"github.com/brotherlogic/discogssyncer/dial"
+	"github.com/brotherlogic/goserver/utils"
 
+	"github.com/golang/protobuf/jsonpb"
 	"github.com/golang/protobuf/proto"
 
 	pb "github.com/brotherlogic/discogssyncer/server"
-	pbdi "github.com/brotherlogic/discovery/proto"
 	pbd "github.com/brotherlogic/godiscogs"
 )
 
-func findServer(name string) (string, int) {
-	conn, err := grpc.Dial("192.168.86.64:50055", grpc.WithInsecure())
-	if err != nil {
-		log.Fatalf("Cannot reach discover server: %v (trying to discover %v)", err, name)
+// formatFor works out how a file is encoded, going by its extension unless told otherwise
+func formatFor(name, format string) string {
+	if format != "auto" {
+		return format
 	}
-	defer conn.Close()
-
-	registry := pbdi.NewDiscoveryServiceClient(conn)
-	rs, err := registry.ListAllServices(context.Background(), &pbdi.Empty{})
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".json":
+		return "json"
+	case ".txt", ".pbtxt", ".textproto":
+		return "text"
+	}
+	return "binary"
+}
 
-	if err != nil {
-		log.Fatalf("Failure to list: %v", err)
+func parseMetadata(data []byte, format string) (*pb.ReleaseMetadata, error) {
+	metadata := &pb.ReleaseMetadata{}
+	var err error
+	switch format {
+	case "binary":
+		err = proto.Unmarshal(data, metadata)
+	case "text":
+		err = proto.UnmarshalText(string(data), metadata)
+	case "json":
+		err = jsonpb.UnmarshalString(string(data), metadata)
+	default:
+		err = fmt.Errorf("Unknown format %v", format)
 	}
+	return metadata, err
+}
 
-	for _, r := range rs.Services {
-		if r.Name == name {
-			log.Printf("%v -> %v", name, r)
-			return r.Ip, int(r.Port)
+// releaseID takes the id from the file name (<id>.*), falling back to the id in the metadata
+func releaseID(name string, metadata *pb.ReleaseMetadata) (int32, error) {
+	base := filepath.Base(name)
+	id, err := strconv.Atoi(strings.Split(base, ".")[0])
+	if err != nil {
+		if metadata.Id > 0 {
+			return metadata.Id, nil
 		}
+		return 0, fmt.Errorf("No release id in the name or the metadata")
 	}
-
-	log.Printf("No %v running", name)
-
-	return "", -1
+	if metadata.Id > 0 && metadata.Id != int32(id) {
+		return 0, fmt.Errorf("File is named for %v but holds metadata for %v", id, metadata.Id)
+	}
+	return int32(id), nil
 }
 
-func processMetadataFile(id int32, f string, client pb.DiscogsServiceClient) {
-
-	log.Printf("READING %v", f)
+type result struct {
+	name string
+	id   int32
+	err  error
+}
 
-	data, err := ioutil.ReadFile(f)
+func main() {
+	var input = flag.String("input", "data", "Directory or archive (.zip, .tar, .tar.gz) of metadata files")
+	var format = flag.String("format", "auto", "Encoding of the files: auto, binary, text or json")
+	var server = flag.String("server", "", "host:port of the syncer, found through discovery if not set")
+	var discovery = flag.String("discovery", utils.Discover, "host:port of the discovery server")
+	var dryRun = flag.Bool("dry_run", false, "Parse and check the files without updating anything")
+	flag.Parse()
+
+	entries, err := readEntries(*input)
 	if err != nil {
-		log.Fatalf("Unable to read file: %v", err)
+		log.Fatalf("Unable to read %v: %v", *input, err)
 	}
-	metadata := &pb.ReleaseMetadata{}
-	err = proto.Unmarshal(data, metadata)
-	if err != nil {
-		log.Fatalf("Unable to unmarshall data %v", err)
+
+	var client pb.DiscogsServiceClient
+	if !*dryRun {
+		conn, err := dial.Syncer(*server, *discovery)
+		if err != nil {
+			log.Fatalf("Unable to dial server: %v", err)
+		}
+		defer conn.Close()
+		client = pb.NewDiscogsServiceClient(conn)
 	}
 
-	rs, err := client.UpdateMetadata(context.Background(), &pb.MetadataUpdate{Release: &pbd.Release{Id: id}, Update: metadata})
-	if err != nil {
-		log.Printf("Update error: %v", err)
-	} else {
-		log.Printf("Update success: %v", rs)
+	var results []result
+	for _, e := range entries {
+		r := result{name: e.name, err: e.err}
+		if r.err == nil {
+			r.id, r.err = load(e, *format, client)
+		}
+		if r.err != nil {
+			log.Printf("FAILED %v: %v", r.name, r.err)
+		}
+		results = append(results, r)
+	}
+
+	failed := 0
+	for _, r := range results {
+		if r.err != nil {
+			failed++
+		}
+	}
+	verb := "Loaded"
+	if *dryRun {
+		verb = "Checked"
+	}
+	fmt.Printf("%v %v of %v files\n", verb, len(results)-failed, len(results))
+	for _, r := range results {
+		if r.err != nil {
+			fmt.Printf("  %v: %v\n", r.name, r.err)
+		}
+	}
+	if failed > 0 {
+		os.Exit(1)
 	}
 }
 
-func main() {
-	host, port := findServer("discogssyncer")
-	if port <= 0 {
-		log.Fatalf("Unable to find server")
+// load parses an entry and sends it to the server, a nil client makes this a dry run
+func load(e entry, format string, client pb.DiscogsServiceClient) (int32, error) {
+	metadata, err := parseMetadata(e.data, formatFor(e.name, format))
+	if err != nil {
+		return 0, err
 	}
-	conn, err := grpc.Dial(host+":"+strconv.Itoa(port), grpc.WithInsecure())
+	id, err := releaseID(e.name, metadata)
 	if err != nil {
-		log.Fatalf("Unable to dial server: %v", err)
+		return 0, err
 	}
-	defer conn.Close()
 
-	client := pb.NewDiscogsServiceClient(conn)
-	dir, _ := ioutil.ReadDir("data")
-	for _, f := range dir {
-		parts := strings.Split(f.Name(), ".")
-		id, err := strconv.Atoi(parts[0])
-		if err != nil {
-			log.Fatalf("Error proc: %v", err)
-		}
-		processMetadataFile(int32(id), "data/"+f.Name(), client)
+	if client == nil {
+		log.Printf("Would update %v with %v", id, proto.CompactTextString(metadata))
+		return id, nil
 	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	_, err = client.UpdateMetadata(ctx, &pb.MetadataUpdate{Release: &pbd.Release{Id: id}, Update: metadata})
+	return id, err
 }
@@ -0,0 +1,202 @@
+package main
+
+import (
+	"sort"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/brotherlogic/discogssyncer/server"
+	pbd "github.com/brotherlogic/godiscogs"
+)
+
+const (
+	day = 60 * 60 * 24
+
+	// How many plays we hold on to, the oldest go first
+	maxPlays = 20000
+)
+
+type byPlays struct {
+	counts []*pb.PlayCount
+	least  bool
+}
+
+func (s byPlays) Len() int      { return len(s.counts) }
+func (s byPlays) Swap(i, j int) { s.counts[i], s.counts[j] = s.counts[j], s.counts[i] }
+func (s byPlays) Less(i, j int) bool {
+	if s.counts[i].Count != s.counts[j].Count {
+		if s.least {
+			return s.counts[i].Count < s.counts[j].Count
+		}
+		return s.counts[i].Count > s.counts[j].Count
+	}
+	return s.counts[i].LastPlayed > s.counts[j].LastPlayed
+}
+
+type int64s []int64
+
+func (s int64s) Len() int           { return len(s) }
+func (s int64s) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s int64s) Less(i, j int) bool { return s[i] < s[j] }
+
+// playedInstance checks whether a play was of the given copy of a release
+func playedInstance(play *pb.Play, rel *pbd.Release) bool {
+	if play.ReleaseId != rel.Id {
+		return false
+	}
+	return play.InstanceId == 0 || rel.InstanceId == 0 || play.InstanceId == rel.InstanceId
+}
+
+func matchPlay(play *pb.Play, req *pb.PlayRequest) bool {
+	if req.ReleaseId > 0 && play.ReleaseId != req.ReleaseId {
+		return false
+	}
+	if req.InstanceId > 0 && play.InstanceId != req.InstanceId {
+		return false
+	}
+	if req.Since > 0 && play.Date < req.Since {
+		return false
+	}
+	if req.Until > 0 && play.Date > req.Until {
+		return false
+	}
+	return req.Listener == "" || play.Listener == req.Listener
+}
+
+// RecordPlay logs a listen of a record in the collection
+func (syncer *Syncer) RecordPlay(ctx context.Context, in *pb.Play) (*pb.Play, error) {
+	t := time.Now()
+	played := syncer.findInstance(in.ReleaseId, in.InstanceId)
+	if played == nil {
+		return nil, status.Errorf(codes.NotFound, "Unable to find %v (instance %v) in the collection", in.ReleaseId, in.InstanceId)
+	}
+
+	play := &pb.Play{ReleaseId: in.ReleaseId, InstanceId: played.InstanceId, Date: in.Date, Side: in.Side, Track: in.Track, Listener: in.Listener}
+	if play.Date == 0 {
+		play.Date = t.Unix()
+	}
+
+	syncer.collection.Plays = append(syncer.collection.Plays, play)
+	if len(syncer.collection.Plays) > maxPlays {
+		syncer.collection.Plays = syncer.collection.Plays[len(syncer.collection.Plays)-maxPlays:]
+	}
+	syncer.touch(play.ReleaseId)
+	syncer.saveCollection()
+	syncer.LogFunction("RecordPlay", t)
+	return play, nil
+}
+
+// GetPlays returns the listening history
+func (syncer *Syncer) GetPlays(ctx context.Context, in *pb.PlayRequest) (*pb.PlayList, error) {
+	t := time.Now()
+	plays := &pb.PlayList{}
+	for _, play := range syncer.collection.Plays {
+		if matchPlay(play, in) {
+			plays.Plays = append(plays.Plays, play)
+		}
+	}
+	syncer.LogFunction("GetPlays", t)
+	return plays, nil
+}
+
+// GetPlayCounts ranks the collection by how often each record is played
+func (syncer *Syncer) GetPlayCounts(ctx context.Context, in *pb.PlayCountRequest) (*pb.PlayCountList, error) {
+	t := time.Now()
+	col, _ := syncer.GetCollection(ctx, &pb.Empty{})
+
+	counts := &pb.PlayCountList{}
+	for _, rel := range col.Releases {
+		count := &pb.PlayCount{Release: rel}
+		for _, play := range syncer.collection.Plays {
+			if playedInstance(play, rel) {
+				count.Count++
+				if play.Date > count.LastPlayed {
+					count.LastPlayed = play.Date
+				}
+			}
+		}
+		counts.Counts = append(counts.Counts, count)
+	}
+
+	sort.Stable(byPlays{counts: counts.Counts, least: in.LeastFirst})
+	if in.Limit > 0 && len(counts.Counts) > int(in.Limit) {
+		counts.Counts = counts.Counts[:in.Limit]
+	}
+
+	syncer.LogFunction("GetPlayCounts", t)
+	return counts, nil
+}
+
+// GetNeverPlayed lists the records we haven't listened to since we bought them
+func (syncer *Syncer) GetNeverPlayed(ctx context.Context, in *pb.NeverPlayedRequest) (*pb.ReleaseList, error) {
+	t := time.Now()
+	col, _ := syncer.GetCollection(ctx, &pb.Empty{})
+
+	releases := &pb.ReleaseList{}
+	for _, rel := range col.Releases {
+		var added int64
+		if md := syncer.findMetadata(rel.Id); md != nil {
+			added = md.DateAdded
+		}
+		if in.OwnedFor > 0 && (added == 0 || t.Unix()-added < in.OwnedFor) {
+			continue
+		}
+
+		played := false
+		for _, play := range syncer.collection.Plays {
+			if playedInstance(play, rel) && play.Date >= added {
+				played = true
+				break
+			}
+		}
+		if !played {
+			releases.Releases = append(releases.Releases, rel)
+		}
+	}
+
+	syncer.LogFunction("GetNeverPlayed", t)
+	return releases, nil
+}
+
+// GetStreaks works out our runs of consecutive listening days (in UTC)
+func (syncer *Syncer) GetStreaks(ctx context.Context, in *pb.StreakRequest) (*pb.StreakResponse, error) {
+	t := time.Now()
+
+	seen := make(map[int64]bool)
+	var days []int64
+	for _, play := range syncer.collection.Plays {
+		if in.Listener != "" && play.Listener != in.Listener {
+			continue
+		}
+		if d := play.Date / day; !seen[d] {
+			seen[d] = true
+			days = append(days, d)
+		}
+	}
+	sort.Sort(int64s(days))
+
+	resp := &pb.StreakResponse{}
+	run := int32(0)
+	for i, d := range days {
+		if i > 0 && days[i-1] == d-1 {
+			run++
+		} else {
+			run = 1
+		}
+		if run > resp.Longest {
+			resp.Longest = run
+			resp.LongestStart = (d - int64(run) + 1) * day
+			resp.LongestEnd = d * day
+		}
+	}
+
+	if len(days) > 0 && days[len(days)-1] >= t.Unix()/day-1 {
+		resp.Current = run
+	}
+
+	syncer.LogFunction("GetStreaks", t)
+	return resp, nil
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+
+	pb "github.com/brotherlogic/discogssyncer/server"
+	pbd "github.com/brotherlogic/godiscogs"
+)
+
+func TestRecordPlay(t *testing.T) {
+	syncer := GetTestSyncer(".testrecordplay", true)
+	syncer.saveRelease(&pbd.Release{FolderId: 23, Id: 25, InstanceId: 37}, 23)
+
+	play, err := syncer.RecordPlay(context.Background(), &pb.Play{ReleaseId: 25, Side: "B"})
+	if err != nil {
+		t.Fatalf("Error recording play: %v", err)
+	}
+
+	if play.InstanceId != 37 || play.Date == 0 {
+		t.Errorf("Play has not been filled in: %v", play)
+	}
+
+	if syncer.findMetadata(25).LastTouched == 0 {
+		t.Errorf("Playing has not touched the record: %v", syncer.findMetadata(25))
+	}
+
+	plays, err := syncer.GetPlays(context.Background(), &pb.PlayRequest{InstanceId: 37})
+	if err != nil || len(plays.Plays) != 1 || plays.Plays[0].Side != "B" {
+		t.Errorf("Play has not been stored: %v (%v)", plays, err)
+	}
+}
+
+func TestRecordPlayNotInCollection(t *testing.T) {
+	syncer := GetTestSyncer(".testrecordplaymissing", true)
+
+	_, err := syncer.RecordPlay(context.Background(), &pb.Play{ReleaseId: 25})
+	if errorCode(err) != codes.NotFound {
+		t.Errorf("Playing a missing record gave %v", err)
+	}
+}
+
+func TestPlaysAreCapped(t *testing.T) {
+	syncer := GetTestSyncer(".testplayscap", true)
+	syncer.saveRelease(&pbd.Release{FolderId: 23, Id: 25, InstanceId: 37}, 23)
+	for i := 0; i < maxPlays; i++ {
+		syncer.collection.Plays = append(syncer.collection.Plays, &pb.Play{ReleaseId: 25, InstanceId: 37, Date: int64(i + 1)})
+	}
+
+	syncer.RecordPlay(context.Background(), &pb.Play{ReleaseId: 25, Date: 50000})
+	if len(syncer.collection.Plays) != maxPlays || syncer.collection.Plays[0].Date != 2 || syncer.collection.Plays[maxPlays-1].Date != 50000 {
+		t.Errorf("Plays have been badly capped: %v plays from %v", len(syncer.collection.Plays), syncer.collection.Plays[0])
+	}
+}
+
+func TestPlayCounts(t *testing.T) {
+	syncer := GetTestSyncer(".testplaycounts", true)
+	syncer.saveRelease(&pbd.Release{FolderId: 23, Id: 25, InstanceId: 37}, 23)
+	syncer.saveRelease(&pbd.Release{FolderId: 23, Id: 27, InstanceId: 39}, 23)
+	syncer.saveRelease(&pbd.Release{FolderId: 23, Id: 32, InstanceId: 40}, 23)
+	syncer.RecordPlay(context.Background(), &pb.Play{ReleaseId: 25})
+	syncer.RecordPlay(context.Background(), &pb.Play{ReleaseId: 25})
+	syncer.RecordPlay(context.Background(), &pb.Play{ReleaseId: 27})
+
+	counts, err := syncer.GetPlayCounts(context.Background(), &pb.PlayCountRequest{})
+	if err != nil {
+		t.Fatalf("Error getting counts: %v", err)
+	}
+	if len(counts.Counts) != 3 || counts.Counts[0].Release.Id != 25 || counts.Counts[0].Count != 2 {
+		t.Errorf("Most played is wrong: %v", counts)
+	}
+
+	counts, err = syncer.GetPlayCounts(context.Background(), &pb.PlayCountRequest{LeastFirst: true, Limit: 1})
+	if err != nil {
+		t.Fatalf("Error getting counts: %v", err)
+	}
+	if len(counts.Counts) != 1 || counts.Counts[0].Release.Id != 32 {
+		t.Errorf("Least played is wrong: %v", counts)
+	}
+}
+
+func TestNeverPlayed(t *testing.T) {
+	syncer := GetTestSyncer(".testneverplayed", true)
+	syncer.saveRelease(&pbd.Release{FolderId: 23, Id: 25, InstanceId: 37}, 23)
+	syncer.saveRelease(&pbd.Release{FolderId: 23, Id: 27, InstanceId: 39}, 23)
+	syncer.findMetadata(25).DateAdded = time.Now().AddDate(0, -1, 0).Unix()
+	syncer.findMetadata(27).DateAdded = time.Now().AddDate(0, -1, 0).Unix()
+
+	// A listen before we bought the record doesn't count
+	syncer.RecordPlay(context.Background(), &pb.Play{ReleaseId: 25, Date: time.Now().AddDate(-1, 0, 0).Unix()})
+	syncer.RecordPlay(context.Background(), &pb.Play{ReleaseId: 27})
+
+	never, err := syncer.GetNeverPlayed(context.Background(), &pb.NeverPlayedRequest{})
+	if err != nil {
+		t.Fatalf("Error getting unplayed: %v", err)
+	}
+	if len(never.Releases) != 1 || never.Releases[0].Id != 25 {
+		t.Errorf("Unplayed records are wrong: %v", never)
+	}
+
+	never, err = syncer.GetNeverPlayed(context.Background(), &pb.NeverPlayedRequest{OwnedFor: 60 * 60 * 24 * 365})
+	if err != nil || len(never.Releases) != 0 {
+		t.Errorf("Recent purchases have been returned: %v (%v)", never, err)
+	}
+}
+
+func TestStreaks(t *testing.T) {
+	syncer := GetTestSyncer(".teststreaks", true)
+	syncer.saveRelease(&pbd.Release{FolderId: 23, Id: 25, InstanceId: 37}, 23)
+	now := time.Now()
+	for _, d := range []int{-10, -9, -8, -5, -1, 0} {
+		syncer.RecordPlay(context.Background(), &pb.Play{ReleaseId: 25, Date: now.AddDate(0, 0, d).Unix()})
+	}
+	syncer.RecordPlay(context.Background(), &pb.Play{ReleaseId: 25, Date: now.AddDate(0, 0, -4).Unix(), Listener: "guest"})
+
+	streaks, err := syncer.GetStreaks(context.Background(), &pb.StreakRequest{})
+	if err != nil {
+		t.Fatalf("Error getting streaks: %v", err)
+	}
+	if streaks.Longest != 3 || streaks.Current != 2 {
+		t.Errorf("Streaks are wrong: %v", streaks)
+	}
+
+	streaks, err = syncer.GetStreaks(context.Background(), &pb.StreakRequest{Listener: "guest"})
+	if err != nil || streaks.Longest != 1 || streaks.Current != 0 {
+		t.Errorf("Listener streaks are wrong: %v (%v)", streaks, err)
+	}
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/brotherlogic/discogssyncer/server"
+	pbd "github.com/brotherlogic/godiscogs"
+)
+
+const (
+	// New purchases land in the Uncategorized folder on discogs
+	uncategorized = 1
+
+	// How many rule audits we hold on to
+	maxRuleAudit = 500
+)
+
+// matchRule finds the first rule that applies to a release
+func (syncer *Syncer) matchRule(rel *pbd.Release, now time.Time) *pb.FolderRule {
+	md := syncer.findMetadata(rel.Id)
+	for _, rule := range syncer.collection.FolderRules {
+		matched := true
+		for _, c := range rule.Conditions {
+			if !meetsCondition(c, rel, md, now) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return rule
+		}
+	}
+	return nil
+}
+
+// applyRules files away new purchases, recording what we've done
+func (syncer *Syncer) applyRules(releases []*pbd.Release) {
+	t := time.Now()
+	for _, rel := range releases {
+		rule := syncer.matchRule(rel, t)
+		if rule == nil || rel.FolderId == rule.FolderId {
+			continue
+		}
+
+		audit := &pb.RuleAudit{Rule: rule.Name, Release: rel, FromFolderId: rel.FolderId, ToFolderId: rule.FolderId, Date: t.Unix()}
+		if err := syncer.moveRelease(&pb.ReleaseMove{Release: rel, NewFolderId: rule.FolderId}, false, true); err != nil {
+			audit.Error = err.Error()
+		}
+		syncer.collection.RuleAudit = append(syncer.collection.RuleAudit, audit)
+	}
+
+	if len(syncer.collection.RuleAudit) > maxRuleAudit {
+		syncer.collection.RuleAudit = syncer.collection.RuleAudit[len(syncer.collection.RuleAudit)-maxRuleAudit:]
+	}
+}
+
+// AddFolderRule adds a rule to the end of the rule list
+func (syncer *Syncer) AddFolderRule(ctx context.Context, in *pb.FolderRule) (*pb.FolderRuleList, error) {
+	t := time.Now()
+	if in.Name == "" {
+		return nil, status.Error(codes.InvalidArgument, "Rules need a name")
+	}
+	for _, rule := range syncer.collection.FolderRules {
+		if rule.Name == in.Name {
+			return nil, status.Errorf(codes.AlreadyExists, "There is already a rule called %v", in.Name)
+		}
+	}
+	if syncer.findFolder(in.FolderId) == nil {
+		return nil, status.Errorf(codes.NotFound, "Unable to locate folder with id %v", in.FolderId)
+	}
+	for _, c := range in.Conditions {
+		if err := validateCondition(c); err != nil {
+			return nil, err
+		}
+	}
+
+	syncer.collection.FolderRules = append(syncer.collection.FolderRules, in)
+	syncer.saveCollection()
+	syncer.LogFunction("AddFolderRule", t)
+	return &pb.FolderRuleList{Rules: syncer.collection.FolderRules}, nil
+}
+
+// DeleteFolderRule removes a rule by name
+func (syncer *Syncer) DeleteFolderRule(ctx context.Context, in *pb.FolderRule) (*pb.FolderRuleList, error) {
+	t := time.Now()
+	for i, rule := range syncer.collection.FolderRules {
+		if rule.Name == in.Name {
+			syncer.collection.FolderRules = append(syncer.collection.FolderRules[:i], syncer.collection.FolderRules[i+1:]...)
+			syncer.saveCollection()
+			syncer.LogFunction("DeleteFolderRule", t)
+			return &pb.FolderRuleList{Rules: syncer.collection.FolderRules}, nil
+		}
+	}
+	return nil, status.Errorf(codes.NotFound, "Unable to find rule %v", in.Name)
+}
+
+// GetFolderRules lists the rules in the order they're tried
+func (syncer *Syncer) GetFolderRules(ctx context.Context, in *pb.Empty) (*pb.FolderRuleList, error) {
+	return &pb.FolderRuleList{Rules: syncer.collection.FolderRules}, nil
+}
+
+// PreviewFolderRules shows where the rules would file what's currently uncategorized
+func (syncer *Syncer) PreviewFolderRules(ctx context.Context, in *pb.Empty) (*pb.RuleAuditList, error) {
+	t := time.Now()
+	list := &pb.RuleAuditList{}
+	for _, rel := range syncer.getReleases(uncategorized).GetReleases() {
+		if rule := syncer.matchRule(rel, t); rule != nil {
+			list.Audits = append(list.Audits, &pb.RuleAudit{Rule: rule.Name, Release: rel, FromFolderId: rel.FolderId, ToFolderId: rule.FolderId, Date: t.Unix()})
+		}
+	}
+	syncer.LogFunction("PreviewFolderRules", t)
+	return list, nil
+}
+
+// GetRuleAudit lists the moves our rules have made
+func (syncer *Syncer) GetRuleAudit(ctx context.Context, in *pb.Empty) (*pb.RuleAuditList, error) {
+	return &pb.RuleAuditList{Audits: syncer.collection.RuleAudit}, nil
+}
@@ -0,0 +1,176 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+
+	pb "github.com/brotherlogic/discogssyncer/server"
+	pbd "github.com/brotherlogic/godiscogs"
+)
+
+// purchaseRetriever adds a couple of new, uncategorized purchases to the test collection
+type purchaseRetriever struct {
+	testDiscogsRetriever
+}
+
+func (r purchaseRetriever) GetCollection() []pbd.Release {
+	releases := r.testDiscogsRetriever.GetCollection()
+	releases = append(releases, pbd.Release{FolderId: 1, Id: 501, InstanceId: 5011})
+	releases = append(releases, pbd.Release{FolderId: 1, Id: 502, InstanceId: 5021})
+	return releases
+}
+
+func (r purchaseRetriever) GetRelease(id int) (pbd.Release, error) {
+	switch id {
+	case 501:
+		return pbd.Release{Id: 501, Labels: []*pbd.Label{&pbd.Label{Name: "Blue Note"}}, Formats: []*pbd.Format{&pbd.Format{Name: "Vinyl"}}}, nil
+	case 502:
+		return pbd.Release{Id: 502, Formats: []*pbd.Format{&pbd.Format{Name: "CD"}}}, nil
+	}
+	return r.testDiscogsRetriever.GetRelease(id)
+}
+
+func (r purchaseRetriever) GetFolders() []pbd.Folder {
+	return append(r.testDiscogsRetriever.GetFolders(), pbd.Folder{Id: 1, Name: "Uncategorized"})
+}
+
+func addTestRules(t *testing.T, syncer *Syncer) {
+	_, err := syncer.AddFolderRule(context.Background(), &pb.FolderRule{Name: "blue note", FolderId: 23, Conditions: []*pb.Condition{
+		&pb.Condition{Field: pb.SmartField_LABEL, Value: "Blue Note"},
+		&pb.Condition{Field: pb.SmartField_FORMAT, Value: "Vinyl"},
+	}})
+	if err != nil {
+		t.Fatalf("Error adding rule: %v", err)
+	}
+	_, err = syncer.AddFolderRule(context.Background(), &pb.FolderRule{Name: "vinyl", FolderId: 25, Conditions: []*pb.Condition{
+		&pb.Condition{Field: pb.SmartField_FORMAT, Value: "Vinyl"},
+	}})
+	if err != nil {
+		t.Fatalf("Error adding rule: %v", err)
+	}
+}
+
+func TestFolderRulesFilePurchases(t *testing.T) {
+	syncer := GetTestSyncer(".testfolderrulesfile", true)
+	syncer.SaveCollection()
+	addTestRules(t, syncer)
+
+	syncer.retr = purchaseRetriever{}
+	syncer.SaveCollection()
+
+	if len(syncer.getReleases(uncategorized).Releases) != 1 {
+		t.Errorf("Purchases have been badly filed: %v", syncer.getReleases(uncategorized))
+	}
+	moved := false
+	for _, rel := range syncer.getReleases(23).Releases {
+		if rel.Id == 501 && rel.FolderId == 23 && len(rel.Labels) == 1 {
+			moved = true
+		}
+	}
+	if !moved {
+		t.Errorf("Purchase has not been moved: %v", syncer.getReleases(23))
+	}
+
+	audit, _ := syncer.GetRuleAudit(context.Background(), &pb.Empty{})
+	if len(audit.Audits) != 1 || audit.Audits[0].Rule != "blue note" || audit.Audits[0].ToFolderId != 23 {
+		t.Errorf("Move has not been audited: %v", audit)
+	}
+
+	// Syncing again shouldn't touch what we've already seen
+	syncer.SaveCollection()
+	audit, _ = syncer.GetRuleAudit(context.Background(), &pb.Empty{})
+	if len(audit.Audits) != 1 {
+		t.Errorf("Rules have been reapplied: %v", audit)
+	}
+}
+
+func TestFolderRulesThrottle(t *testing.T) {
+	syncer := GetTestSyncer(".testfolderrulesthrottle", true)
+	syncer.SaveCollection()
+	addTestRules(t, syncer)
+	syncer.discogsDelay = time.Millisecond * 50
+	syncer.lastDiscogsCall = time.Now()
+
+	start := time.Now()
+	syncer.retr = purchaseRetriever{}
+	syncer.SaveCollection()
+	if time.Since(start) < time.Millisecond*40 {
+		t.Errorf("Rule moves have not been throttled: %v", time.Since(start))
+	}
+}
+
+func TestFolderRulesSkipFirstSync(t *testing.T) {
+	syncer := GetTestSyncer(".testfolderrulesfirstsync", true)
+	for _, f := range []*pbd.Folder{&pbd.Folder{Id: 23, Name: "Testing"}, &pbd.Folder{Id: 25, Name: "TestingTwo"}} {
+		syncer.collection.Folders = append(syncer.collection.Folders, &pb.CollectionFolder{Folder: f, Releases: &pb.ReleaseList{}})
+	}
+	addTestRules(t, syncer)
+
+	syncer.retr = purchaseRetriever{}
+	syncer.SaveCollection()
+
+	if len(syncer.getReleases(uncategorized).Releases) != 2 || len(syncer.collection.RuleAudit) != 0 {
+		t.Errorf("Records have been filed on the first sync: %v, %v", syncer.getReleases(uncategorized), syncer.collection.RuleAudit)
+	}
+}
+
+func TestPreviewFolderRules(t *testing.T) {
+	syncer := GetTestSyncer(".testpreviewfolderrules", true)
+	syncer.retr = purchaseRetriever{}
+	syncer.SaveCollection()
+	addTestRules(t, syncer)
+
+	preview, err := syncer.PreviewFolderRules(context.Background(), &pb.Empty{})
+	if err != nil {
+		t.Fatalf("Error previewing rules: %v", err)
+	}
+	if len(preview.Audits) != 1 || preview.Audits[0].Release.Id != 501 {
+		t.Errorf("Bad preview: %v", preview)
+	}
+	if len(syncer.getReleases(uncategorized).Releases) != 2 {
+		t.Errorf("Preview has moved releases: %v", syncer.getReleases(uncategorized))
+	}
+}
+
+func TestFolderRuleValidation(t *testing.T) {
+	syncer := GetTestSyncer(".testfolderrulevalidation", true)
+	syncer.SaveCollection()
+	addTestRules(t, syncer)
+
+	var bad = []struct {
+		rule *pb.FolderRule
+		code codes.Code
+	}{
+		{&pb.FolderRule{FolderId: 23}, codes.InvalidArgument},
+		{&pb.FolderRule{Name: "vinyl", FolderId: 23}, codes.AlreadyExists},
+		{&pb.FolderRule{Name: "missing", FolderId: 99}, codes.NotFound},
+		{&pb.FolderRule{Name: "bad", FolderId: 23, Conditions: []*pb.Condition{&pb.Condition{Field: pb.SmartField_YEAR, Value: "recent"}}}, codes.InvalidArgument},
+	}
+	for _, test := range bad {
+		if _, err := syncer.AddFolderRule(context.Background(), test.rule); errorCode(err) != test.code {
+			t.Errorf("Bad rule has been added: %v (%v)", test.rule, err)
+		}
+	}
+
+	rules, err := syncer.DeleteFolderRule(context.Background(), &pb.FolderRule{Name: "blue note"})
+	if err != nil || len(rules.Rules) != 1 {
+		t.Errorf("Rule has not been deleted: %v (%v)", rules, err)
+	}
+	if _, err = syncer.DeleteFolderRule(context.Background(), &pb.FolderRule{Name: "blue note"}); errorCode(err) != codes.NotFound {
+		t.Errorf("Missing rule has been deleted: %v", err)
+	}
+}
+
+func TestSaveCollectionKeepsTags(t *testing.T) {
+	syncer := GetTestSyncer(".testsavecollectionkeepstags", true)
+	syncer.SaveCollection()
+	syncer.AddTags(context.Background(), &pb.TagRequest{Release: &pbd.Release{Id: 25}, Tags: []string{"jazz"}})
+
+	syncer.SaveCollection()
+	if len(syncer.findMetadata(25).Tags) != 1 {
+		t.Errorf("Tags have been duplicated by a sync: %v", syncer.findMetadata(25))
+	}
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"golang.org/x/net/context"
+
+	pb "github.com/brotherlogic/discogssyncer/server"
+	pbd "github.com/brotherlogic/godiscogs"
+)
+
+// Weights used to rank sell candidates, duplicates are the easiest to let go
+const (
+	duplicateScore = 3
+	lowRatingScore = 2
+	untouchedScore = 1
+)
+
+type byScore []*pb.SellCandidate
+
+func (s byScore) Len() int      { return len(s) }
+func (s byScore) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s byScore) Less(i, j int) bool {
+	if s[i].Score != s[j].Score {
+		return s[i].Score > s[j].Score
+	}
+	return s[i].Value > s[j].Value
+}
+
+// bestCopies picks the copy of each duplicated master that we should keep
+func (syncer *Syncer) bestCopies(releases []*pbd.Release) map[int32]*pbd.Release {
+	keep := make(map[int32]*pbd.Release)
+	for _, rel := range releases {
+		md := syncer.findMetadata(rel.Id)
+		if rel.MasterId == 0 || md == nil || !md.Others {
+			continue
+		}
+		if best, ok := keep[rel.MasterId]; !ok || rel.Rating > best.Rating {
+			keep[rel.MasterId] = rel
+		}
+	}
+	return keep
+}
+
+// SellCandidates ranks the records in the collection that we could part with
+func (syncer *Syncer) SellCandidates(ctx context.Context, req *pb.SellCandidatesRequest) (*pb.SellCandidateList, error) {
+	t := time.Now()
+	col, _ := syncer.GetCollection(ctx, &pb.Empty{})
+
+	keep := make(map[int32]*pbd.Release)
+	if req.IncludeDuplicates {
+		keep = syncer.bestCopies(col.Releases)
+	}
+
+	list := &pb.SellCandidateList{}
+	for _, rel := range col.Releases {
+		md := syncer.findMetadata(rel.Id)
+		cand := &pb.SellCandidate{Release: rel, Metadata: md}
+
+		if best, ok := keep[rel.MasterId]; ok && best != rel {
+			cand.Score += duplicateScore
+			cand.Reasons = append(cand.Reasons, fmt.Sprintf("We also own %v of the same master", best.Id))
+		}
+
+		if req.MaxRating > 0 && rel.Rating > 0 && rel.Rating <= req.MaxRating {
+			cand.Score += lowRatingScore
+			cand.Reasons = append(cand.Reasons, fmt.Sprintf("Rated %v", rel.Rating))
+		}
+
+		if req.UntouchedFor > 0 && md != nil {
+			last := md.LastTouched
+			if last == 0 {
+				last = md.DateAdded
+			}
+			if last > 0 && t.Unix()-last >= req.UntouchedFor {
+				cand.Score += untouchedScore
+				cand.Reasons = append(cand.Reasons, fmt.Sprintf("Untouched since %v", time.Unix(last, 0).Format("2006-01-02")))
+			}
+		}
+
+		if cand.Score == 0 {
+			continue
+		}
+
+		cand.Value = syncer.retr.GetSalePrice(int(rel.Id))
+		if cand.Value < req.MinValue {
+			continue
+		}
+		list.Candidates = append(list.Candidates, cand)
+	}
+
+	sort.Stable(byScore(list.Candidates))
+	if req.Limit > 0 && len(list.Candidates) > int(req.Limit) {
+		list.Candidates = list.Candidates[:req.Limit]
+	}
+
+	syncer.LogFunction("SellCandidates", t)
+	return list, nil
+}
+
+// BulkSell lists a set of releases for sale, reporting on each one
+func (syncer *Syncer) BulkSell(ctx context.Context, req *pb.BulkSellRequest) (*pb.BulkSellResponse, error) {
+	t := time.Now()
+	col, _ := syncer.GetCollection(ctx, &pb.Empty{})
+	owned := make(map[int32]bool)
+	for _, rel := range col.Releases {
+		owned[rel.Id] = true
+	}
+
+	resp := &pb.BulkSellResponse{}
+	listed := make(map[int32]bool)
+	for _, rel := range req.Releases {
+		res := &pb.SellResult{Release: rel}
+		switch {
+		case !owned[rel.Id]:
+			res.Error = fmt.Sprintf("%v is not in the collection", rel.Id)
+		case listed[rel.Id]:
+			res.Error = fmt.Sprintf("%v has already been listed", rel.Id)
+		default:
+			res.Price = syncer.sellRelease(rel.Id)
+			res.Success = true
+			listed[rel.Id] = true
+		}
+		resp.Results = append(resp.Results, res)
+	}
+
+	syncer.LogFunction("BulkSell", t)
+	return resp, nil
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+
+	pb "github.com/brotherlogic/discogssyncer/server"
+	pbd "github.com/brotherlogic/godiscogs"
+)
+
+func TestSellCandidatesDuplicates(t *testing.T) {
+	syncer := GetTestSyncer(".testSellCandidatesDuplicates", true)
+	syncer.SaveCollection()
+
+	list, err := syncer.SellCandidates(context.Background(), &pb.SellCandidatesRequest{IncludeDuplicates: true})
+	if err != nil {
+		t.Fatalf("Error getting candidates: %v", err)
+	}
+
+	// 25 and 29 share a master so only one of them should be offered up
+	if len(list.Candidates) != 1 || list.Candidates[0].Release.MasterId != 234 {
+		t.Errorf("Bad duplicate candidates: %v", list)
+	}
+}
+
+func TestSellCandidatesLowRating(t *testing.T) {
+	syncer := GetTestSyncer(".testSellCandidatesLowRating", true)
+	syncer.saveRelease(&pbd.Release{FolderId: 23, Id: 25, InstanceId: 37, Rating: 1}, 23)
+	syncer.saveRelease(&pbd.Release{FolderId: 23, Id: 27, InstanceId: 39, Rating: 5}, 23)
+	syncer.saveRelease(&pbd.Release{FolderId: 23, Id: 32, InstanceId: 40}, 23)
+
+	list, err := syncer.SellCandidates(context.Background(), &pb.SellCandidatesRequest{MaxRating: 2})
+	if err != nil {
+		t.Fatalf("Error getting candidates: %v", err)
+	}
+
+	if len(list.Candidates) != 1 || list.Candidates[0].Release.Id != 25 || len(list.Candidates[0].Reasons) != 1 {
+		t.Errorf("Bad low rated candidates: %v", list)
+	}
+}
+
+func TestSellCandidatesUntouched(t *testing.T) {
+	syncer := GetTestSyncer(".testSellCandidatesUntouched", true)
+	syncer.saveRelease(&pbd.Release{FolderId: 23, Id: 25, InstanceId: 37}, 23)
+	syncer.saveRelease(&pbd.Release{FolderId: 23, Id: 27, InstanceId: 39}, 23)
+	syncer.findMetadata(25).DateAdded = time.Now().AddDate(-2, 0, 0).Unix()
+	syncer.findMetadata(27).DateAdded = time.Now().AddDate(-2, 0, 0).Unix()
+
+	// Editing the record should count as touching it
+	syncer.UpdateMetadata(context.Background(), &pb.MetadataUpdate{Release: &pbd.Release{Id: 25, FolderId: 23}, Update: &pb.ReleaseMetadata{Cost: 200}})
+
+	list, err := syncer.SellCandidates(context.Background(), &pb.SellCandidatesRequest{UntouchedFor: 60 * 60 * 24 * 365})
+	if err != nil {
+		t.Fatalf("Error getting candidates: %v", err)
+	}
+
+	if len(list.Candidates) != 1 || list.Candidates[0].Release.Id != 27 {
+		t.Errorf("Bad untouched candidates: %v", list)
+	}
+}
+
+func TestSellCandidatesValueAndLimit(t *testing.T) {
+	syncer := GetTestSyncer(".testSellCandidatesValue", true)
+	syncer.saveRelease(&pbd.Release{FolderId: 23, Id: 25, InstanceId: 37, Rating: 1}, 23)
+	syncer.saveRelease(&pbd.Release{FolderId: 23, Id: 27, InstanceId: 39, Rating: 2}, 23)
+
+	list, err := syncer.SellCandidates(context.Background(), &pb.SellCandidatesRequest{MaxRating: 2, MinValue: 20})
+	if err != nil {
+		t.Fatalf("Error getting candidates: %v", err)
+	}
+	if len(list.Candidates) != 0 {
+		t.Errorf("Cheap records have been suggested: %v", list)
+	}
+
+	list, err = syncer.SellCandidates(context.Background(), &pb.SellCandidatesRequest{MaxRating: 2, Limit: 1})
+	if err != nil {
+		t.Fatalf("Error getting candidates: %v", err)
+	}
+	if len(list.Candidates) != 1 || list.Candidates[0].Value != 12.35 {
+		t.Errorf("Limit has not been applied: %v", list)
+	}
+}
+
+func TestBulkSell(t *testing.T) {
+	syncer := GetTestSyncer(".testBulkSell", true)
+	syncer.SaveCollection()
+
+	resp, err := syncer.BulkSell(context.Background(), &pb.BulkSellRequest{Releases: []*pbd.Release{&pbd.Release{Id: 25}, &pbd.Release{Id: 1234}, &pbd.Release{Id: 25}}})
+	if err != nil {
+		t.Fatalf("Error in bulk sell: %v", err)
+	}
+
+	if len(resp.Results) != 3 {
+		t.Fatalf("Wrong number of results: %v", resp)
+	}
+	if !resp.Results[0].Success || resp.Results[0].Price != 12.35 {
+		t.Errorf("Sale has failed: %v", resp.Results[0])
+	}
+	if resp.Results[1].Success || resp.Results[2].Success {
+		t.Errorf("Bad sales have gone through: %v", resp)
+	}
+}
@@ -12,18 +12,77 @@ It has these top-level messages:
 	RecordCollection
 	CollectionFolder
 	ReleaseMetadata
+	ShelfPosition
+	OrderRequest
+	ShelfEntry
+	ShelfList
+	InsertionRequest
+	InsertionResponse
+	Tag
+	TagRequest
+	TagQuery
+	TagRename
+	TagCount
+	TagCountList
+	FieldDefinition
+	FieldSchema
+	CustomField
 	Record
 	Empty
 	FolderList
 	ReleaseList
 	RecordList
 	ReleaseMove
+	FolderDelete
 	MetadataUpdate
+	BulkMoveRequest
+	BulkMetadataRequest
+	OperationResult
+	BulkResponse
 	Want
+	WantlistRequest
+	CollapseRequest
 	Wantlist
 	SpendRequest
 	SpendResponse
 	SearchRequest
+	SyncResult
+	SellCandidatesRequest
+	SellCandidate
+	SellCandidateList
+	BulkSellRequest
+	SellResult
+	BulkSellResponse
+	Play
+	PlayList
+	PlayRequest
+	PlayCountRequest
+	PlayCount
+	PlayCountList
+	NeverPlayedRequest
+	StreakRequest
+	StreakResponse
+	SuggestionRequest
+	Suggestion
+	Condition
+	SmartFolder
+	SmartFolderList
+	FolderRule
+	FolderRuleList
+	RuleAudit
+	RuleAuditList
+	Loan
+	LoanRequest
+	LoanList
+	ExportRequest
+	ExportRow
+	DiscogsImportRequest
+	ImportChange
+	ImportReport
+	BackupRequest
+	RestoreRequest
+	RestoreChange
+	RestoreReport
 */
 package discogsserver
 
@@ -48,6 +107,259 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
+type ShelfOrder int32
+
+const (
+	ShelfOrder_BY_ARTIST ShelfOrder = 0
+	// Label, then catalogue number
+	ShelfOrder_BY_LABEL      ShelfOrder = 1
+	ShelfOrder_BY_DATE_ADDED ShelfOrder = 2
+	// The positions we've stored, anything unplaced goes at the end
+	ShelfOrder_CUSTOM ShelfOrder = 3
+)
+
+var ShelfOrder_name = map[int32]string{
+	0: "BY_ARTIST",
+	1: "BY_LABEL",
+	2: "BY_DATE_ADDED",
+	3: "CUSTOM",
+}
+var ShelfOrder_value = map[string]int32{
+	"BY_ARTIST":     0,
+	"BY_LABEL":      1,
+	"BY_DATE_ADDED": 2,
+	"CUSTOM":        3,
+}
+
+func (x ShelfOrder) String() string {
+	return proto.EnumName(ShelfOrder_name, int32(x))
+}
+func (ShelfOrder) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
+
+type FieldType int32
+
+const (
+	FieldType_STRING FieldType = 0
+	FieldType_INT    FieldType = 1
+	FieldType_DATE   FieldType = 2
+	FieldType_ENUM   FieldType = 3
+	FieldType_BOOL   FieldType = 4
+)
+
+var FieldType_name = map[int32]string{
+	0: "STRING",
+	1: "INT",
+	2: "DATE",
+	3: "ENUM",
+	4: "BOOL",
+}
+var FieldType_value = map[string]int32{
+	"STRING": 0,
+	"INT":    1,
+	"DATE":   2,
+	"ENUM":   3,
+	"BOOL":   4,
+}
+
+func (x FieldType) String() string {
+	return proto.EnumName(FieldType_name, int32(x))
+}
+func (FieldType) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+
+type WantSort int32
+
+const (
+	WantSort_UNSORTED      WantSort = 0
+	WantSort_BY_PRIORITY   WantSort = 1
+	WantSort_BY_DATE_ADDED WantSort = 2
+	WantSort_BY_MAX_PRICE  WantSort = 3
+)
+
+var WantSort_name = map[int32]string{
+	0: "UNSORTED",
+	1: "BY_PRIORITY",
+	2: "BY_DATE_ADDED",
+	3: "BY_MAX_PRICE",
+}
+var WantSort_value = map[string]int32{
+	"UNSORTED":      0,
+	"BY_PRIORITY":   1,
+	"BY_DATE_ADDED": 2,
+	"BY_MAX_PRICE":  3,
+}
+
+func (x WantSort) String() string {
+	return proto.EnumName(WantSort_name, int32(x))
+}
+func (WantSort) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+
+type SuggestionStrategy int32
+
+const (
+	SuggestionStrategy_LEAST_RECENTLY_SUGGESTED SuggestionStrategy = 0
+	SuggestionStrategy_WEIGHTED_BY_RATING       SuggestionStrategy = 1
+	SuggestionStrategy_NEWEST_FIRST             SuggestionStrategy = 2
+	SuggestionStrategy_RANDOM                   SuggestionStrategy = 3
+)
+
+var SuggestionStrategy_name = map[int32]string{
+	0: "LEAST_RECENTLY_SUGGESTED",
+	1: "WEIGHTED_BY_RATING",
+	2: "NEWEST_FIRST",
+	3: "RANDOM",
+}
+var SuggestionStrategy_value = map[string]int32{
+	"LEAST_RECENTLY_SUGGESTED": 0,
+	"WEIGHTED_BY_RATING":       1,
+	"NEWEST_FIRST":             2,
+	"RANDOM":                   3,
+}
+
+func (x SuggestionStrategy) String() string {
+	return proto.EnumName(SuggestionStrategy_name, int32(x))
+}
+func (SuggestionStrategy) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+
+type SmartField int32
+
+const (
+	SmartField_RATING SmartField = 0
+	// Days since the release was added
+	SmartField_AGE_DAYS SmartField = 1
+	SmartField_LABEL    SmartField = 2
+	SmartField_ARTIST   SmartField = 3
+	SmartField_TITLE    SmartField = 4
+	SmartField_GENRE    SmartField = 5
+	SmartField_STYLE    SmartField = 6
+	SmartField_FORMAT   SmartField = 7
+	SmartField_TAG      SmartField = 8
+	SmartField_FOLDER   SmartField = 9
+	SmartField_COST     SmartField = 10
+	SmartField_YEAR     SmartField = 11
+)
+
+var SmartField_name = map[int32]string{
+	0:  "RATING",
+	1:  "AGE_DAYS",
+	2:  "LABEL",
+	3:  "ARTIST",
+	4:  "TITLE",
+	5:  "GENRE",
+	6:  "STYLE",
+	7:  "FORMAT",
+	8:  "TAG",
+	9:  "FOLDER",
+	10: "COST",
+	11: "YEAR",
+}
+var SmartField_value = map[string]int32{
+	"RATING":   0,
+	"AGE_DAYS": 1,
+	"LABEL":    2,
+	"ARTIST":   3,
+	"TITLE":    4,
+	"GENRE":    5,
+	"STYLE":    6,
+	"FORMAT":   7,
+	"TAG":      8,
+	"FOLDER":   9,
+	"COST":     10,
+	"YEAR":     11,
+}
+
+func (x SmartField) String() string {
+	return proto.EnumName(SmartField_name, int32(x))
+}
+func (SmartField) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+
+type Comparison int32
+
+const (
+	Comparison_EQUALS     Comparison = 0
+	Comparison_NOT_EQUALS Comparison = 1
+	// Ordered comparisons only apply to numeric fields
+	Comparison_GREATER  Comparison = 2
+	Comparison_AT_LEAST Comparison = 3
+	Comparison_LESS     Comparison = 4
+	Comparison_AT_MOST  Comparison = 5
+	// Substring match, only applies to text fields
+	Comparison_CONTAINS Comparison = 6
+)
+
+var Comparison_name = map[int32]string{
+	0: "EQUALS",
+	1: "NOT_EQUALS",
+	2: "GREATER",
+	3: "AT_LEAST",
+	4: "LESS",
+	5: "AT_MOST",
+	6: "CONTAINS",
+}
+var Comparison_value = map[string]int32{
+	"EQUALS":     0,
+	"NOT_EQUALS": 1,
+	"GREATER":    2,
+	"AT_LEAST":   3,
+	"LESS":       4,
+	"AT_MOST":    5,
+	"CONTAINS":   6,
+}
+
+func (x Comparison) String() string {
+	return proto.EnumName(Comparison_name, int32(x))
+}
+func (Comparison) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+
+type BackupFormat int32
+
+const (
+	// The whole collection as one JSON document
+	BackupFormat_JSON BackupFormat = 0
+	// One JSON collection per line, each holding a single item
+	BackupFormat_NDJSON BackupFormat = 1
+)
+
+var BackupFormat_name = map[int32]string{
+	0: "JSON",
+	1: "NDJSON",
+}
+var BackupFormat_value = map[string]int32{
+	"JSON":   0,
+	"NDJSON": 1,
+}
+
+func (x BackupFormat) String() string {
+	return proto.EnumName(BackupFormat_name, int32(x))
+}
+func (BackupFormat) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+
+type ConflictPolicy int32
+
+const (
+	// Keep what we have
+	ConflictPolicy_SKIP ConflictPolicy = 0
+	// Replace what we have with the backup
+	ConflictPolicy_OVERWRITE ConflictPolicy = 1
+	// Keep what we have but fill in anything it's missing from the backup
+	ConflictPolicy_MERGE ConflictPolicy = 2
+)
+
+var ConflictPolicy_name = map[int32]string{
+	0: "SKIP",
+	1: "OVERWRITE",
+	2: "MERGE",
+}
+var ConflictPolicy_value = map[string]int32{
+	"SKIP":      0,
+	"OVERWRITE": 1,
+	"MERGE":     2,
+}
+
+func (x ConflictPolicy) String() string {
+	return proto.EnumName(ConflictPolicy_name, int32(x))
+}
+func (ConflictPolicy) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+
 type Token struct {
 	Token string `protobuf:"bytes,1,opt,name=token" json:"token,omitempty"`
 }
@@ -68,6 +380,22 @@ type RecordCollection struct {
 	Folders  []*CollectionFolder `protobuf:"bytes,1,rep,name=folders" json:"folders,omitempty"`
 	Metadata []*ReleaseMetadata  `protobuf:"bytes,2,rep,name=metadata" json:"metadata,omitempty"`
 	Wantlist *Wantlist           `protobuf:"bytes,3,opt,name=wantlist" json:"wantlist,omitempty"`
+	// Releases we don't own but want
+	Cache *ReleaseList `protobuf:"bytes,4,opt,name=cache" json:"cache,omitempty"`
+	// Every listen we've logged
+	Plays []*Play `protobuf:"bytes,5,rep,name=plays" json:"plays,omitempty"`
+	// Everything we've suggested for listening
+	Suggestions []*Suggestion `protobuf:"bytes,6,rep,name=suggestions" json:"suggestions,omitempty"`
+	// The custom metadata fields we track
+	Schema *FieldSchema `protobuf:"bytes,7,opt,name=schema" json:"schema,omitempty"`
+	// Virtual folders defined by saved queries
+	SmartFolders []*SmartFolder `protobuf:"bytes,8,rep,name=smart_folders,json=smartFolders" json:"smart_folders,omitempty"`
+	// Rules for filing new purchases, in the order they're tried
+	FolderRules []*FolderRule `protobuf:"bytes,9,rep,name=folder_rules,json=folderRules" json:"folder_rules,omitempty"`
+	// The moves our folder rules have made, oldest first
+	RuleAudit []*RuleAudit `protobuf:"bytes,10,rep,name=rule_audit,json=ruleAudit" json:"rule_audit,omitempty"`
+	// Every loan we've made, returned or not
+	Loans []*Loan `protobuf:"bytes,11,rep,name=loans" json:"loans,omitempty"`
 }
 
 func (m *RecordCollection) Reset()                    { *m = RecordCollection{} }
@@ -96,6 +424,62 @@ func (m *RecordCollection) GetWantlist() *Wantlist {
 	return nil
 }
 
+func (m *RecordCollection) GetCache() *ReleaseList {
+	if m != nil {
+		return m.Cache
+	}
+	return nil
+}
+
+func (m *RecordCollection) GetPlays() []*Play {
+	if m != nil {
+		return m.Plays
+	}
+	return nil
+}
+
+func (m *RecordCollection) GetSuggestions() []*Suggestion {
+	if m != nil {
+		return m.Suggestions
+	}
+	return nil
+}
+
+func (m *RecordCollection) GetSchema() *FieldSchema {
+	if m != nil {
+		return m.Schema
+	}
+	return nil
+}
+
+func (m *RecordCollection) GetSmartFolders() []*SmartFolder {
+	if m != nil {
+		return m.SmartFolders
+	}
+	return nil
+}
+
+func (m *RecordCollection) GetFolderRules() []*FolderRule {
+	if m != nil {
+		return m.FolderRules
+	}
+	return nil
+}
+
+func (m *RecordCollection) GetRuleAudit() []*RuleAudit {
+	if m != nil {
+		return m.RuleAudit
+	}
+	return nil
+}
+
+func (m *RecordCollection) GetLoans() []*Loan {
+	if m != nil {
+		return m.Loans
+	}
+	return nil
+}
+
 type CollectionFolder struct {
 	Folder   *godiscogs.Folder `protobuf:"bytes,1,opt,name=folder" json:"folder,omitempty"`
 	Releases *ReleaseList      `protobuf:"bytes,2,opt,name=releases" json:"releases,omitempty"`
@@ -135,6 +519,19 @@ type ReleaseMetadata struct {
 	Id int32 `protobuf:"varint,6,opt,name=id" json:"id,omitempty"`
 	// The data we last updated this release
 	LastCache int64 `protobuf:"varint,7,opt,name=last_cache,json=lastCache" json:"last_cache,omitempty"`
+	// The date we last moved, rated, edited or played this release
+	LastTouched int64 `protobuf:"varint,8,opt,name=last_touched,json=lastTouched" json:"last_touched,omitempty"`
+	// The date this release came off the wantlist
+	WantFulfilled int64 `protobuf:"varint,9,opt,name=want_fulfilled,json=wantFulfilled" json:"want_fulfilled,omitempty"`
+	// What we paid against the want in pence, zero until we know the cost
+	WantPrice int32 `protobuf:"varint,10,opt,name=want_price,json=wantPrice" json:"want_price,omitempty"`
+	// Values for the fields in the custom schema
+	Custom []*CustomField `protobuf:"bytes,11,rep,name=custom" json:"custom,omitempty"`
+	Tags   []*Tag         `protobuf:"bytes,12,rep,name=tags" json:"tags,omitempty"`
+	// Where each copy sits on the shelves
+	ShelfPositions []*ShelfPosition `protobuf:"bytes,13,rep,name=shelf_positions,json=shelfPositions" json:"shelf_positions,omitempty"`
+	// Free text notes on the release
+	Notes string `protobuf:"bytes,14,opt,name=notes" json:"notes,omitempty"`
 }
 
 func (m *ReleaseMetadata) Reset()                    { *m = ReleaseMetadata{} }
@@ -191,858 +588,4105 @@ func (m *ReleaseMetadata) GetLastCache() int64 {
 	return 0
 }
 
-type Record struct {
-	Release  *godiscogs.Release `protobuf:"bytes,1,opt,name=release" json:"release,omitempty"`
-	Metadata *ReleaseMetadata   `protobuf:"bytes,2,opt,name=metadata" json:"metadata,omitempty"`
+func (m *ReleaseMetadata) GetLastTouched() int64 {
+	if m != nil {
+		return m.LastTouched
+	}
+	return 0
 }
 
-func (m *Record) Reset()                    { *m = Record{} }
-func (m *Record) String() string            { return proto.CompactTextString(m) }
-func (*Record) ProtoMessage()               {}
-func (*Record) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+func (m *ReleaseMetadata) GetWantFulfilled() int64 {
+	if m != nil {
+		return m.WantFulfilled
+	}
+	return 0
+}
 
-func (m *Record) GetRelease() *godiscogs.Release {
+func (m *ReleaseMetadata) GetWantPrice() int32 {
 	if m != nil {
-		return m.Release
+		return m.WantPrice
+	}
+	return 0
+}
+
+func (m *ReleaseMetadata) GetCustom() []*CustomField {
+	if m != nil {
+		return m.Custom
 	}
 	return nil
 }
 
-func (m *Record) GetMetadata() *ReleaseMetadata {
+func (m *ReleaseMetadata) GetTags() []*Tag {
 	if m != nil {
-		return m.Metadata
+		return m.Tags
 	}
 	return nil
 }
 
-type Empty struct {
+func (m *ReleaseMetadata) GetShelfPositions() []*ShelfPosition {
+	if m != nil {
+		return m.ShelfPositions
+	}
+	return nil
 }
 
-func (m *Empty) Reset()                    { *m = Empty{} }
-func (m *Empty) String() string            { return proto.CompactTextString(m) }
-func (*Empty) ProtoMessage()               {}
-func (*Empty) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+func (m *ReleaseMetadata) GetNotes() string {
+	if m != nil {
+		return m.Notes
+	}
+	return ""
+}
 
-type FolderList struct {
-	Folders []*godiscogs.Folder `protobuf:"bytes,1,rep,name=folders" json:"folders,omitempty"`
+type ShelfPosition struct {
+	ReleaseId  int32  `protobuf:"varint,1,opt,name=release_id,json=releaseId" json:"release_id,omitempty"`
+	InstanceId int32  `protobuf:"varint,2,opt,name=instance_id,json=instanceId" json:"instance_id,omitempty"`
+	Shelf      string `protobuf:"bytes,3,opt,name=shelf" json:"shelf,omitempty"`
+	// Counting from 1 at the start of the shelf
+	Position int32 `protobuf:"varint,4,opt,name=position" json:"position,omitempty"`
 }
 
-func (m *FolderList) Reset()                    { *m = FolderList{} }
-func (m *FolderList) String() string            { return proto.CompactTextString(m) }
-func (*FolderList) ProtoMessage()               {}
-func (*FolderList) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+func (m *ShelfPosition) Reset()                    { *m = ShelfPosition{} }
+func (m *ShelfPosition) String() string            { return proto.CompactTextString(m) }
+func (*ShelfPosition) ProtoMessage()               {}
+func (*ShelfPosition) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
 
-func (m *FolderList) GetFolders() []*godiscogs.Folder {
+func (m *ShelfPosition) GetReleaseId() int32 {
 	if m != nil {
-		return m.Folders
+		return m.ReleaseId
 	}
-	return nil
+	return 0
 }
 
-type ReleaseList struct {
-	Releases []*godiscogs.Release `protobuf:"bytes,1,rep,name=releases" json:"releases,omitempty"`
+func (m *ShelfPosition) GetInstanceId() int32 {
+	if m != nil {
+		return m.InstanceId
+	}
+	return 0
 }
 
-func (m *ReleaseList) Reset()                    { *m = ReleaseList{} }
-func (m *ReleaseList) String() string            { return proto.CompactTextString(m) }
-func (*ReleaseList) ProtoMessage()               {}
-func (*ReleaseList) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+func (m *ShelfPosition) GetShelf() string {
+	if m != nil {
+		return m.Shelf
+	}
+	return ""
+}
 
-func (m *ReleaseList) GetReleases() []*godiscogs.Release {
+func (m *ShelfPosition) GetPosition() int32 {
 	if m != nil {
-		return m.Releases
+		return m.Position
 	}
-	return nil
+	return 0
 }
 
-type RecordList struct {
-	Records []*Record `protobuf:"bytes,1,rep,name=records" json:"records,omitempty"`
+type OrderRequest struct {
+	FolderId int32      `protobuf:"varint,1,opt,name=folder_id,json=folderId" json:"folder_id,omitempty"`
+	Order    ShelfOrder `protobuf:"varint,2,opt,name=order,enum=discogsserver.ShelfOrder" json:"order,omitempty"`
+	// Store the computed order, using the folder name when shelf is unset
+	Apply bool   `protobuf:"varint,3,opt,name=apply" json:"apply,omitempty"`
+	Shelf string `protobuf:"bytes,4,opt,name=shelf" json:"shelf,omitempty"`
 }
 
-func (m *RecordList) Reset()                    { *m = RecordList{} }
-func (m *RecordList) String() string            { return proto.CompactTextString(m) }
-func (*RecordList) ProtoMessage()               {}
-func (*RecordList) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+func (m *OrderRequest) Reset()                    { *m = OrderRequest{} }
+func (m *OrderRequest) String() string            { return proto.CompactTextString(m) }
+func (*OrderRequest) ProtoMessage()               {}
+func (*OrderRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
 
-func (m *RecordList) GetRecords() []*Record {
+func (m *OrderRequest) GetFolderId() int32 {
 	if m != nil {
-		return m.Records
+		return m.FolderId
 	}
-	return nil
+	return 0
 }
 
-type ReleaseMove struct {
-	Release     *godiscogs.Release `protobuf:"bytes,1,opt,name=release" json:"release,omitempty"`
-	NewFolderId int32              `protobuf:"varint,2,opt,name=new_folder_id,json=newFolderId" json:"new_folder_id,omitempty"`
+func (m *OrderRequest) GetOrder() ShelfOrder {
+	if m != nil {
+		return m.Order
+	}
+	return ShelfOrder_BY_ARTIST
 }
 
-func (m *ReleaseMove) Reset()                    { *m = ReleaseMove{} }
-func (m *ReleaseMove) String() string            { return proto.CompactTextString(m) }
-func (*ReleaseMove) ProtoMessage()               {}
-func (*ReleaseMove) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
-
-func (m *ReleaseMove) GetRelease() *godiscogs.Release {
+func (m *OrderRequest) GetApply() bool {
 	if m != nil {
-		return m.Release
+		return m.Apply
 	}
-	return nil
+	return false
 }
 
-func (m *ReleaseMove) GetNewFolderId() int32 {
+func (m *OrderRequest) GetShelf() string {
 	if m != nil {
-		return m.NewFolderId
+		return m.Shelf
 	}
-	return 0
+	return ""
 }
 
-type MetadataUpdate struct {
-	Release *godiscogs.Release `protobuf:"bytes,1,opt,name=release" json:"release,omitempty"`
-	Update  *ReleaseMetadata   `protobuf:"bytes,2,opt,name=update" json:"update,omitempty"`
+type ShelfEntry struct {
+	Release  *godiscogs.Release `protobuf:"bytes,1,opt,name=release" json:"release,omitempty"`
+	Position *ShelfPosition     `protobuf:"bytes,2,opt,name=position" json:"position,omitempty"`
 }
 
-func (m *MetadataUpdate) Reset()                    { *m = MetadataUpdate{} }
-func (m *MetadataUpdate) String() string            { return proto.CompactTextString(m) }
-func (*MetadataUpdate) ProtoMessage()               {}
-func (*MetadataUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+func (m *ShelfEntry) Reset()                    { *m = ShelfEntry{} }
+func (m *ShelfEntry) String() string            { return proto.CompactTextString(m) }
+func (*ShelfEntry) ProtoMessage()               {}
+func (*ShelfEntry) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
 
-func (m *MetadataUpdate) GetRelease() *godiscogs.Release {
+func (m *ShelfEntry) GetRelease() *godiscogs.Release {
 	if m != nil {
 		return m.Release
 	}
 	return nil
 }
 
-func (m *MetadataUpdate) GetUpdate() *ReleaseMetadata {
+func (m *ShelfEntry) GetPosition() *ShelfPosition {
 	if m != nil {
-		return m.Update
+		return m.Position
 	}
 	return nil
 }
 
-type Want struct {
-	ReleaseId int32 `protobuf:"varint,1,opt,name=release_id,json=releaseId" json:"release_id,omitempty"`
-	Valued    bool  `protobuf:"varint,2,opt,name=valued" json:"valued,omitempty"`
-	Wanted    bool  `protobuf:"varint,3,opt,name=wanted" json:"wanted,omitempty"`
+type ShelfList struct {
+	Entries []*ShelfEntry `protobuf:"bytes,1,rep,name=entries" json:"entries,omitempty"`
 }
 
-func (m *Want) Reset()                    { *m = Want{} }
-func (m *Want) String() string            { return proto.CompactTextString(m) }
-func (*Want) ProtoMessage()               {}
-func (*Want) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+func (m *ShelfList) Reset()                    { *m = ShelfList{} }
+func (m *ShelfList) String() string            { return proto.CompactTextString(m) }
+func (*ShelfList) ProtoMessage()               {}
+func (*ShelfList) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
 
-func (m *Want) GetReleaseId() int32 {
+func (m *ShelfList) GetEntries() []*ShelfEntry {
 	if m != nil {
-		return m.ReleaseId
+		return m.Entries
 	}
-	return 0
+	return nil
 }
 
-func (m *Want) GetValued() bool {
-	if m != nil {
-		return m.Valued
-	}
-	return false
+type InsertionRequest struct {
+	Release  *godiscogs.Release `protobuf:"bytes,1,opt,name=release" json:"release,omitempty"`
+	FolderId int32              `protobuf:"varint,2,opt,name=folder_id,json=folderId" json:"folder_id,omitempty"`
+	Order    ShelfOrder         `protobuf:"varint,3,opt,name=order,enum=discogsserver.ShelfOrder" json:"order,omitempty"`
 }
 
-func (m *Want) GetWanted() bool {
+func (m *InsertionRequest) Reset()                    { *m = InsertionRequest{} }
+func (m *InsertionRequest) String() string            { return proto.CompactTextString(m) }
+func (*InsertionRequest) ProtoMessage()               {}
+func (*InsertionRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+
+func (m *InsertionRequest) GetRelease() *godiscogs.Release {
 	if m != nil {
-		return m.Wanted
+		return m.Release
 	}
-	return false
+	return nil
 }
 
-type Wantlist struct {
-	Want []*Want `protobuf:"bytes,1,rep,name=want" json:"want,omitempty"`
+func (m *InsertionRequest) GetFolderId() int32 {
+	if m != nil {
+		return m.FolderId
+	}
+	return 0
 }
 
-func (m *Wantlist) Reset()                    { *m = Wantlist{} }
-func (m *Wantlist) String() string            { return proto.CompactTextString(m) }
-func (*Wantlist) ProtoMessage()               {}
-func (*Wantlist) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
-
-func (m *Wantlist) GetWant() []*Want {
+func (m *InsertionRequest) GetOrder() ShelfOrder {
 	if m != nil {
-		return m.Want
+		return m.Order
 	}
-	return nil
+	return ShelfOrder_BY_ARTIST
 }
 
-type SpendRequest struct {
-	Month int32 `protobuf:"varint,1,opt,name=month" json:"month,omitempty"`
-	Year  int32 `protobuf:"varint,2,opt,name=year" json:"year,omitempty"`
-	Lower int64 `protobuf:"varint,3,opt,name=lower" json:"lower,omitempty"`
-	Upper int64 `protobuf:"varint,4,opt,name=upper" json:"upper,omitempty"`
+type InsertionResponse struct {
+	// Where the new record should go
+	Position int32 `protobuf:"varint,1,opt,name=position" json:"position,omitempty"`
+	// Its neighbours once it's in place, unset at the ends of the shelf
+	Before *godiscogs.Release `protobuf:"bytes,2,opt,name=before" json:"before,omitempty"`
+	After  *godiscogs.Release `protobuf:"bytes,3,opt,name=after" json:"after,omitempty"`
+	// The records which move along one to make room, with their new positions
+	Shifted []*ShelfEntry `protobuf:"bytes,4,rep,name=shifted" json:"shifted,omitempty"`
 }
 
-func (m *SpendRequest) Reset()                    { *m = SpendRequest{} }
-func (m *SpendRequest) String() string            { return proto.CompactTextString(m) }
-func (*SpendRequest) ProtoMessage()               {}
-func (*SpendRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+func (m *InsertionResponse) Reset()                    { *m = InsertionResponse{} }
+func (m *InsertionResponse) String() string            { return proto.CompactTextString(m) }
+func (*InsertionResponse) ProtoMessage()               {}
+func (*InsertionResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
 
-func (m *SpendRequest) GetMonth() int32 {
+func (m *InsertionResponse) GetPosition() int32 {
 	if m != nil {
-		return m.Month
+		return m.Position
 	}
 	return 0
 }
 
-func (m *SpendRequest) GetYear() int32 {
+func (m *InsertionResponse) GetBefore() *godiscogs.Release {
 	if m != nil {
-		return m.Year
+		return m.Before
 	}
-	return 0
+	return nil
 }
 
-func (m *SpendRequest) GetLower() int64 {
+func (m *InsertionResponse) GetAfter() *godiscogs.Release {
 	if m != nil {
-		return m.Lower
+		return m.After
 	}
-	return 0
+	return nil
 }
 
-func (m *SpendRequest) GetUpper() int64 {
+func (m *InsertionResponse) GetShifted() []*ShelfEntry {
 	if m != nil {
-		return m.Upper
+		return m.Shifted
 	}
-	return 0
+	return nil
 }
 
-type SpendResponse struct {
-	TotalSpend int32             `protobuf:"varint,1,opt,name=total_spend,json=totalSpend" json:"total_spend,omitempty"`
-	Spends     []*MetadataUpdate `protobuf:"bytes,2,rep,name=spends" json:"spends,omitempty"`
+type Tag struct {
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	// The copy this tag applies to, zero for every copy
+	InstanceId int32 `protobuf:"varint,2,opt,name=instance_id,json=instanceId" json:"instance_id,omitempty"`
 }
 
-func (m *SpendResponse) Reset()                    { *m = SpendResponse{} }
-func (m *SpendResponse) String() string            { return proto.CompactTextString(m) }
-func (*SpendResponse) ProtoMessage()               {}
-func (*SpendResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+func (m *Tag) Reset()                    { *m = Tag{} }
+func (m *Tag) String() string            { return proto.CompactTextString(m) }
+func (*Tag) ProtoMessage()               {}
+func (*Tag) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
 
-func (m *SpendResponse) GetTotalSpend() int32 {
+func (m *Tag) GetName() string {
 	if m != nil {
-		return m.TotalSpend
+		return m.Name
 	}
-	return 0
+	return ""
 }
 
-func (m *SpendResponse) GetSpends() []*MetadataUpdate {
+func (m *Tag) GetInstanceId() int32 {
 	if m != nil {
-		return m.Spends
+		return m.InstanceId
 	}
-	return nil
+	return 0
 }
 
-type SearchRequest struct {
-	Query string `protobuf:"bytes,1,opt,name=query" json:"query,omitempty"`
+type TagRequest struct {
+	Release *godiscogs.Release `protobuf:"bytes,1,opt,name=release" json:"release,omitempty"`
+	Tags    []string           `protobuf:"bytes,2,rep,name=tags" json:"tags,omitempty"`
+	// Scope the tags to a single copy of the release
+	InstanceId int32 `protobuf:"varint,3,opt,name=instance_id,json=instanceId" json:"instance_id,omitempty"`
 }
 
-func (m *SearchRequest) Reset()                    { *m = SearchRequest{} }
-func (m *SearchRequest) String() string            { return proto.CompactTextString(m) }
-func (*SearchRequest) ProtoMessage()               {}
-func (*SearchRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+func (m *TagRequest) Reset()                    { *m = TagRequest{} }
+func (m *TagRequest) String() string            { return proto.CompactTextString(m) }
+func (*TagRequest) ProtoMessage()               {}
+func (*TagRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
 
-func (m *SearchRequest) GetQuery() string {
+func (m *TagRequest) GetRelease() *godiscogs.Release {
 	if m != nil {
-		return m.Query
+		return m.Release
 	}
-	return ""
+	return nil
 }
 
-func init() {
-	proto.RegisterType((*Token)(nil), "discogsserver.Token")
-	proto.RegisterType((*RecordCollection)(nil), "discogsserver.RecordCollection")
-	proto.RegisterType((*CollectionFolder)(nil), "discogsserver.CollectionFolder")
-	proto.RegisterType((*ReleaseMetadata)(nil), "discogsserver.ReleaseMetadata")
-	proto.RegisterType((*Record)(nil), "discogsserver.Record")
-	proto.RegisterType((*Empty)(nil), "discogsserver.Empty")
-	proto.RegisterType((*FolderList)(nil), "discogsserver.FolderList")
-	proto.RegisterType((*ReleaseList)(nil), "discogsserver.ReleaseList")
-	proto.RegisterType((*RecordList)(nil), "discogsserver.RecordList")
-	proto.RegisterType((*ReleaseMove)(nil), "discogsserver.ReleaseMove")
-	proto.RegisterType((*MetadataUpdate)(nil), "discogsserver.MetadataUpdate")
-	proto.RegisterType((*Want)(nil), "discogsserver.Want")
-	proto.RegisterType((*Wantlist)(nil), "discogsserver.Wantlist")
-	proto.RegisterType((*SpendRequest)(nil), "discogsserver.SpendRequest")
-	proto.RegisterType((*SpendResponse)(nil), "discogsserver.SpendResponse")
-	proto.RegisterType((*SearchRequest)(nil), "discogsserver.SearchRequest")
+func (m *TagRequest) GetTags() []string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
+func (m *TagRequest) GetInstanceId() int32 {
+	if m != nil {
+		return m.InstanceId
+	}
+	return 0
+}
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+type TagQuery struct {
+	// Releases must carry all of these
+	Tags []string `protobuf:"bytes,1,rep,name=tags" json:"tags,omitempty"`
+}
 
-// Client API for DiscogsService service
+func (m *TagQuery) Reset()                    { *m = TagQuery{} }
+func (m *TagQuery) String() string            { return proto.CompactTextString(m) }
+func (*TagQuery) ProtoMessage()               {}
+func (*TagQuery) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
 
-type DiscogsServiceClient interface {
-	GetCollection(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ReleaseList, error)
-	GetReleasesInFolder(ctx context.Context, in *FolderList, opts ...grpc.CallOption) (*RecordList, error)
-	MoveToFolder(ctx context.Context, in *ReleaseMove, opts ...grpc.CallOption) (*Empty, error)
-	AddToFolder(ctx context.Context, in *ReleaseMove, opts ...grpc.CallOption) (*Empty, error)
-	UpdateMetadata(ctx context.Context, in *MetadataUpdate, opts ...grpc.CallOption) (*ReleaseMetadata, error)
-	GetMetadata(ctx context.Context, in *godiscogs.Release, opts ...grpc.CallOption) (*ReleaseMetadata, error)
-	UpdateRating(ctx context.Context, in *godiscogs.Release, opts ...grpc.CallOption) (*Empty, error)
-	GetSingleRelease(ctx context.Context, in *godiscogs.Release, opts ...grpc.CallOption) (*godiscogs.Release, error)
-	GetWantlist(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Wantlist, error)
-	CollapseWantlist(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Wantlist, error)
-	RebuildWantlist(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Wantlist, error)
-	GetSpend(ctx context.Context, in *SpendRequest, opts ...grpc.CallOption) (*SpendResponse, error)
-	EditWant(ctx context.Context, in *Want, opts ...grpc.CallOption) (*Want, error)
-	DeleteWant(ctx context.Context, in *Want, opts ...grpc.CallOption) (*Wantlist, error)
-	AddWant(ctx context.Context, in *Want, opts ...grpc.CallOption) (*Empty, error)
-	SyncWithDiscogs(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error)
-	DeleteInstance(ctx context.Context, in *godiscogs.Release, opts ...grpc.CallOption) (*Empty, error)
-	Sell(ctx context.Context, in *godiscogs.Release, opts ...grpc.CallOption) (*Empty, error)
-	GetIncompleteReleases(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ReleaseList, error)
+func (m *TagQuery) GetTags() []string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
 }
 
-type discogsServiceClient struct {
-	cc *grpc.ClientConn
+type TagRename struct {
+	// Renaming onto an existing tag merges the two
+	From string `protobuf:"bytes,1,opt,name=from" json:"from,omitempty"`
+	To   string `protobuf:"bytes,2,opt,name=to" json:"to,omitempty"`
 }
 
-func NewDiscogsServiceClient(cc *grpc.ClientConn) DiscogsServiceClient {
-	return &discogsServiceClient{cc}
-}
+func (m *TagRename) Reset()                    { *m = TagRename{} }
+func (m *TagRename) String() string            { return proto.CompactTextString(m) }
+func (*TagRename) ProtoMessage()               {}
+func (*TagRename) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
 
-func (c *discogsServiceClient) GetCollection(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ReleaseList, error) {
-	out := new(ReleaseList)
-	err := grpc.Invoke(ctx, "/discogsserver.DiscogsService/GetCollection", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *TagRename) GetFrom() string {
+	if m != nil {
+		return m.From
 	}
-	return out, nil
+	return ""
 }
 
-func (c *discogsServiceClient) GetReleasesInFolder(ctx context.Context, in *FolderList, opts ...grpc.CallOption) (*RecordList, error) {
-	out := new(RecordList)
-	err := grpc.Invoke(ctx, "/discogsserver.DiscogsService/GetReleasesInFolder", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *TagRename) GetTo() string {
+	if m != nil {
+		return m.To
 	}
-	return out, nil
+	return ""
 }
 
-func (c *discogsServiceClient) MoveToFolder(ctx context.Context, in *ReleaseMove, opts ...grpc.CallOption) (*Empty, error) {
-	out := new(Empty)
-	err := grpc.Invoke(ctx, "/discogsserver.DiscogsService/MoveToFolder", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+type TagCount struct {
+	Tag   string `protobuf:"bytes,1,opt,name=tag" json:"tag,omitempty"`
+	Count int32  `protobuf:"varint,2,opt,name=count" json:"count,omitempty"`
 }
 
-func (c *discogsServiceClient) AddToFolder(ctx context.Context, in *ReleaseMove, opts ...grpc.CallOption) (*Empty, error) {
-	out := new(Empty)
-	err := grpc.Invoke(ctx, "/discogsserver.DiscogsService/AddToFolder", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *TagCount) Reset()                    { *m = TagCount{} }
+func (m *TagCount) String() string            { return proto.CompactTextString(m) }
+func (*TagCount) ProtoMessage()               {}
+func (*TagCount) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+
+func (m *TagCount) GetTag() string {
+	if m != nil {
+		return m.Tag
 	}
-	return out, nil
+	return ""
 }
 
-func (c *discogsServiceClient) UpdateMetadata(ctx context.Context, in *MetadataUpdate, opts ...grpc.CallOption) (*ReleaseMetadata, error) {
-	out := new(ReleaseMetadata)
-	err := grpc.Invoke(ctx, "/discogsserver.DiscogsService/UpdateMetadata", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *TagCount) GetCount() int32 {
+	if m != nil {
+		return m.Count
 	}
-	return out, nil
+	return 0
 }
 
-func (c *discogsServiceClient) GetMetadata(ctx context.Context, in *godiscogs.Release, opts ...grpc.CallOption) (*ReleaseMetadata, error) {
-	out := new(ReleaseMetadata)
-	err := grpc.Invoke(ctx, "/discogsserver.DiscogsService/GetMetadata", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+type TagCountList struct {
+	Counts []*TagCount `protobuf:"bytes,1,rep,name=counts" json:"counts,omitempty"`
+}
+
+func (m *TagCountList) Reset()                    { *m = TagCountList{} }
+func (m *TagCountList) String() string            { return proto.CompactTextString(m) }
+func (*TagCountList) ProtoMessage()               {}
+func (*TagCountList) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+
+func (m *TagCountList) GetCounts() []*TagCount {
+	if m != nil {
+		return m.Counts
 	}
-	return out, nil
+	return nil
 }
 
-func (c *discogsServiceClient) UpdateRating(ctx context.Context, in *godiscogs.Release, opts ...grpc.CallOption) (*Empty, error) {
-	out := new(Empty)
-	err := grpc.Invoke(ctx, "/discogsserver.DiscogsService/UpdateRating", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+type FieldDefinition struct {
+	Name string    `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Type FieldType `protobuf:"varint,2,opt,name=type,enum=discogsserver.FieldType" json:"type,omitempty"`
+	// The allowed values of an ENUM field
+	Options []string `protobuf:"bytes,3,rep,name=options" json:"options,omitempty"`
+}
+
+func (m *FieldDefinition) Reset()                    { *m = FieldDefinition{} }
+func (m *FieldDefinition) String() string            { return proto.CompactTextString(m) }
+func (*FieldDefinition) ProtoMessage()               {}
+func (*FieldDefinition) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+
+func (m *FieldDefinition) GetName() string {
+	if m != nil {
+		return m.Name
 	}
-	return out, nil
+	return ""
 }
 
-func (c *discogsServiceClient) GetSingleRelease(ctx context.Context, in *godiscogs.Release, opts ...grpc.CallOption) (*godiscogs.Release, error) {
-	out := new(godiscogs.Release)
-	err := grpc.Invoke(ctx, "/discogsserver.DiscogsService/GetSingleRelease", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *FieldDefinition) GetType() FieldType {
+	if m != nil {
+		return m.Type
 	}
-	return out, nil
+	return FieldType_STRING
 }
 
-func (c *discogsServiceClient) GetWantlist(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Wantlist, error) {
-	out := new(Wantlist)
-	err := grpc.Invoke(ctx, "/discogsserver.DiscogsService/GetWantlist", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *FieldDefinition) GetOptions() []string {
+	if m != nil {
+		return m.Options
 	}
-	return out, nil
+	return nil
 }
 
-func (c *discogsServiceClient) CollapseWantlist(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Wantlist, error) {
-	out := new(Wantlist)
-	err := grpc.Invoke(ctx, "/discogsserver.DiscogsService/CollapseWantlist", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+type FieldSchema struct {
+	Fields []*FieldDefinition `protobuf:"bytes,1,rep,name=fields" json:"fields,omitempty"`
+}
+
+func (m *FieldSchema) Reset()                    { *m = FieldSchema{} }
+func (m *FieldSchema) String() string            { return proto.CompactTextString(m) }
+func (*FieldSchema) ProtoMessage()               {}
+func (*FieldSchema) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+
+func (m *FieldSchema) GetFields() []*FieldDefinition {
+	if m != nil {
+		return m.Fields
 	}
-	return out, nil
+	return nil
 }
 
-func (c *discogsServiceClient) RebuildWantlist(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Wantlist, error) {
-	out := new(Wantlist)
-	err := grpc.Invoke(ctx, "/discogsserver.DiscogsService/RebuildWantlist", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+type CustomField struct {
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	// The value as a string, dates are 2006-01-02, an empty value clears the field
+	Value string `protobuf:"bytes,2,opt,name=value" json:"value,omitempty"`
+	// The copy this value applies to, zero for every copy
+	InstanceId int32 `protobuf:"varint,3,opt,name=instance_id,json=instanceId" json:"instance_id,omitempty"`
+}
+
+func (m *CustomField) Reset()                    { *m = CustomField{} }
+func (m *CustomField) String() string            { return proto.CompactTextString(m) }
+func (*CustomField) ProtoMessage()               {}
+func (*CustomField) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
+
+func (m *CustomField) GetName() string {
+	if m != nil {
+		return m.Name
 	}
-	return out, nil
+	return ""
 }
 
-func (c *discogsServiceClient) GetSpend(ctx context.Context, in *SpendRequest, opts ...grpc.CallOption) (*SpendResponse, error) {
-	out := new(SpendResponse)
-	err := grpc.Invoke(ctx, "/discogsserver.DiscogsService/GetSpend", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *CustomField) GetValue() string {
+	if m != nil {
+		return m.Value
 	}
-	return out, nil
+	return ""
 }
 
-func (c *discogsServiceClient) EditWant(ctx context.Context, in *Want, opts ...grpc.CallOption) (*Want, error) {
-	out := new(Want)
-	err := grpc.Invoke(ctx, "/discogsserver.DiscogsService/EditWant", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *CustomField) GetInstanceId() int32 {
+	if m != nil {
+		return m.InstanceId
 	}
-	return out, nil
+	return 0
 }
 
-func (c *discogsServiceClient) DeleteWant(ctx context.Context, in *Want, opts ...grpc.CallOption) (*Wantlist, error) {
-	out := new(Wantlist)
-	err := grpc.Invoke(ctx, "/discogsserver.DiscogsService/DeleteWant", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+type Record struct {
+	Release  *godiscogs.Release `protobuf:"bytes,1,opt,name=release" json:"release,omitempty"`
+	Metadata *ReleaseMetadata   `protobuf:"bytes,2,opt,name=metadata" json:"metadata,omitempty"`
+	// Set if the record is currently out on loan
+	Loan *Loan `protobuf:"bytes,3,opt,name=loan" json:"loan,omitempty"`
+}
+
+func (m *Record) Reset()                    { *m = Record{} }
+func (m *Record) String() string            { return proto.CompactTextString(m) }
+func (*Record) ProtoMessage()               {}
+func (*Record) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
+
+func (m *Record) GetRelease() *godiscogs.Release {
+	if m != nil {
+		return m.Release
 	}
-	return out, nil
+	return nil
 }
 
-func (c *discogsServiceClient) AddWant(ctx context.Context, in *Want, opts ...grpc.CallOption) (*Empty, error) {
-	out := new(Empty)
-	err := grpc.Invoke(ctx, "/discogsserver.DiscogsService/AddWant", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *Record) GetMetadata() *ReleaseMetadata {
+	if m != nil {
+		return m.Metadata
 	}
-	return out, nil
+	return nil
 }
 
-func (c *discogsServiceClient) SyncWithDiscogs(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error) {
-	out := new(Empty)
-	err := grpc.Invoke(ctx, "/discogsserver.DiscogsService/SyncWithDiscogs", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *Record) GetLoan() *Loan {
+	if m != nil {
+		return m.Loan
 	}
-	return out, nil
+	return nil
 }
 
-func (c *discogsServiceClient) DeleteInstance(ctx context.Context, in *godiscogs.Release, opts ...grpc.CallOption) (*Empty, error) {
-	out := new(Empty)
-	err := grpc.Invoke(ctx, "/discogsserver.DiscogsService/DeleteInstance", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+type Empty struct {
+}
+
+func (m *Empty) Reset()                    { *m = Empty{} }
+func (m *Empty) String() string            { return proto.CompactTextString(m) }
+func (*Empty) ProtoMessage()               {}
+func (*Empty) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
+
+type FolderList struct {
+	Folders []*godiscogs.Folder `protobuf:"bytes,1,rep,name=folders" json:"folders,omitempty"`
+}
+
+func (m *FolderList) Reset()                    { *m = FolderList{} }
+func (m *FolderList) String() string            { return proto.CompactTextString(m) }
+func (*FolderList) ProtoMessage()               {}
+func (*FolderList) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
+
+func (m *FolderList) GetFolders() []*godiscogs.Folder {
+	if m != nil {
+		return m.Folders
 	}
-	return out, nil
+	return nil
+}
+
+type ReleaseList struct {
+	Releases []*godiscogs.Release `protobuf:"bytes,1,rep,name=releases" json:"releases,omitempty"`
+}
+
+func (m *ReleaseList) Reset()                    { *m = ReleaseList{} }
+func (m *ReleaseList) String() string            { return proto.CompactTextString(m) }
+func (*ReleaseList) ProtoMessage()               {}
+func (*ReleaseList) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
+
+func (m *ReleaseList) GetReleases() []*godiscogs.Release {
+	if m != nil {
+		return m.Releases
+	}
+	return nil
+}
+
+type RecordList struct {
+	Records []*Record `protobuf:"bytes,1,rep,name=records" json:"records,omitempty"`
+}
+
+func (m *RecordList) Reset()                    { *m = RecordList{} }
+func (m *RecordList) String() string            { return proto.CompactTextString(m) }
+func (*RecordList) ProtoMessage()               {}
+func (*RecordList) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
+
+func (m *RecordList) GetRecords() []*Record {
+	if m != nil {
+		return m.Records
+	}
+	return nil
+}
+
+type ReleaseMove struct {
+	Release     *godiscogs.Release `protobuf:"bytes,1,opt,name=release" json:"release,omitempty"`
+	NewFolderId int32              `protobuf:"varint,2,opt,name=new_folder_id,json=newFolderId" json:"new_folder_id,omitempty"`
+}
+
+func (m *ReleaseMove) Reset()                    { *m = ReleaseMove{} }
+func (m *ReleaseMove) String() string            { return proto.CompactTextString(m) }
+func (*ReleaseMove) ProtoMessage()               {}
+func (*ReleaseMove) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
+
+func (m *ReleaseMove) GetRelease() *godiscogs.Release {
+	if m != nil {
+		return m.Release
+	}
+	return nil
+}
+
+func (m *ReleaseMove) GetNewFolderId() int32 {
+	if m != nil {
+		return m.NewFolderId
+	}
+	return 0
+}
+
+type FolderDelete struct {
+	Folder *godiscogs.Folder `protobuf:"bytes,1,opt,name=folder" json:"folder,omitempty"`
+	// Where to move anything left in the folder, non-empty folders are
+	// only deleted when this is set
+	DestinationId int32 `protobuf:"varint,2,opt,name=destination_id,json=destinationId" json:"destination_id,omitempty"`
+}
+
+func (m *FolderDelete) Reset()                    { *m = FolderDelete{} }
+func (m *FolderDelete) String() string            { return proto.CompactTextString(m) }
+func (*FolderDelete) ProtoMessage()               {}
+func (*FolderDelete) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
+
+func (m *FolderDelete) GetFolder() *godiscogs.Folder {
+	if m != nil {
+		return m.Folder
+	}
+	return nil
+}
+
+func (m *FolderDelete) GetDestinationId() int32 {
+	if m != nil {
+		return m.DestinationId
+	}
+	return 0
+}
+
+type MetadataUpdate struct {
+	Release *godiscogs.Release `protobuf:"bytes,1,opt,name=release" json:"release,omitempty"`
+	Update  *ReleaseMetadata   `protobuf:"bytes,2,opt,name=update" json:"update,omitempty"`
+}
+
+func (m *MetadataUpdate) Reset()                    { *m = MetadataUpdate{} }
+func (m *MetadataUpdate) String() string            { return proto.CompactTextString(m) }
+func (*MetadataUpdate) ProtoMessage()               {}
+func (*MetadataUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26} }
+
+func (m *MetadataUpdate) GetRelease() *godiscogs.Release {
+	if m != nil {
+		return m.Release
+	}
+	return nil
+}
+
+func (m *MetadataUpdate) GetUpdate() *ReleaseMetadata {
+	if m != nil {
+		return m.Update
+	}
+	return nil
+}
+
+type BulkMoveRequest struct {
+	Moves []*ReleaseMove `protobuf:"bytes,1,rep,name=moves" json:"moves,omitempty"`
+}
+
+func (m *BulkMoveRequest) Reset()                    { *m = BulkMoveRequest{} }
+func (m *BulkMoveRequest) String() string            { return proto.CompactTextString(m) }
+func (*BulkMoveRequest) ProtoMessage()               {}
+func (*BulkMoveRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{27} }
+
+func (m *BulkMoveRequest) GetMoves() []*ReleaseMove {
+	if m != nil {
+		return m.Moves
+	}
+	return nil
+}
+
+type BulkMetadataRequest struct {
+	Updates []*MetadataUpdate `protobuf:"bytes,1,rep,name=updates" json:"updates,omitempty"`
+}
+
+func (m *BulkMetadataRequest) Reset()                    { *m = BulkMetadataRequest{} }
+func (m *BulkMetadataRequest) String() string            { return proto.CompactTextString(m) }
+func (*BulkMetadataRequest) ProtoMessage()               {}
+func (*BulkMetadataRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{28} }
+
+func (m *BulkMetadataRequest) GetUpdates() []*MetadataUpdate {
+	if m != nil {
+		return m.Updates
+	}
+	return nil
+}
+
+type OperationResult struct {
+	Release *godiscogs.Release `protobuf:"bytes,1,opt,name=release" json:"release,omitempty"`
+	Success bool               `protobuf:"varint,2,opt,name=success" json:"success,omitempty"`
+	Error   string             `protobuf:"bytes,3,opt,name=error" json:"error,omitempty"`
+}
+
+func (m *OperationResult) Reset()                    { *m = OperationResult{} }
+func (m *OperationResult) String() string            { return proto.CompactTextString(m) }
+func (*OperationResult) ProtoMessage()               {}
+func (*OperationResult) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{29} }
+
+func (m *OperationResult) GetRelease() *godiscogs.Release {
+	if m != nil {
+		return m.Release
+	}
+	return nil
+}
+
+func (m *OperationResult) GetSuccess() bool {
+	if m != nil {
+		return m.Success
+	}
+	return false
+}
+
+func (m *OperationResult) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type BulkResponse struct {
+	// One result per operation, in request order
+	Results []*OperationResult `protobuf:"bytes,1,rep,name=results" json:"results,omitempty"`
+}
+
+func (m *BulkResponse) Reset()                    { *m = BulkResponse{} }
+func (m *BulkResponse) String() string            { return proto.CompactTextString(m) }
+func (*BulkResponse) ProtoMessage()               {}
+func (*BulkResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{30} }
+
+func (m *BulkResponse) GetResults() []*OperationResult {
+	if m != nil {
+		return m.Results
+	}
+	return nil
+}
+
+type Want struct {
+	ReleaseId int32 `protobuf:"varint,1,opt,name=release_id,json=releaseId" json:"release_id,omitempty"`
+	Valued    bool  `protobuf:"varint,2,opt,name=valued" json:"valued,omitempty"`
+	Wanted    bool  `protobuf:"varint,3,opt,name=wanted" json:"wanted,omitempty"`
+	// How much we want this, higher means more
+	Priority int32 `protobuf:"varint,4,opt,name=priority" json:"priority,omitempty"`
+	// Free text notes on the want
+	Note string `protobuf:"bytes,5,opt,name=note" json:"note,omitempty"`
+	// The most we're willing to pay in pence
+	MaxPrice int32 `protobuf:"varint,6,opt,name=max_price,json=maxPrice" json:"max_price,omitempty"`
+	// The date the want was added
+	DateAdded int64    `protobuf:"varint,7,opt,name=date_added,json=dateAdded" json:"date_added,omitempty"`
+	Tags      []string `protobuf:"bytes,8,rep,name=tags" json:"tags,omitempty"`
+	// Any pressing of this master will do, release_id is zero unless this
+	// want was expanded from a master want
+	MasterId int32 `protobuf:"varint,9,opt,name=master_id,json=masterId" json:"master_id,omitempty"`
+	// Constraints on which pressings of the master will do
+	Format  string `protobuf:"bytes,10,opt,name=format" json:"format,omitempty"`
+	Country string `protobuf:"bytes,11,opt,name=country" json:"country,omitempty"`
+	MinYear int32  `protobuf:"varint,12,opt,name=min_year,json=minYear" json:"min_year,omitempty"`
+	MaxYear int32  `protobuf:"varint,13,opt,name=max_year,json=maxYear" json:"max_year,omitempty"`
+	// The fields EditWant should set, named as in this message; fields listed here
+	// are set even when zero, and with no mask only the non-zero fields are set
+	UpdateMask []string `protobuf:"bytes,14,rep,name=update_mask,json=updateMask" json:"update_mask,omitempty"`
+}
+
+func (m *Want) Reset()                    { *m = Want{} }
+func (m *Want) String() string            { return proto.CompactTextString(m) }
+func (*Want) ProtoMessage()               {}
+func (*Want) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{31} }
+
+func (m *Want) GetReleaseId() int32 {
+	if m != nil {
+		return m.ReleaseId
+	}
+	return 0
+}
+
+func (m *Want) GetValued() bool {
+	if m != nil {
+		return m.Valued
+	}
+	return false
+}
+
+func (m *Want) GetWanted() bool {
+	if m != nil {
+		return m.Wanted
+	}
+	return false
+}
+
+func (m *Want) GetPriority() int32 {
+	if m != nil {
+		return m.Priority
+	}
+	return 0
+}
+
+func (m *Want) GetNote() string {
+	if m != nil {
+		return m.Note
+	}
+	return ""
+}
+
+func (m *Want) GetMaxPrice() int32 {
+	if m != nil {
+		return m.MaxPrice
+	}
+	return 0
+}
+
+func (m *Want) GetDateAdded() int64 {
+	if m != nil {
+		return m.DateAdded
+	}
+	return 0
+}
+
+func (m *Want) GetTags() []string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+func (m *Want) GetMasterId() int32 {
+	if m != nil {
+		return m.MasterId
+	}
+	return 0
+}
+
+func (m *Want) GetFormat() string {
+	if m != nil {
+		return m.Format
+	}
+	return ""
+}
+
+func (m *Want) GetCountry() string {
+	if m != nil {
+		return m.Country
+	}
+	return ""
+}
+
+func (m *Want) GetMinYear() int32 {
+	if m != nil {
+		return m.MinYear
+	}
+	return 0
+}
+
+func (m *Want) GetMaxYear() int32 {
+	if m != nil {
+		return m.MaxYear
+	}
+	return 0
+}
+
+func (m *Want) GetUpdateMask() []string {
+	if m != nil {
+		return m.UpdateMask
+	}
+	return nil
+}
+
+type WantlistRequest struct {
+	// Only return wants at or above this priority
+	MinPriority int32 `protobuf:"varint,1,opt,name=min_priority,json=minPriority" json:"min_priority,omitempty"`
+	// Only return wants carrying all of these tags
+	Tags []string `protobuf:"bytes,2,rep,name=tags" json:"tags,omitempty"`
+	// Only return wants which are currently on the discogs wantlist
+	WantedOnly bool     `protobuf:"varint,3,opt,name=wanted_only,json=wantedOnly" json:"wanted_only,omitempty"`
+	Sort       WantSort `protobuf:"varint,4,opt,name=sort,enum=discogsserver.WantSort" json:"sort,omitempty"`
+}
+
+func (m *WantlistRequest) Reset()                    { *m = WantlistRequest{} }
+func (m *WantlistRequest) String() string            { return proto.CompactTextString(m) }
+func (*WantlistRequest) ProtoMessage()               {}
+func (*WantlistRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{32} }
+
+func (m *WantlistRequest) GetMinPriority() int32 {
+	if m != nil {
+		return m.MinPriority
+	}
+	return 0
+}
+
+func (m *WantlistRequest) GetTags() []string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+func (m *WantlistRequest) GetWantedOnly() bool {
+	if m != nil {
+		return m.WantedOnly
+	}
+	return false
+}
+
+func (m *WantlistRequest) GetSort() WantSort {
+	if m != nil {
+		return m.Sort
+	}
+	return WantSort_UNSORTED
+}
+
+type CollapseRequest struct {
+	// Keep wants at or above this priority, when unset we keep valued wants
+	PriorityThreshold int32 `protobuf:"varint,1,opt,name=priority_threshold,json=priorityThreshold" json:"priority_threshold,omitempty"`
+}
+
+func (m *CollapseRequest) Reset()                    { *m = CollapseRequest{} }
+func (m *CollapseRequest) String() string            { return proto.CompactTextString(m) }
+func (*CollapseRequest) ProtoMessage()               {}
+func (*CollapseRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{33} }
+
+func (m *CollapseRequest) GetPriorityThreshold() int32 {
+	if m != nil {
+		return m.PriorityThreshold
+	}
+	return 0
+}
+
+type Wantlist struct {
+	Want []*Want `protobuf:"bytes,1,rep,name=want" json:"want,omitempty"`
+}
+
+func (m *Wantlist) Reset()                    { *m = Wantlist{} }
+func (m *Wantlist) String() string            { return proto.CompactTextString(m) }
+func (*Wantlist) ProtoMessage()               {}
+func (*Wantlist) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{34} }
+
+func (m *Wantlist) GetWant() []*Want {
+	if m != nil {
+		return m.Want
+	}
+	return nil
+}
+
+type SpendRequest struct {
+	Month int32 `protobuf:"varint,1,opt,name=month" json:"month,omitempty"`
+	Year  int32 `protobuf:"varint,2,opt,name=year" json:"year,omitempty"`
+	Lower int64 `protobuf:"varint,3,opt,name=lower" json:"lower,omitempty"`
+	Upper int64 `protobuf:"varint,4,opt,name=upper" json:"upper,omitempty"`
+	// Only count releases carrying all of these tags
+	Tags []string `protobuf:"bytes,5,rep,name=tags" json:"tags,omitempty"`
+}
+
+func (m *SpendRequest) Reset()                    { *m = SpendRequest{} }
+func (m *SpendRequest) String() string            { return proto.CompactTextString(m) }
+func (*SpendRequest) ProtoMessage()               {}
+func (*SpendRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{35} }
+
+func (m *SpendRequest) GetMonth() int32 {
+	if m != nil {
+		return m.Month
+	}
+	return 0
+}
+
+func (m *SpendRequest) GetYear() int32 {
+	if m != nil {
+		return m.Year
+	}
+	return 0
+}
+
+func (m *SpendRequest) GetLower() int64 {
+	if m != nil {
+		return m.Lower
+	}
+	return 0
+}
+
+func (m *SpendRequest) GetUpper() int64 {
+	if m != nil {
+		return m.Upper
+	}
+	return 0
+}
+
+func (m *SpendRequest) GetTags() []string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+type SpendResponse struct {
+	TotalSpend int32             `protobuf:"varint,1,opt,name=total_spend,json=totalSpend" json:"total_spend,omitempty"`
+	Spends     []*MetadataUpdate `protobuf:"bytes,2,rep,name=spends" json:"spends,omitempty"`
+}
+
+func (m *SpendResponse) Reset()                    { *m = SpendResponse{} }
+func (m *SpendResponse) String() string            { return proto.CompactTextString(m) }
+func (*SpendResponse) ProtoMessage()               {}
+func (*SpendResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{36} }
+
+func (m *SpendResponse) GetTotalSpend() int32 {
+	if m != nil {
+		return m.TotalSpend
+	}
+	return 0
+}
+
+func (m *SpendResponse) GetSpends() []*MetadataUpdate {
+	if m != nil {
+		return m.Spends
+	}
+	return nil
+}
+
+type SearchRequest struct {
+	Query string `protobuf:"bytes,1,opt,name=query" json:"query,omitempty"`
+	// Only return releases whose custom fields match all of these
+	Fields []*CustomField `protobuf:"bytes,2,rep,name=fields" json:"fields,omitempty"`
+	// Only return releases carrying all of these tags
+	Tags []string `protobuf:"bytes,3,rep,name=tags" json:"tags,omitempty"`
+}
+
+func (m *SearchRequest) Reset()                    { *m = SearchRequest{} }
+func (m *SearchRequest) String() string            { return proto.CompactTextString(m) }
+func (*SearchRequest) ProtoMessage()               {}
+func (*SearchRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{37} }
+
+func (m *SearchRequest) GetQuery() string {
+	if m != nil {
+		return m.Query
+	}
+	return ""
+}
+
+func (m *SearchRequest) GetFields() []*CustomField {
+	if m != nil {
+		return m.Fields
+	}
+	return nil
+}
+
+func (m *SearchRequest) GetTags() []string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+type SyncResult struct {
+	// Wants which turned up in the collection during the sync
+	Fulfilled []*Want `protobuf:"bytes,1,rep,name=fulfilled" json:"fulfilled,omitempty"`
+}
+
+func (m *SyncResult) Reset()                    { *m = SyncResult{} }
+func (m *SyncResult) String() string            { return proto.CompactTextString(m) }
+func (*SyncResult) ProtoMessage()               {}
+func (*SyncResult) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{38} }
+
+func (m *SyncResult) GetFulfilled() []*Want {
+	if m != nil {
+		return m.Fulfilled
+	}
+	return nil
+}
+
+type SellCandidatesRequest struct {
+	// Flag records rated at or below this (unrated records are ignored)
+	MaxRating int32 `protobuf:"varint,1,opt,name=max_rating,json=maxRating" json:"max_rating,omitempty"`
+	// Flag records which haven't been touched in this many seconds
+	UntouchedFor int64 `protobuf:"varint,2,opt,name=untouched_for,json=untouchedFor" json:"untouched_for,omitempty"`
+	// Skip records whose sale price is below this
+	MinValue float32 `protobuf:"fixed32,3,opt,name=min_value,json=minValue" json:"min_value,omitempty"`
+	// Flag records where we own another pressing of the same master
+	IncludeDuplicates bool `protobuf:"varint,4,opt,name=include_duplicates,json=includeDuplicates" json:"include_duplicates,omitempty"`
+	// The maximum number of candidates to return (0 returns all)
+	Limit int32 `protobuf:"varint,5,opt,name=limit" json:"limit,omitempty"`
+}
+
+func (m *SellCandidatesRequest) Reset()                    { *m = SellCandidatesRequest{} }
+func (m *SellCandidatesRequest) String() string            { return proto.CompactTextString(m) }
+func (*SellCandidatesRequest) ProtoMessage()               {}
+func (*SellCandidatesRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{39} }
+
+func (m *SellCandidatesRequest) GetMaxRating() int32 {
+	if m != nil {
+		return m.MaxRating
+	}
+	return 0
+}
+
+func (m *SellCandidatesRequest) GetUntouchedFor() int64 {
+	if m != nil {
+		return m.UntouchedFor
+	}
+	return 0
+}
+
+func (m *SellCandidatesRequest) GetMinValue() float32 {
+	if m != nil {
+		return m.MinValue
+	}
+	return 0
+}
+
+func (m *SellCandidatesRequest) GetIncludeDuplicates() bool {
+	if m != nil {
+		return m.IncludeDuplicates
+	}
+	return false
+}
+
+func (m *SellCandidatesRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type SellCandidate struct {
+	Release  *godiscogs.Release `protobuf:"bytes,1,opt,name=release" json:"release,omitempty"`
+	Metadata *ReleaseMetadata   `protobuf:"bytes,2,opt,name=metadata" json:"metadata,omitempty"`
+	// The suggested sale price
+	Value float32 `protobuf:"fixed32,3,opt,name=value" json:"value,omitempty"`
+	// Higher scores are better candidates
+	Score int32 `protobuf:"varint,4,opt,name=score" json:"score,omitempty"`
+	// Why this record was picked
+	Reasons []string `protobuf:"bytes,5,rep,name=reasons" json:"reasons,omitempty"`
+}
+
+func (m *SellCandidate) Reset()                    { *m = SellCandidate{} }
+func (m *SellCandidate) String() string            { return proto.CompactTextString(m) }
+func (*SellCandidate) ProtoMessage()               {}
+func (*SellCandidate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{40} }
+
+func (m *SellCandidate) GetRelease() *godiscogs.Release {
+	if m != nil {
+		return m.Release
+	}
+	return nil
+}
+
+func (m *SellCandidate) GetMetadata() *ReleaseMetadata {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+func (m *SellCandidate) GetValue() float32 {
+	if m != nil {
+		return m.Value
+	}
+	return 0
+}
+
+func (m *SellCandidate) GetScore() int32 {
+	if m != nil {
+		return m.Score
+	}
+	return 0
+}
+
+func (m *SellCandidate) GetReasons() []string {
+	if m != nil {
+		return m.Reasons
+	}
+	return nil
+}
+
+type SellCandidateList struct {
+	Candidates []*SellCandidate `protobuf:"bytes,1,rep,name=candidates" json:"candidates,omitempty"`
+}
+
+func (m *SellCandidateList) Reset()                    { *m = SellCandidateList{} }
+func (m *SellCandidateList) String() string            { return proto.CompactTextString(m) }
+func (*SellCandidateList) ProtoMessage()               {}
+func (*SellCandidateList) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{41} }
+
+func (m *SellCandidateList) GetCandidates() []*SellCandidate {
+	if m != nil {
+		return m.Candidates
+	}
+	return nil
+}
+
+type BulkSellRequest struct {
+	Releases []*godiscogs.Release `protobuf:"bytes,1,rep,name=releases" json:"releases,omitempty"`
+}
+
+func (m *BulkSellRequest) Reset()                    { *m = BulkSellRequest{} }
+func (m *BulkSellRequest) String() string            { return proto.CompactTextString(m) }
+func (*BulkSellRequest) ProtoMessage()               {}
+func (*BulkSellRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{42} }
+
+func (m *BulkSellRequest) GetReleases() []*godiscogs.Release {
+	if m != nil {
+		return m.Releases
+	}
+	return nil
+}
+
+type SellResult struct {
+	Release *godiscogs.Release `protobuf:"bytes,1,opt,name=release" json:"release,omitempty"`
+	Price   float32            `protobuf:"fixed32,2,opt,name=price" json:"price,omitempty"`
+	Success bool               `protobuf:"varint,3,opt,name=success" json:"success,omitempty"`
+	Error   string             `protobuf:"bytes,4,opt,name=error" json:"error,omitempty"`
+}
+
+func (m *SellResult) Reset()                    { *m = SellResult{} }
+func (m *SellResult) String() string            { return proto.CompactTextString(m) }
+func (*SellResult) ProtoMessage()               {}
+func (*SellResult) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{43} }
+
+func (m *SellResult) GetRelease() *godiscogs.Release {
+	if m != nil {
+		return m.Release
+	}
+	return nil
+}
+
+func (m *SellResult) GetPrice() float32 {
+	if m != nil {
+		return m.Price
+	}
+	return 0
+}
+
+func (m *SellResult) GetSuccess() bool {
+	if m != nil {
+		return m.Success
+	}
+	return false
+}
+
+func (m *SellResult) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type BulkSellResponse struct {
+	Results []*SellResult `protobuf:"bytes,1,rep,name=results" json:"results,omitempty"`
+}
+
+func (m *BulkSellResponse) Reset()                    { *m = BulkSellResponse{} }
+func (m *BulkSellResponse) String() string            { return proto.CompactTextString(m) }
+func (*BulkSellResponse) ProtoMessage()               {}
+func (*BulkSellResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{44} }
+
+func (m *BulkSellResponse) GetResults() []*SellResult {
+	if m != nil {
+		return m.Results
+	}
+	return nil
+}
+
+type Play struct {
+	ReleaseId int32 `protobuf:"varint,1,opt,name=release_id,json=releaseId" json:"release_id,omitempty"`
+	// The instance that was played, filled from the collection if unset
+	InstanceId int32 `protobuf:"varint,2,opt,name=instance_id,json=instanceId" json:"instance_id,omitempty"`
+	// When the listen happened, defaults to now
+	Date int64 `protobuf:"varint,3,opt,name=date" json:"date,omitempty"`
+	// Optionally what we listened to, e.g. side "B" or track 3
+	Side  string `protobuf:"bytes,4,opt,name=side" json:"side,omitempty"`
+	Track int32  `protobuf:"varint,5,opt,name=track" json:"track,omitempty"`
+	// Who was listening
+	Listener string `protobuf:"bytes,6,opt,name=listener" json:"listener,omitempty"`
+}
+
+func (m *Play) Reset()                    { *m = Play{} }
+func (m *Play) String() string            { return proto.CompactTextString(m) }
+func (*Play) ProtoMessage()               {}
+func (*Play) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{45} }
+
+func (m *Play) GetReleaseId() int32 {
+	if m != nil {
+		return m.ReleaseId
+	}
+	return 0
+}
+
+func (m *Play) GetInstanceId() int32 {
+	if m != nil {
+		return m.InstanceId
+	}
+	return 0
+}
+
+func (m *Play) GetDate() int64 {
+	if m != nil {
+		return m.Date
+	}
+	return 0
+}
+
+func (m *Play) GetSide() string {
+	if m != nil {
+		return m.Side
+	}
+	return ""
+}
+
+func (m *Play) GetTrack() int32 {
+	if m != nil {
+		return m.Track
+	}
+	return 0
+}
+
+func (m *Play) GetListener() string {
+	if m != nil {
+		return m.Listener
+	}
+	return ""
+}
+
+type PlayList struct {
+	Plays []*Play `protobuf:"bytes,1,rep,name=plays" json:"plays,omitempty"`
+}
+
+func (m *PlayList) Reset()                    { *m = PlayList{} }
+func (m *PlayList) String() string            { return proto.CompactTextString(m) }
+func (*PlayList) ProtoMessage()               {}
+func (*PlayList) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{46} }
+
+func (m *PlayList) GetPlays() []*Play {
+	if m != nil {
+		return m.Plays
+	}
+	return nil
+}
+
+type PlayRequest struct {
+	// Restrict to plays of this release or instance
+	ReleaseId  int32 `protobuf:"varint,1,opt,name=release_id,json=releaseId" json:"release_id,omitempty"`
+	InstanceId int32 `protobuf:"varint,2,opt,name=instance_id,json=instanceId" json:"instance_id,omitempty"`
+	// Restrict to plays in this window
+	Since    int64  `protobuf:"varint,3,opt,name=since" json:"since,omitempty"`
+	Until    int64  `protobuf:"varint,4,opt,name=until" json:"until,omitempty"`
+	Listener string `protobuf:"bytes,5,opt,name=listener" json:"listener,omitempty"`
+}
+
+func (m *PlayRequest) Reset()                    { *m = PlayRequest{} }
+func (m *PlayRequest) String() string            { return proto.CompactTextString(m) }
+func (*PlayRequest) ProtoMessage()               {}
+func (*PlayRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{47} }
+
+func (m *PlayRequest) GetReleaseId() int32 {
+	if m != nil {
+		return m.ReleaseId
+	}
+	return 0
+}
+
+func (m *PlayRequest) GetInstanceId() int32 {
+	if m != nil {
+		return m.InstanceId
+	}
+	return 0
+}
+
+func (m *PlayRequest) GetSince() int64 {
+	if m != nil {
+		return m.Since
+	}
+	return 0
+}
+
+func (m *PlayRequest) GetUntil() int64 {
+	if m != nil {
+		return m.Until
+	}
+	return 0
+}
+
+func (m *PlayRequest) GetListener() string {
+	if m != nil {
+		return m.Listener
+	}
+	return ""
+}
+
+type PlayCountRequest struct {
+	// Put the least played records first
+	LeastFirst bool `protobuf:"varint,1,opt,name=least_first,json=leastFirst" json:"least_first,omitempty"`
+	// The maximum number of counts to return (0 returns all)
+	Limit int32 `protobuf:"varint,2,opt,name=limit" json:"limit,omitempty"`
+}
+
+func (m *PlayCountRequest) Reset()                    { *m = PlayCountRequest{} }
+func (m *PlayCountRequest) String() string            { return proto.CompactTextString(m) }
+func (*PlayCountRequest) ProtoMessage()               {}
+func (*PlayCountRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{48} }
+
+func (m *PlayCountRequest) GetLeastFirst() bool {
+	if m != nil {
+		return m.LeastFirst
+	}
+	return false
+}
+
+func (m *PlayCountRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type PlayCount struct {
+	Release    *godiscogs.Release `protobuf:"bytes,1,opt,name=release" json:"release,omitempty"`
+	Count      int32              `protobuf:"varint,2,opt,name=count" json:"count,omitempty"`
+	LastPlayed int64              `protobuf:"varint,3,opt,name=last_played,json=lastPlayed" json:"last_played,omitempty"`
+}
+
+func (m *PlayCount) Reset()                    { *m = PlayCount{} }
+func (m *PlayCount) String() string            { return proto.CompactTextString(m) }
+func (*PlayCount) ProtoMessage()               {}
+func (*PlayCount) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{49} }
+
+func (m *PlayCount) GetRelease() *godiscogs.Release {
+	if m != nil {
+		return m.Release
+	}
+	return nil
+}
+
+func (m *PlayCount) GetCount() int32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *PlayCount) GetLastPlayed() int64 {
+	if m != nil {
+		return m.LastPlayed
+	}
+	return 0
+}
+
+type PlayCountList struct {
+	Counts []*PlayCount `protobuf:"bytes,1,rep,name=counts" json:"counts,omitempty"`
+}
+
+func (m *PlayCountList) Reset()                    { *m = PlayCountList{} }
+func (m *PlayCountList) String() string            { return proto.CompactTextString(m) }
+func (*PlayCountList) ProtoMessage()               {}
+func (*PlayCountList) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{50} }
+
+func (m *PlayCountList) GetCounts() []*PlayCount {
+	if m != nil {
+		return m.Counts
+	}
+	return nil
+}
+
+type NeverPlayedRequest struct {
+	// Only return records we've owned for at least this many seconds
+	OwnedFor int64 `protobuf:"varint,1,opt,name=owned_for,json=ownedFor" json:"owned_for,omitempty"`
+}
+
+func (m *NeverPlayedRequest) Reset()                    { *m = NeverPlayedRequest{} }
+func (m *NeverPlayedRequest) String() string            { return proto.CompactTextString(m) }
+func (*NeverPlayedRequest) ProtoMessage()               {}
+func (*NeverPlayedRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{51} }
+
+func (m *NeverPlayedRequest) GetOwnedFor() int64 {
+	if m != nil {
+		return m.OwnedFor
+	}
+	return 0
+}
+
+type StreakRequest struct {
+	Listener string `protobuf:"bytes,1,opt,name=listener" json:"listener,omitempty"`
+}
+
+func (m *StreakRequest) Reset()                    { *m = StreakRequest{} }
+func (m *StreakRequest) String() string            { return proto.CompactTextString(m) }
+func (*StreakRequest) ProtoMessage()               {}
+func (*StreakRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{52} }
+
+func (m *StreakRequest) GetListener() string {
+	if m != nil {
+		return m.Listener
+	}
+	return ""
+}
+
+type StreakResponse struct {
+	// The run of consecutive days with a listen, ending today or yesterday
+	Current      int32 `protobuf:"varint,1,opt,name=current" json:"current,omitempty"`
+	Longest      int32 `protobuf:"varint,2,opt,name=longest" json:"longest,omitempty"`
+	LongestStart int64 `protobuf:"varint,3,opt,name=longest_start,json=longestStart" json:"longest_start,omitempty"`
+	LongestEnd   int64 `protobuf:"varint,4,opt,name=longest_end,json=longestEnd" json:"longest_end,omitempty"`
+}
+
+func (m *StreakResponse) Reset()                    { *m = StreakResponse{} }
+func (m *StreakResponse) String() string            { return proto.CompactTextString(m) }
+func (*StreakResponse) ProtoMessage()               {}
+func (*StreakResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{53} }
+
+func (m *StreakResponse) GetCurrent() int32 {
+	if m != nil {
+		return m.Current
+	}
+	return 0
+}
+
+func (m *StreakResponse) GetLongest() int32 {
+	if m != nil {
+		return m.Longest
+	}
+	return 0
+}
+
+func (m *StreakResponse) GetLongestStart() int64 {
+	if m != nil {
+		return m.LongestStart
+	}
+	return 0
+}
+
+func (m *StreakResponse) GetLongestEnd() int64 {
+	if m != nil {
+		return m.LongestEnd
+	}
+	return 0
+}
+
+type SuggestionRequest struct {
+	// The folders to pick from, the whole collection when unset
+	Folders  []int32            `protobuf:"varint,1,rep,packed,name=folders" json:"folders,omitempty"`
+	Strategy SuggestionStrategy `protobuf:"varint,2,opt,name=strategy,enum=discogsserver.SuggestionStrategy" json:"strategy,omitempty"`
+	// Only pick records in this genre or style
+	Genre string `protobuf:"bytes,3,opt,name=genre" json:"genre,omitempty"`
+	Style string `protobuf:"bytes,4,opt,name=style" json:"style,omitempty"`
+	// Don't repeat anything suggested in the last this many seconds
+	AvoidWindow int64 `protobuf:"varint,5,opt,name=avoid_window,json=avoidWindow" json:"avoid_window,omitempty"`
+}
+
+func (m *SuggestionRequest) Reset()                    { *m = SuggestionRequest{} }
+func (m *SuggestionRequest) String() string            { return proto.CompactTextString(m) }
+func (*SuggestionRequest) ProtoMessage()               {}
+func (*SuggestionRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{54} }
+
+func (m *SuggestionRequest) GetFolders() []int32 {
+	if m != nil {
+		return m.Folders
+	}
+	return nil
+}
+
+func (m *SuggestionRequest) GetStrategy() SuggestionStrategy {
+	if m != nil {
+		return m.Strategy
+	}
+	return SuggestionStrategy_LEAST_RECENTLY_SUGGESTED
+}
+
+func (m *SuggestionRequest) GetGenre() string {
+	if m != nil {
+		return m.Genre
+	}
+	return ""
+}
+
+func (m *SuggestionRequest) GetStyle() string {
+	if m != nil {
+		return m.Style
+	}
+	return ""
+}
+
+func (m *SuggestionRequest) GetAvoidWindow() int64 {
+	if m != nil {
+		return m.AvoidWindow
+	}
+	return 0
+}
+
+type Suggestion struct {
+	ReleaseId  int32 `protobuf:"varint,1,opt,name=release_id,json=releaseId" json:"release_id,omitempty"`
+	InstanceId int32 `protobuf:"varint,2,opt,name=instance_id,json=instanceId" json:"instance_id,omitempty"`
+	Date       int64 `protobuf:"varint,3,opt,name=date" json:"date,omitempty"`
+}
+
+func (m *Suggestion) Reset()                    { *m = Suggestion{} }
+func (m *Suggestion) String() string            { return proto.CompactTextString(m) }
+func (*Suggestion) ProtoMessage()               {}
+func (*Suggestion) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{55} }
+
+func (m *Suggestion) GetReleaseId() int32 {
+	if m != nil {
+		return m.ReleaseId
+	}
+	return 0
+}
+
+func (m *Suggestion) GetInstanceId() int32 {
+	if m != nil {
+		return m.InstanceId
+	}
+	return 0
+}
+
+func (m *Suggestion) GetDate() int64 {
+	if m != nil {
+		return m.Date
+	}
+	return 0
+}
+
+type Condition struct {
+	Field      SmartField `protobuf:"varint,1,opt,name=field,enum=discogsserver.SmartField" json:"field,omitempty"`
+	Comparison Comparison `protobuf:"varint,2,opt,name=comparison,enum=discogsserver.Comparison" json:"comparison,omitempty"`
+	Value      string     `protobuf:"bytes,3,opt,name=value" json:"value,omitempty"`
+}
+
+func (m *Condition) Reset()                    { *m = Condition{} }
+func (m *Condition) String() string            { return proto.CompactTextString(m) }
+func (*Condition) ProtoMessage()               {}
+func (*Condition) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{56} }
+
+func (m *Condition) GetField() SmartField {
+	if m != nil {
+		return m.Field
+	}
+	return SmartField_RATING
+}
+
+func (m *Condition) GetComparison() Comparison {
+	if m != nil {
+		return m.Comparison
+	}
+	return Comparison_EQUALS
+}
+
+func (m *Condition) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+type SmartFolder struct {
+	// Smart folders get negative ids so they never clash with discogs folders
+	Folder *godiscogs.Folder `protobuf:"bytes,1,opt,name=folder" json:"folder,omitempty"`
+	// Releases must meet all of these
+	Conditions []*Condition `protobuf:"bytes,2,rep,name=conditions" json:"conditions,omitempty"`
+}
+
+func (m *SmartFolder) Reset()                    { *m = SmartFolder{} }
+func (m *SmartFolder) String() string            { return proto.CompactTextString(m) }
+func (*SmartFolder) ProtoMessage()               {}
+func (*SmartFolder) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{57} }
+
+func (m *SmartFolder) GetFolder() *godiscogs.Folder {
+	if m != nil {
+		return m.Folder
+	}
+	return nil
+}
+
+func (m *SmartFolder) GetConditions() []*Condition {
+	if m != nil {
+		return m.Conditions
+	}
+	return nil
+}
+
+type SmartFolderList struct {
+	Folders []*SmartFolder `protobuf:"bytes,1,rep,name=folders" json:"folders,omitempty"`
+}
+
+func (m *SmartFolderList) Reset()                    { *m = SmartFolderList{} }
+func (m *SmartFolderList) String() string            { return proto.CompactTextString(m) }
+func (*SmartFolderList) ProtoMessage()               {}
+func (*SmartFolderList) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{58} }
+
+func (m *SmartFolderList) GetFolders() []*SmartFolder {
+	if m != nil {
+		return m.Folders
+	}
+	return nil
+}
+
+type FolderRule struct {
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	// Releases must meet all of these
+	Conditions []*Condition `protobuf:"bytes,2,rep,name=conditions" json:"conditions,omitempty"`
+	// Where matching releases get moved
+	FolderId int32 `protobuf:"varint,3,opt,name=folder_id,json=folderId" json:"folder_id,omitempty"`
+}
+
+func (m *FolderRule) Reset()                    { *m = FolderRule{} }
+func (m *FolderRule) String() string            { return proto.CompactTextString(m) }
+func (*FolderRule) ProtoMessage()               {}
+func (*FolderRule) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{59} }
+
+func (m *FolderRule) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *FolderRule) GetConditions() []*Condition {
+	if m != nil {
+		return m.Conditions
+	}
+	return nil
+}
+
+func (m *FolderRule) GetFolderId() int32 {
+	if m != nil {
+		return m.FolderId
+	}
+	return 0
+}
+
+type FolderRuleList struct {
+	Rules []*FolderRule `protobuf:"bytes,1,rep,name=rules" json:"rules,omitempty"`
+}
+
+func (m *FolderRuleList) Reset()                    { *m = FolderRuleList{} }
+func (m *FolderRuleList) String() string            { return proto.CompactTextString(m) }
+func (*FolderRuleList) ProtoMessage()               {}
+func (*FolderRuleList) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{60} }
+
+func (m *FolderRuleList) GetRules() []*FolderRule {
+	if m != nil {
+		return m.Rules
+	}
+	return nil
+}
+
+type RuleAudit struct {
+	Rule         string             `protobuf:"bytes,1,opt,name=rule" json:"rule,omitempty"`
+	Release      *godiscogs.Release `protobuf:"bytes,2,opt,name=release" json:"release,omitempty"`
+	FromFolderId int32              `protobuf:"varint,3,opt,name=from_folder_id,json=fromFolderId" json:"from_folder_id,omitempty"`
+	ToFolderId   int32              `protobuf:"varint,4,opt,name=to_folder_id,json=toFolderId" json:"to_folder_id,omitempty"`
+	Date         int64              `protobuf:"varint,5,opt,name=date" json:"date,omitempty"`
+	// Set if the move failed
+	Error string `protobuf:"bytes,6,opt,name=error" json:"error,omitempty"`
+}
+
+func (m *RuleAudit) Reset()                    { *m = RuleAudit{} }
+func (m *RuleAudit) String() string            { return proto.CompactTextString(m) }
+func (*RuleAudit) ProtoMessage()               {}
+func (*RuleAudit) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{61} }
+
+func (m *RuleAudit) GetRule() string {
+	if m != nil {
+		return m.Rule
+	}
+	return ""
+}
+
+func (m *RuleAudit) GetRelease() *godiscogs.Release {
+	if m != nil {
+		return m.Release
+	}
+	return nil
+}
+
+func (m *RuleAudit) GetFromFolderId() int32 {
+	if m != nil {
+		return m.FromFolderId
+	}
+	return 0
+}
+
+func (m *RuleAudit) GetToFolderId() int32 {
+	if m != nil {
+		return m.ToFolderId
+	}
+	return 0
+}
+
+func (m *RuleAudit) GetDate() int64 {
+	if m != nil {
+		return m.Date
+	}
+	return 0
+}
+
+func (m *RuleAudit) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type RuleAuditList struct {
+	Audits []*RuleAudit `protobuf:"bytes,1,rep,name=audits" json:"audits,omitempty"`
+}
+
+func (m *RuleAuditList) Reset()                    { *m = RuleAuditList{} }
+func (m *RuleAuditList) String() string            { return proto.CompactTextString(m) }
+func (*RuleAuditList) ProtoMessage()               {}
+func (*RuleAuditList) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{62} }
+
+func (m *RuleAuditList) GetAudits() []*RuleAudit {
+	if m != nil {
+		return m.Audits
+	}
+	return nil
+}
+
+type Loan struct {
+	ReleaseId int32 `protobuf:"varint,1,opt,name=release_id,json=releaseId" json:"release_id,omitempty"`
+	// The copy we lent, filled from the collection if unset
+	InstanceId int32  `protobuf:"varint,2,opt,name=instance_id,json=instanceId" json:"instance_id,omitempty"`
+	Borrower   string `protobuf:"bytes,3,opt,name=borrower" json:"borrower,omitempty"`
+	// When it went out, defaults to now
+	Lent int64 `protobuf:"varint,4,opt,name=lent" json:"lent,omitempty"`
+	// When we want it back, zero for no deadline
+	Due int64 `protobuf:"varint,5,opt,name=due" json:"due,omitempty"`
+	// When it came back, zero while it's still out
+	Returned int64 `protobuf:"varint,6,opt,name=returned" json:"returned,omitempty"`
+}
+
+func (m *Loan) Reset()                    { *m = Loan{} }
+func (m *Loan) String() string            { return proto.CompactTextString(m) }
+func (*Loan) ProtoMessage()               {}
+func (*Loan) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{63} }
+
+func (m *Loan) GetReleaseId() int32 {
+	if m != nil {
+		return m.ReleaseId
+	}
+	return 0
+}
+
+func (m *Loan) GetInstanceId() int32 {
+	if m != nil {
+		return m.InstanceId
+	}
+	return 0
+}
+
+func (m *Loan) GetBorrower() string {
+	if m != nil {
+		return m.Borrower
+	}
+	return ""
+}
+
+func (m *Loan) GetLent() int64 {
+	if m != nil {
+		return m.Lent
+	}
+	return 0
+}
+
+func (m *Loan) GetDue() int64 {
+	if m != nil {
+		return m.Due
+	}
+	return 0
+}
+
+func (m *Loan) GetReturned() int64 {
+	if m != nil {
+		return m.Returned
+	}
+	return 0
+}
+
+type LoanRequest struct {
+	// Restrict to loans of this release or instance
+	ReleaseId  int32  `protobuf:"varint,1,opt,name=release_id,json=releaseId" json:"release_id,omitempty"`
+	InstanceId int32  `protobuf:"varint,2,opt,name=instance_id,json=instanceId" json:"instance_id,omitempty"`
+	Borrower   string `protobuf:"bytes,3,opt,name=borrower" json:"borrower,omitempty"`
+	// Only return loans past their due date
+	OverdueOnly bool `protobuf:"varint,4,opt,name=overdue_only,json=overdueOnly" json:"overdue_only,omitempty"`
+	// Include loans which have come back
+	IncludeReturned bool `protobuf:"varint,5,opt,name=include_returned,json=includeReturned" json:"include_returned,omitempty"`
+}
+
+func (m *LoanRequest) Reset()                    { *m = LoanRequest{} }
+func (m *LoanRequest) String() string            { return proto.CompactTextString(m) }
+func (*LoanRequest) ProtoMessage()               {}
+func (*LoanRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{64} }
+
+func (m *LoanRequest) GetReleaseId() int32 {
+	if m != nil {
+		return m.ReleaseId
+	}
+	return 0
+}
+
+func (m *LoanRequest) GetInstanceId() int32 {
+	if m != nil {
+		return m.InstanceId
+	}
+	return 0
+}
+
+func (m *LoanRequest) GetBorrower() string {
+	if m != nil {
+		return m.Borrower
+	}
+	return ""
+}
+
+func (m *LoanRequest) GetOverdueOnly() bool {
+	if m != nil {
+		return m.OverdueOnly
+	}
+	return false
+}
+
+func (m *LoanRequest) GetIncludeReturned() bool {
+	if m != nil {
+		return m.IncludeReturned
+	}
+	return false
+}
+
+type LoanList struct {
+	Loans []*Loan `protobuf:"bytes,1,rep,name=loans" json:"loans,omitempty"`
+}
+
+func (m *LoanList) Reset()                    { *m = LoanList{} }
+func (m *LoanList) String() string            { return proto.CompactTextString(m) }
+func (*LoanList) ProtoMessage()               {}
+func (*LoanList) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{65} }
+
+func (m *LoanList) GetLoans() []*Loan {
+	if m != nil {
+		return m.Loans
+	}
+	return nil
+}
+
+type ExportRequest struct {
+	// The columns to export, defaults to every release and metadata column
+	// followed by the custom fields
+	Columns []string `protobuf:"bytes,1,rep,name=columns" json:"columns,omitempty"`
+	// The Go time layout for date columns, defaults to 2006-01-02
+	DateFormat string `protobuf:"bytes,2,opt,name=date_format,json=dateFormat" json:"date_format,omitempty"`
+}
+
+func (m *ExportRequest) Reset()                    { *m = ExportRequest{} }
+func (m *ExportRequest) String() string            { return proto.CompactTextString(m) }
+func (*ExportRequest) ProtoMessage()               {}
+func (*ExportRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{66} }
+
+func (m *ExportRequest) GetColumns() []string {
+	if m != nil {
+		return m.Columns
+	}
+	return nil
+}
+
+func (m *ExportRequest) GetDateFormat() string {
+	if m != nil {
+		return m.DateFormat
+	}
+	return ""
+}
+
+type ExportRow struct {
+	// A single CSV encoded line including its newline, the first row is the header
+	Line string `protobuf:"bytes,1,opt,name=line" json:"line,omitempty"`
+}
+
+func (m *ExportRow) Reset()                    { *m = ExportRow{} }
+func (m *ExportRow) String() string            { return proto.CompactTextString(m) }
+func (*ExportRow) ProtoMessage()               {}
+func (*ExportRow) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{67} }
+
+func (m *ExportRow) GetLine() string {
+	if m != nil {
+		return m.Line
+	}
+	return ""
+}
+
+type DiscogsImportRequest struct {
+	// The contents of the collection CSV export from discogs
+	Csv string `protobuf:"bytes,1,opt,name=csv" json:"csv,omitempty"`
+	// Report what would change without changing anything
+	DryRun bool `protobuf:"varint,2,opt,name=dry_run,json=dryRun" json:"dry_run,omitempty"`
+}
+
+func (m *DiscogsImportRequest) Reset()                    { *m = DiscogsImportRequest{} }
+func (m *DiscogsImportRequest) String() string            { return proto.CompactTextString(m) }
+func (*DiscogsImportRequest) ProtoMessage()               {}
+func (*DiscogsImportRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{68} }
+
+func (m *DiscogsImportRequest) GetCsv() string {
+	if m != nil {
+		return m.Csv
+	}
+	return ""
+}
+
+func (m *DiscogsImportRequest) GetDryRun() bool {
+	if m != nil {
+		return m.DryRun
+	}
+	return false
+}
+
+type ImportChange struct {
+	ReleaseId  int32  `protobuf:"varint,1,opt,name=release_id,json=releaseId" json:"release_id,omitempty"`
+	InstanceId int32  `protobuf:"varint,2,opt,name=instance_id,json=instanceId" json:"instance_id,omitempty"`
+	Field      string `protobuf:"bytes,3,opt,name=field" json:"field,omitempty"`
+	OldValue   string `protobuf:"bytes,4,opt,name=old_value,json=oldValue" json:"old_value,omitempty"`
+	NewValue   string `protobuf:"bytes,5,opt,name=new_value,json=newValue" json:"new_value,omitempty"`
+}
+
+func (m *ImportChange) Reset()                    { *m = ImportChange{} }
+func (m *ImportChange) String() string            { return proto.CompactTextString(m) }
+func (*ImportChange) ProtoMessage()               {}
+func (*ImportChange) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{69} }
+
+func (m *ImportChange) GetReleaseId() int32 {
+	if m != nil {
+		return m.ReleaseId
+	}
+	return 0
+}
+
+func (m *ImportChange) GetInstanceId() int32 {
+	if m != nil {
+		return m.InstanceId
+	}
+	return 0
+}
+
+func (m *ImportChange) GetField() string {
+	if m != nil {
+		return m.Field
+	}
+	return ""
+}
+
+func (m *ImportChange) GetOldValue() string {
+	if m != nil {
+		return m.OldValue
+	}
+	return ""
+}
+
+func (m *ImportChange) GetNewValue() string {
+	if m != nil {
+		return m.NewValue
+	}
+	return ""
+}
+
+type ImportReport struct {
+	Changes []*ImportChange `protobuf:"bytes,1,rep,name=changes" json:"changes,omitempty"`
+	// Rows or changes we couldn't handle
+	Errors []string `protobuf:"bytes,2,rep,name=errors" json:"errors,omitempty"`
+}
+
+func (m *ImportReport) Reset()                    { *m = ImportReport{} }
+func (m *ImportReport) String() string            { return proto.CompactTextString(m) }
+func (*ImportReport) ProtoMessage()               {}
+func (*ImportReport) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{70} }
+
+func (m *ImportReport) GetChanges() []*ImportChange {
+	if m != nil {
+		return m.Changes
+	}
+	return nil
+}
+
+func (m *ImportReport) GetErrors() []string {
+	if m != nil {
+		return m.Errors
+	}
+	return nil
+}
+
+type BackupRequest struct {
+	Format BackupFormat `protobuf:"varint,1,opt,name=format,enum=discogsserver.BackupFormat" json:"format,omitempty"`
+}
+
+func (m *BackupRequest) Reset()                    { *m = BackupRequest{} }
+func (m *BackupRequest) String() string            { return proto.CompactTextString(m) }
+func (*BackupRequest) ProtoMessage()               {}
+func (*BackupRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{71} }
+
+func (m *BackupRequest) GetFormat() BackupFormat {
+	if m != nil {
+		return m.Format
+	}
+	return BackupFormat_JSON
+}
+
+type RestoreRequest struct {
+	Data   string         `protobuf:"bytes,1,opt,name=data" json:"data,omitempty"`
+	Format BackupFormat   `protobuf:"varint,2,opt,name=format,enum=discogsserver.BackupFormat" json:"format,omitempty"`
+	Policy ConflictPolicy `protobuf:"varint,3,opt,name=policy,enum=discogsserver.ConflictPolicy" json:"policy,omitempty"`
+	// Report what would change without changing anything
+	DryRun bool `protobuf:"varint,4,opt,name=dry_run,json=dryRun" json:"dry_run,omitempty"`
+}
+
+func (m *RestoreRequest) Reset()                    { *m = RestoreRequest{} }
+func (m *RestoreRequest) String() string            { return proto.CompactTextString(m) }
+func (*RestoreRequest) ProtoMessage()               {}
+func (*RestoreRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{72} }
+
+func (m *RestoreRequest) GetData() string {
+	if m != nil {
+		return m.Data
+	}
+	return ""
+}
+
+func (m *RestoreRequest) GetFormat() BackupFormat {
+	if m != nil {
+		return m.Format
+	}
+	return BackupFormat_JSON
+}
+
+func (m *RestoreRequest) GetPolicy() ConflictPolicy {
+	if m != nil {
+		return m.Policy
+	}
+	return ConflictPolicy_SKIP
+}
+
+func (m *RestoreRequest) GetDryRun() bool {
+	if m != nil {
+		return m.DryRun
+	}
+	return false
+}
+
+type RestoreChange struct {
+	// What sort of thing changed, e.g. release or want
+	Kind string `protobuf:"bytes,1,opt,name=kind" json:"kind,omitempty"`
+	// Which one changed, e.g. release_id/instance_id for releases
+	Key string `protobuf:"bytes,2,opt,name=key" json:"key,omitempty"`
+	// One of added, overwritten, merged or skipped
+	Action string `protobuf:"bytes,3,opt,name=action" json:"action,omitempty"`
+}
+
+func (m *RestoreChange) Reset()                    { *m = RestoreChange{} }
+func (m *RestoreChange) String() string            { return proto.CompactTextString(m) }
+func (*RestoreChange) ProtoMessage()               {}
+func (*RestoreChange) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{73} }
+
+func (m *RestoreChange) GetKind() string {
+	if m != nil {
+		return m.Kind
+	}
+	return ""
+}
+
+func (m *RestoreChange) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *RestoreChange) GetAction() string {
+	if m != nil {
+		return m.Action
+	}
+	return ""
+}
+
+type RestoreReport struct {
+	Changes []*RestoreChange `protobuf:"bytes,1,rep,name=changes" json:"changes,omitempty"`
+}
+
+func (m *RestoreReport) Reset()                    { *m = RestoreReport{} }
+func (m *RestoreReport) String() string            { return proto.CompactTextString(m) }
+func (*RestoreReport) ProtoMessage()               {}
+func (*RestoreReport) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{74} }
+
+func (m *RestoreReport) GetChanges() []*RestoreChange {
+	if m != nil {
+		return m.Changes
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*Token)(nil), "discogsserver.Token")
+	proto.RegisterType((*RecordCollection)(nil), "discogsserver.RecordCollection")
+	proto.RegisterType((*CollectionFolder)(nil), "discogsserver.CollectionFolder")
+	proto.RegisterType((*ReleaseMetadata)(nil), "discogsserver.ReleaseMetadata")
+	proto.RegisterType((*ShelfPosition)(nil), "discogsserver.ShelfPosition")
+	proto.RegisterType((*OrderRequest)(nil), "discogsserver.OrderRequest")
+	proto.RegisterType((*ShelfEntry)(nil), "discogsserver.ShelfEntry")
+	proto.RegisterType((*ShelfList)(nil), "discogsserver.ShelfList")
+	proto.RegisterType((*InsertionRequest)(nil), "discogsserver.InsertionRequest")
+	proto.RegisterType((*InsertionResponse)(nil), "discogsserver.InsertionResponse")
+	proto.RegisterType((*Tag)(nil), "discogsserver.Tag")
+	proto.RegisterType((*TagRequest)(nil), "discogsserver.TagRequest")
+	proto.RegisterType((*TagQuery)(nil), "discogsserver.TagQuery")
+	proto.RegisterType((*TagRename)(nil), "discogsserver.TagRename")
+	proto.RegisterType((*TagCount)(nil), "discogsserver.TagCount")
+	proto.RegisterType((*TagCountList)(nil), "discogsserver.TagCountList")
+	proto.RegisterType((*FieldDefinition)(nil), "discogsserver.FieldDefinition")
+	proto.RegisterType((*FieldSchema)(nil), "discogsserver.FieldSchema")
+	proto.RegisterType((*CustomField)(nil), "discogsserver.CustomField")
+	proto.RegisterType((*Record)(nil), "discogsserver.Record")
+	proto.RegisterType((*Empty)(nil), "discogsserver.Empty")
+	proto.RegisterType((*FolderList)(nil), "discogsserver.FolderList")
+	proto.RegisterType((*ReleaseList)(nil), "discogsserver.ReleaseList")
+	proto.RegisterType((*RecordList)(nil), "discogsserver.RecordList")
+	proto.RegisterType((*ReleaseMove)(nil), "discogsserver.ReleaseMove")
+	proto.RegisterType((*FolderDelete)(nil), "discogsserver.FolderDelete")
+	proto.RegisterType((*MetadataUpdate)(nil), "discogsserver.MetadataUpdate")
+	proto.RegisterType((*BulkMoveRequest)(nil), "discogsserver.BulkMoveRequest")
+	proto.RegisterType((*BulkMetadataRequest)(nil), "discogsserver.BulkMetadataRequest")
+	proto.RegisterType((*OperationResult)(nil), "discogsserver.OperationResult")
+	proto.RegisterType((*BulkResponse)(nil), "discogsserver.BulkResponse")
+	proto.RegisterType((*Want)(nil), "discogsserver.Want")
+	proto.RegisterType((*WantlistRequest)(nil), "discogsserver.WantlistRequest")
+	proto.RegisterType((*CollapseRequest)(nil), "discogsserver.CollapseRequest")
+	proto.RegisterType((*Wantlist)(nil), "discogsserver.Wantlist")
+	proto.RegisterType((*SpendRequest)(nil), "discogsserver.SpendRequest")
+	proto.RegisterType((*SpendResponse)(nil), "discogsserver.SpendResponse")
+	proto.RegisterType((*SearchRequest)(nil), "discogsserver.SearchRequest")
+	proto.RegisterType((*SyncResult)(nil), "discogsserver.SyncResult")
+	proto.RegisterType((*SellCandidatesRequest)(nil), "discogsserver.SellCandidatesRequest")
+	proto.RegisterType((*SellCandidate)(nil), "discogsserver.SellCandidate")
+	proto.RegisterType((*SellCandidateList)(nil), "discogsserver.SellCandidateList")
+	proto.RegisterType((*BulkSellRequest)(nil), "discogsserver.BulkSellRequest")
+	proto.RegisterType((*SellResult)(nil), "discogsserver.SellResult")
+	proto.RegisterType((*BulkSellResponse)(nil), "discogsserver.BulkSellResponse")
+	proto.RegisterType((*Play)(nil), "discogsserver.Play")
+	proto.RegisterType((*PlayList)(nil), "discogsserver.PlayList")
+	proto.RegisterType((*PlayRequest)(nil), "discogsserver.PlayRequest")
+	proto.RegisterType((*PlayCountRequest)(nil), "discogsserver.PlayCountRequest")
+	proto.RegisterType((*PlayCount)(nil), "discogsserver.PlayCount")
+	proto.RegisterType((*PlayCountList)(nil), "discogsserver.PlayCountList")
+	proto.RegisterType((*NeverPlayedRequest)(nil), "discogsserver.NeverPlayedRequest")
+	proto.RegisterType((*StreakRequest)(nil), "discogsserver.StreakRequest")
+	proto.RegisterType((*StreakResponse)(nil), "discogsserver.StreakResponse")
+	proto.RegisterType((*SuggestionRequest)(nil), "discogsserver.SuggestionRequest")
+	proto.RegisterType((*Suggestion)(nil), "discogsserver.Suggestion")
+	proto.RegisterType((*Condition)(nil), "discogsserver.Condition")
+	proto.RegisterType((*SmartFolder)(nil), "discogsserver.SmartFolder")
+	proto.RegisterType((*SmartFolderList)(nil), "discogsserver.SmartFolderList")
+	proto.RegisterType((*FolderRule)(nil), "discogsserver.FolderRule")
+	proto.RegisterType((*FolderRuleList)(nil), "discogsserver.FolderRuleList")
+	proto.RegisterType((*RuleAudit)(nil), "discogsserver.RuleAudit")
+	proto.RegisterType((*RuleAuditList)(nil), "discogsserver.RuleAuditList")
+	proto.RegisterType((*Loan)(nil), "discogsserver.Loan")
+	proto.RegisterType((*LoanRequest)(nil), "discogsserver.LoanRequest")
+	proto.RegisterType((*LoanList)(nil), "discogsserver.LoanList")
+	proto.RegisterType((*ExportRequest)(nil), "discogsserver.ExportRequest")
+	proto.RegisterType((*ExportRow)(nil), "discogsserver.ExportRow")
+	proto.RegisterType((*DiscogsImportRequest)(nil), "discogsserver.DiscogsImportRequest")
+	proto.RegisterType((*ImportChange)(nil), "discogsserver.ImportChange")
+	proto.RegisterType((*ImportReport)(nil), "discogsserver.ImportReport")
+	proto.RegisterType((*BackupRequest)(nil), "discogsserver.BackupRequest")
+	proto.RegisterType((*RestoreRequest)(nil), "discogsserver.RestoreRequest")
+	proto.RegisterType((*RestoreChange)(nil), "discogsserver.RestoreChange")
+	proto.RegisterType((*RestoreReport)(nil), "discogsserver.RestoreReport")
+	proto.RegisterEnum("discogsserver.ShelfOrder", ShelfOrder_name, ShelfOrder_value)
+	proto.RegisterEnum("discogsserver.FieldType", FieldType_name, FieldType_value)
+	proto.RegisterEnum("discogsserver.WantSort", WantSort_name, WantSort_value)
+	proto.RegisterEnum("discogsserver.SuggestionStrategy", SuggestionStrategy_name, SuggestionStrategy_value)
+	proto.RegisterEnum("discogsserver.SmartField", SmartField_name, SmartField_value)
+	proto.RegisterEnum("discogsserver.Comparison", Comparison_name, Comparison_value)
+	proto.RegisterEnum("discogsserver.BackupFormat", BackupFormat_name, BackupFormat_value)
+	proto.RegisterEnum("discogsserver.ConflictPolicy", ConflictPolicy_name, ConflictPolicy_value)
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for DiscogsService service
+
+type DiscogsServiceClient interface {
+	GetCollection(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ReleaseList, error)
+	GetReleasesInFolder(ctx context.Context, in *FolderList, opts ...grpc.CallOption) (*RecordList, error)
+	MoveToFolder(ctx context.Context, in *ReleaseMove, opts ...grpc.CallOption) (*Empty, error)
+	AddToFolder(ctx context.Context, in *ReleaseMove, opts ...grpc.CallOption) (*Empty, error)
+	UpdateMetadata(ctx context.Context, in *MetadataUpdate, opts ...grpc.CallOption) (*ReleaseMetadata, error)
+	GetMetadata(ctx context.Context, in *godiscogs.Release, opts ...grpc.CallOption) (*ReleaseMetadata, error)
+	UpdateRating(ctx context.Context, in *godiscogs.Release, opts ...grpc.CallOption) (*Empty, error)
+	GetSingleRelease(ctx context.Context, in *godiscogs.Release, opts ...grpc.CallOption) (*godiscogs.Release, error)
+	GetWantlist(ctx context.Context, in *WantlistRequest, opts ...grpc.CallOption) (*Wantlist, error)
+	CollapseWantlist(ctx context.Context, in *CollapseRequest, opts ...grpc.CallOption) (*Wantlist, error)
+	RebuildWantlist(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Wantlist, error)
+	GetSpend(ctx context.Context, in *SpendRequest, opts ...grpc.CallOption) (*SpendResponse, error)
+	EditWant(ctx context.Context, in *Want, opts ...grpc.CallOption) (*Want, error)
+	DeleteWant(ctx context.Context, in *Want, opts ...grpc.CallOption) (*Wantlist, error)
+	AddWant(ctx context.Context, in *Want, opts ...grpc.CallOption) (*Empty, error)
+	ExpandWant(ctx context.Context, in *Want, opts ...grpc.CallOption) (*Wantlist, error)
+	SyncWithDiscogs(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*SyncResult, error)
+	DeleteInstance(ctx context.Context, in *godiscogs.Release, opts ...grpc.CallOption) (*Empty, error)
+	Sell(ctx context.Context, in *godiscogs.Release, opts ...grpc.CallOption) (*Empty, error)
+	GetIncompleteReleases(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ReleaseList, error)
+	SellCandidates(ctx context.Context, in *SellCandidatesRequest, opts ...grpc.CallOption) (*SellCandidateList, error)
+	BulkSell(ctx context.Context, in *BulkSellRequest, opts ...grpc.CallOption) (*BulkSellResponse, error)
+	RecordPlay(ctx context.Context, in *Play, opts ...grpc.CallOption) (*Play, error)
+	GetPlays(ctx context.Context, in *PlayRequest, opts ...grpc.CallOption) (*PlayList, error)
+	GetPlayCounts(ctx context.Context, in *PlayCountRequest, opts ...grpc.CallOption) (*PlayCountList, error)
+	GetNeverPlayed(ctx context.Context, in *NeverPlayedRequest, opts ...grpc.CallOption) (*ReleaseList, error)
+	GetStreaks(ctx context.Context, in *StreakRequest, opts ...grpc.CallOption) (*StreakResponse, error)
+	GetListeningSuggestion(ctx context.Context, in *SuggestionRequest, opts ...grpc.CallOption) (*Record, error)
+	AddField(ctx context.Context, in *FieldDefinition, opts ...grpc.CallOption) (*FieldSchema, error)
+	DeleteField(ctx context.Context, in *FieldDefinition, opts ...grpc.CallOption) (*FieldSchema, error)
+	GetFields(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*FieldSchema, error)
+	AddTags(ctx context.Context, in *TagRequest, opts ...grpc.CallOption) (*ReleaseMetadata, error)
+	RemoveTags(ctx context.Context, in *TagRequest, opts ...grpc.CallOption) (*ReleaseMetadata, error)
+	ListTags(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*TagCountList, error)
+	GetReleasesByTag(ctx context.Context, in *TagQuery, opts ...grpc.CallOption) (*ReleaseList, error)
+	RenameTag(ctx context.Context, in *TagRename, opts ...grpc.CallOption) (*TagCountList, error)
+	AddSmartFolder(ctx context.Context, in *SmartFolder, opts ...grpc.CallOption) (*SmartFolder, error)
+	UpdateSmartFolder(ctx context.Context, in *SmartFolder, opts ...grpc.CallOption) (*SmartFolder, error)
+	DeleteSmartFolder(ctx context.Context, in *godiscogs.Folder, opts ...grpc.CallOption) (*Empty, error)
+	GetSmartFolders(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*SmartFolderList, error)
+	CreateFolder(ctx context.Context, in *godiscogs.Folder, opts ...grpc.CallOption) (*godiscogs.Folder, error)
+	RenameFolder(ctx context.Context, in *godiscogs.Folder, opts ...grpc.CallOption) (*godiscogs.Folder, error)
+	DeleteFolder(ctx context.Context, in *FolderDelete, opts ...grpc.CallOption) (*Empty, error)
+	BulkMove(ctx context.Context, in *BulkMoveRequest, opts ...grpc.CallOption) (*BulkResponse, error)
+	BulkUpdateMetadata(ctx context.Context, in *BulkMetadataRequest, opts ...grpc.CallOption) (*BulkResponse, error)
+	AddFolderRule(ctx context.Context, in *FolderRule, opts ...grpc.CallOption) (*FolderRuleList, error)
+	DeleteFolderRule(ctx context.Context, in *FolderRule, opts ...grpc.CallOption) (*FolderRuleList, error)
+	GetFolderRules(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*FolderRuleList, error)
+	PreviewFolderRules(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*RuleAuditList, error)
+	GetRuleAudit(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*RuleAuditList, error)
+	SetShelfPosition(ctx context.Context, in *ShelfPosition, opts ...grpc.CallOption) (*ReleaseMetadata, error)
+	OrderFolder(ctx context.Context, in *OrderRequest, opts ...grpc.CallOption) (*ShelfList, error)
+	GetInsertionPoint(ctx context.Context, in *InsertionRequest, opts ...grpc.CallOption) (*InsertionResponse, error)
+	LendRecord(ctx context.Context, in *Loan, opts ...grpc.CallOption) (*Loan, error)
+	ReturnRecord(ctx context.Context, in *Loan, opts ...grpc.CallOption) (*Loan, error)
+	ListLoans(ctx context.Context, in *LoanRequest, opts ...grpc.CallOption) (*LoanList, error)
+	ExportCollection(ctx context.Context, in *ExportRequest, opts ...grpc.CallOption) (DiscogsService_ExportCollectionClient, error)
+	ImportDiscogsCSV(ctx context.Context, in *DiscogsImportRequest, opts ...grpc.CallOption) (*ImportReport, error)
+	BackupCollection(ctx context.Context, in *BackupRequest, opts ...grpc.CallOption) (DiscogsService_BackupCollectionClient, error)
+	RestoreCollection(ctx context.Context, in *RestoreRequest, opts ...grpc.CallOption) (*RestoreReport, error)
+	Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*ReleaseList, error)
+}
+
+type discogsServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewDiscogsServiceClient(cc *grpc.ClientConn) DiscogsServiceClient {
+	return &discogsServiceClient{cc}
+}
+
+func (c *discogsServiceClient) GetCollection(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ReleaseList, error) {
+	out := new(ReleaseList)
+	err := grpc.Invoke(ctx, "/discogsserver.DiscogsService/GetCollection", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *discogsServiceClient) GetReleasesInFolder(ctx context.Context, in *FolderList, opts ...grpc.CallOption) (*RecordList, error) {
+	out := new(RecordList)
+	err := grpc.Invoke(ctx, "/discogsserver.DiscogsService/GetReleasesInFolder", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *discogsServiceClient) MoveToFolder(ctx context.Context, in *ReleaseMove, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/discogsserver.DiscogsService/MoveToFolder", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *discogsServiceClient) AddToFolder(ctx context.Context, in *ReleaseMove, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/discogsserver.DiscogsService/AddToFolder", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *discogsServiceClient) UpdateMetadata(ctx context.Context, in *MetadataUpdate, opts ...grpc.CallOption) (*ReleaseMetadata, error) {
+	out := new(ReleaseMetadata)
+	err := grpc.Invoke(ctx, "/discogsserver.DiscogsService/UpdateMetadata", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *discogsServiceClient) GetMetadata(ctx context.Context, in *godiscogs.Release, opts ...grpc.CallOption) (*ReleaseMetadata, error) {
+	out := new(ReleaseMetadata)
+	err := grpc.Invoke(ctx, "/discogsserver.DiscogsService/GetMetadata", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *discogsServiceClient) UpdateRating(ctx context.Context, in *godiscogs.Release, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/discogsserver.DiscogsService/UpdateRating", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *discogsServiceClient) GetSingleRelease(ctx context.Context, in *godiscogs.Release, opts ...grpc.CallOption) (*godiscogs.Release, error) {
+	out := new(godiscogs.Release)
+	err := grpc.Invoke(ctx, "/discogsserver.DiscogsService/GetSingleRelease", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *discogsServiceClient) GetWantlist(ctx context.Context, in *WantlistRequest, opts ...grpc.CallOption) (*Wantlist, error) {
+	out := new(Wantlist)
+	err := grpc.Invoke(ctx, "/discogsserver.DiscogsService/GetWantlist", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *discogsServiceClient) CollapseWantlist(ctx context.Context, in *CollapseRequest, opts ...grpc.CallOption) (*Wantlist, error) {
+	out := new(Wantlist)
+	err := grpc.Invoke(ctx, "/discogsserver.DiscogsService/CollapseWantlist", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *discogsServiceClient) RebuildWantlist(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Wantlist, error) {
+	out := new(Wantlist)
+	err := grpc.Invoke(ctx, "/discogsserver.DiscogsService/RebuildWantlist", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *discogsServiceClient) GetSpend(ctx context.Context, in *SpendRequest, opts ...grpc.CallOption) (*SpendResponse, error) {
+	out := new(SpendResponse)
+	err := grpc.Invoke(ctx, "/discogsserver.DiscogsService/GetSpend", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *discogsServiceClient) EditWant(ctx context.Context, in *Want, opts ...grpc.CallOption) (*Want, error) {
+	out := new(Want)
+	err := grpc.Invoke(ctx, "/discogsserver.DiscogsService/EditWant", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *discogsServiceClient) DeleteWant(ctx context.Context, in *Want, opts ...grpc.CallOption) (*Wantlist, error) {
+	out := new(Wantlist)
+	err := grpc.Invoke(ctx, "/discogsserver.DiscogsService/DeleteWant", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *discogsServiceClient) AddWant(ctx context.Context, in *Want, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/discogsserver.DiscogsService/AddWant", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *discogsServiceClient) ExpandWant(ctx context.Context, in *Want, opts ...grpc.CallOption) (*Wantlist, error) {
+	out := new(Wantlist)
+	err := grpc.Invoke(ctx, "/discogsserver.DiscogsService/ExpandWant", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *discogsServiceClient) SyncWithDiscogs(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*SyncResult, error) {
+	out := new(SyncResult)
+	err := grpc.Invoke(ctx, "/discogsserver.DiscogsService/SyncWithDiscogs", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *discogsServiceClient) DeleteInstance(ctx context.Context, in *godiscogs.Release, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/discogsserver.DiscogsService/DeleteInstance", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
 func (c *discogsServiceClient) Sell(ctx context.Context, in *godiscogs.Release, opts ...grpc.CallOption) (*Empty, error) {
 	out := new(Empty)
-	err := grpc.Invoke(ctx, "/discogsserver.DiscogsService/Sell", in, out, c.cc, opts...)
+	err := grpc.Invoke(ctx, "/discogsserver.DiscogsService/Sell", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *discogsServiceClient) GetIncompleteReleases(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ReleaseList, error) {
+	out := new(ReleaseList)
+	err := grpc.Invoke(ctx, "/discogsserver.DiscogsService/GetIncompleteReleases", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *discogsServiceClient) SellCandidates(ctx context.Context, in *SellCandidatesRequest, opts ...grpc.CallOption) (*SellCandidateList, error) {
+	out := new(SellCandidateList)
+	err := grpc.Invoke(ctx, "/discogsserver.DiscogsService/SellCandidates", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *discogsServiceClient) BulkSell(ctx context.Context, in *BulkSellRequest, opts ...grpc.CallOption) (*BulkSellResponse, error) {
+	out := new(BulkSellResponse)
+	err := grpc.Invoke(ctx, "/discogsserver.DiscogsService/BulkSell", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *discogsServiceClient) RecordPlay(ctx context.Context, in *Play, opts ...grpc.CallOption) (*Play, error) {
+	out := new(Play)
+	err := grpc.Invoke(ctx, "/discogsserver.DiscogsService/RecordPlay", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *discogsServiceClient) GetPlays(ctx context.Context, in *PlayRequest, opts ...grpc.CallOption) (*PlayList, error) {
+	out := new(PlayList)
+	err := grpc.Invoke(ctx, "/discogsserver.DiscogsService/GetPlays", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *discogsServiceClient) GetPlayCounts(ctx context.Context, in *PlayCountRequest, opts ...grpc.CallOption) (*PlayCountList, error) {
+	out := new(PlayCountList)
+	err := grpc.Invoke(ctx, "/discogsserver.DiscogsService/GetPlayCounts", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *discogsServiceClient) GetNeverPlayed(ctx context.Context, in *NeverPlayedRequest, opts ...grpc.CallOption) (*ReleaseList, error) {
+	out := new(ReleaseList)
+	err := grpc.Invoke(ctx, "/discogsserver.DiscogsService/GetNeverPlayed", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *discogsServiceClient) GetStreaks(ctx context.Context, in *StreakRequest, opts ...grpc.CallOption) (*StreakResponse, error) {
+	out := new(StreakResponse)
+	err := grpc.Invoke(ctx, "/discogsserver.DiscogsService/GetStreaks", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *discogsServiceClient) GetListeningSuggestion(ctx context.Context, in *SuggestionRequest, opts ...grpc.CallOption) (*Record, error) {
+	out := new(Record)
+	err := grpc.Invoke(ctx, "/discogsserver.DiscogsService/GetListeningSuggestion", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *discogsServiceClient) AddField(ctx context.Context, in *FieldDefinition, opts ...grpc.CallOption) (*FieldSchema, error) {
+	out := new(FieldSchema)
+	err := grpc.Invoke(ctx, "/discogsserver.DiscogsService/AddField", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *discogsServiceClient) DeleteField(ctx context.Context, in *FieldDefinition, opts ...grpc.CallOption) (*FieldSchema, error) {
+	out := new(FieldSchema)
+	err := grpc.Invoke(ctx, "/discogsserver.DiscogsService/DeleteField", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *discogsServiceClient) GetFields(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*FieldSchema, error) {
+	out := new(FieldSchema)
+	err := grpc.Invoke(ctx, "/discogsserver.DiscogsService/GetFields", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *discogsServiceClient) AddTags(ctx context.Context, in *TagRequest, opts ...grpc.CallOption) (*ReleaseMetadata, error) {
+	out := new(ReleaseMetadata)
+	err := grpc.Invoke(ctx, "/discogsserver.DiscogsService/AddTags", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *discogsServiceClient) RemoveTags(ctx context.Context, in *TagRequest, opts ...grpc.CallOption) (*ReleaseMetadata, error) {
+	out := new(ReleaseMetadata)
+	err := grpc.Invoke(ctx, "/discogsserver.DiscogsService/RemoveTags", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *discogsServiceClient) ListTags(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*TagCountList, error) {
+	out := new(TagCountList)
+	err := grpc.Invoke(ctx, "/discogsserver.DiscogsService/ListTags", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *discogsServiceClient) GetReleasesByTag(ctx context.Context, in *TagQuery, opts ...grpc.CallOption) (*ReleaseList, error) {
+	out := new(ReleaseList)
+	err := grpc.Invoke(ctx, "/discogsserver.DiscogsService/GetReleasesByTag", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *discogsServiceClient) RenameTag(ctx context.Context, in *TagRename, opts ...grpc.CallOption) (*TagCountList, error) {
+	out := new(TagCountList)
+	err := grpc.Invoke(ctx, "/discogsserver.DiscogsService/RenameTag", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *discogsServiceClient) AddSmartFolder(ctx context.Context, in *SmartFolder, opts ...grpc.CallOption) (*SmartFolder, error) {
+	out := new(SmartFolder)
+	err := grpc.Invoke(ctx, "/discogsserver.DiscogsService/AddSmartFolder", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *discogsServiceClient) UpdateSmartFolder(ctx context.Context, in *SmartFolder, opts ...grpc.CallOption) (*SmartFolder, error) {
+	out := new(SmartFolder)
+	err := grpc.Invoke(ctx, "/discogsserver.DiscogsService/UpdateSmartFolder", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *discogsServiceClient) DeleteSmartFolder(ctx context.Context, in *godiscogs.Folder, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/discogsserver.DiscogsService/DeleteSmartFolder", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *discogsServiceClient) GetSmartFolders(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*SmartFolderList, error) {
+	out := new(SmartFolderList)
+	err := grpc.Invoke(ctx, "/discogsserver.DiscogsService/GetSmartFolders", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *discogsServiceClient) CreateFolder(ctx context.Context, in *godiscogs.Folder, opts ...grpc.CallOption) (*godiscogs.Folder, error) {
+	out := new(godiscogs.Folder)
+	err := grpc.Invoke(ctx, "/discogsserver.DiscogsService/CreateFolder", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *discogsServiceClient) RenameFolder(ctx context.Context, in *godiscogs.Folder, opts ...grpc.CallOption) (*godiscogs.Folder, error) {
+	out := new(godiscogs.Folder)
+	err := grpc.Invoke(ctx, "/discogsserver.DiscogsService/RenameFolder", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *discogsServiceClient) DeleteFolder(ctx context.Context, in *FolderDelete, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/discogsserver.DiscogsService/DeleteFolder", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *discogsServiceClient) BulkMove(ctx context.Context, in *BulkMoveRequest, opts ...grpc.CallOption) (*BulkResponse, error) {
+	out := new(BulkResponse)
+	err := grpc.Invoke(ctx, "/discogsserver.DiscogsService/BulkMove", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *discogsServiceClient) BulkUpdateMetadata(ctx context.Context, in *BulkMetadataRequest, opts ...grpc.CallOption) (*BulkResponse, error) {
+	out := new(BulkResponse)
+	err := grpc.Invoke(ctx, "/discogsserver.DiscogsService/BulkUpdateMetadata", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *discogsServiceClient) AddFolderRule(ctx context.Context, in *FolderRule, opts ...grpc.CallOption) (*FolderRuleList, error) {
+	out := new(FolderRuleList)
+	err := grpc.Invoke(ctx, "/discogsserver.DiscogsService/AddFolderRule", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *discogsServiceClient) DeleteFolderRule(ctx context.Context, in *FolderRule, opts ...grpc.CallOption) (*FolderRuleList, error) {
+	out := new(FolderRuleList)
+	err := grpc.Invoke(ctx, "/discogsserver.DiscogsService/DeleteFolderRule", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *discogsServiceClient) GetFolderRules(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*FolderRuleList, error) {
+	out := new(FolderRuleList)
+	err := grpc.Invoke(ctx, "/discogsserver.DiscogsService/GetFolderRules", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *discogsServiceClient) PreviewFolderRules(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*RuleAuditList, error) {
+	out := new(RuleAuditList)
+	err := grpc.Invoke(ctx, "/discogsserver.DiscogsService/PreviewFolderRules", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *discogsServiceClient) GetRuleAudit(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*RuleAuditList, error) {
+	out := new(RuleAuditList)
+	err := grpc.Invoke(ctx, "/discogsserver.DiscogsService/GetRuleAudit", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *discogsServiceClient) SetShelfPosition(ctx context.Context, in *ShelfPosition, opts ...grpc.CallOption) (*ReleaseMetadata, error) {
+	out := new(ReleaseMetadata)
+	err := grpc.Invoke(ctx, "/discogsserver.DiscogsService/SetShelfPosition", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *discogsServiceClient) OrderFolder(ctx context.Context, in *OrderRequest, opts ...grpc.CallOption) (*ShelfList, error) {
+	out := new(ShelfList)
+	err := grpc.Invoke(ctx, "/discogsserver.DiscogsService/OrderFolder", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *discogsServiceClient) GetInsertionPoint(ctx context.Context, in *InsertionRequest, opts ...grpc.CallOption) (*InsertionResponse, error) {
+	out := new(InsertionResponse)
+	err := grpc.Invoke(ctx, "/discogsserver.DiscogsService/GetInsertionPoint", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *discogsServiceClient) LendRecord(ctx context.Context, in *Loan, opts ...grpc.CallOption) (*Loan, error) {
+	out := new(Loan)
+	err := grpc.Invoke(ctx, "/discogsserver.DiscogsService/LendRecord", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *discogsServiceClient) ReturnRecord(ctx context.Context, in *Loan, opts ...grpc.CallOption) (*Loan, error) {
+	out := new(Loan)
+	err := grpc.Invoke(ctx, "/discogsserver.DiscogsService/ReturnRecord", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *discogsServiceClient) ListLoans(ctx context.Context, in *LoanRequest, opts ...grpc.CallOption) (*LoanList, error) {
+	out := new(LoanList)
+	err := grpc.Invoke(ctx, "/discogsserver.DiscogsService/ListLoans", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *discogsServiceClient) ExportCollection(ctx context.Context, in *ExportRequest, opts ...grpc.CallOption) (DiscogsService_ExportCollectionClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_DiscogsService_serviceDesc.Streams[0], c.cc, "/discogsserver.DiscogsService/ExportCollection", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &discogsServiceExportCollectionClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type DiscogsService_ExportCollectionClient interface {
+	Recv() (*ExportRow, error)
+	grpc.ClientStream
+}
+
+type discogsServiceExportCollectionClient struct {
+	grpc.ClientStream
+}
+
+func (x *discogsServiceExportCollectionClient) Recv() (*ExportRow, error) {
+	m := new(ExportRow)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *discogsServiceClient) ImportDiscogsCSV(ctx context.Context, in *DiscogsImportRequest, opts ...grpc.CallOption) (*ImportReport, error) {
+	out := new(ImportReport)
+	err := grpc.Invoke(ctx, "/discogsserver.DiscogsService/ImportDiscogsCSV", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *discogsServiceClient) BackupCollection(ctx context.Context, in *BackupRequest, opts ...grpc.CallOption) (DiscogsService_BackupCollectionClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_DiscogsService_serviceDesc.Streams[1], c.cc, "/discogsserver.DiscogsService/BackupCollection", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &discogsServiceBackupCollectionClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type DiscogsService_BackupCollectionClient interface {
+	Recv() (*ExportRow, error)
+	grpc.ClientStream
+}
+
+type discogsServiceBackupCollectionClient struct {
+	grpc.ClientStream
+}
+
+func (x *discogsServiceBackupCollectionClient) Recv() (*ExportRow, error) {
+	m := new(ExportRow)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *discogsServiceClient) RestoreCollection(ctx context.Context, in *RestoreRequest, opts ...grpc.CallOption) (*RestoreReport, error) {
+	out := new(RestoreReport)
+	err := grpc.Invoke(ctx, "/discogsserver.DiscogsService/RestoreCollection", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *discogsServiceClient) Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*ReleaseList, error) {
+	out := new(ReleaseList)
+	err := grpc.Invoke(ctx, "/discogsserver.DiscogsService/Search", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
-	return out, nil
+	return out, nil
+}
+
+// Server API for DiscogsService service
+
+type DiscogsServiceServer interface {
+	GetCollection(context.Context, *Empty) (*ReleaseList, error)
+	GetReleasesInFolder(context.Context, *FolderList) (*RecordList, error)
+	MoveToFolder(context.Context, *ReleaseMove) (*Empty, error)
+	AddToFolder(context.Context, *ReleaseMove) (*Empty, error)
+	UpdateMetadata(context.Context, *MetadataUpdate) (*ReleaseMetadata, error)
+	GetMetadata(context.Context, *godiscogs.Release) (*ReleaseMetadata, error)
+	UpdateRating(context.Context, *godiscogs.Release) (*Empty, error)
+	GetSingleRelease(context.Context, *godiscogs.Release) (*godiscogs.Release, error)
+	GetWantlist(context.Context, *WantlistRequest) (*Wantlist, error)
+	CollapseWantlist(context.Context, *CollapseRequest) (*Wantlist, error)
+	RebuildWantlist(context.Context, *Empty) (*Wantlist, error)
+	GetSpend(context.Context, *SpendRequest) (*SpendResponse, error)
+	EditWant(context.Context, *Want) (*Want, error)
+	DeleteWant(context.Context, *Want) (*Wantlist, error)
+	AddWant(context.Context, *Want) (*Empty, error)
+	ExpandWant(context.Context, *Want) (*Wantlist, error)
+	SyncWithDiscogs(context.Context, *Empty) (*SyncResult, error)
+	DeleteInstance(context.Context, *godiscogs.Release) (*Empty, error)
+	Sell(context.Context, *godiscogs.Release) (*Empty, error)
+	GetIncompleteReleases(context.Context, *Empty) (*ReleaseList, error)
+	SellCandidates(context.Context, *SellCandidatesRequest) (*SellCandidateList, error)
+	BulkSell(context.Context, *BulkSellRequest) (*BulkSellResponse, error)
+	RecordPlay(context.Context, *Play) (*Play, error)
+	GetPlays(context.Context, *PlayRequest) (*PlayList, error)
+	GetPlayCounts(context.Context, *PlayCountRequest) (*PlayCountList, error)
+	GetNeverPlayed(context.Context, *NeverPlayedRequest) (*ReleaseList, error)
+	GetStreaks(context.Context, *StreakRequest) (*StreakResponse, error)
+	GetListeningSuggestion(context.Context, *SuggestionRequest) (*Record, error)
+	AddField(context.Context, *FieldDefinition) (*FieldSchema, error)
+	DeleteField(context.Context, *FieldDefinition) (*FieldSchema, error)
+	GetFields(context.Context, *Empty) (*FieldSchema, error)
+	AddTags(context.Context, *TagRequest) (*ReleaseMetadata, error)
+	RemoveTags(context.Context, *TagRequest) (*ReleaseMetadata, error)
+	ListTags(context.Context, *Empty) (*TagCountList, error)
+	GetReleasesByTag(context.Context, *TagQuery) (*ReleaseList, error)
+	RenameTag(context.Context, *TagRename) (*TagCountList, error)
+	AddSmartFolder(context.Context, *SmartFolder) (*SmartFolder, error)
+	UpdateSmartFolder(context.Context, *SmartFolder) (*SmartFolder, error)
+	DeleteSmartFolder(context.Context, *godiscogs.Folder) (*Empty, error)
+	GetSmartFolders(context.Context, *Empty) (*SmartFolderList, error)
+	CreateFolder(context.Context, *godiscogs.Folder) (*godiscogs.Folder, error)
+	RenameFolder(context.Context, *godiscogs.Folder) (*godiscogs.Folder, error)
+	DeleteFolder(context.Context, *FolderDelete) (*Empty, error)
+	BulkMove(context.Context, *BulkMoveRequest) (*BulkResponse, error)
+	BulkUpdateMetadata(context.Context, *BulkMetadataRequest) (*BulkResponse, error)
+	AddFolderRule(context.Context, *FolderRule) (*FolderRuleList, error)
+	DeleteFolderRule(context.Context, *FolderRule) (*FolderRuleList, error)
+	GetFolderRules(context.Context, *Empty) (*FolderRuleList, error)
+	PreviewFolderRules(context.Context, *Empty) (*RuleAuditList, error)
+	GetRuleAudit(context.Context, *Empty) (*RuleAuditList, error)
+	SetShelfPosition(context.Context, *ShelfPosition) (*ReleaseMetadata, error)
+	OrderFolder(context.Context, *OrderRequest) (*ShelfList, error)
+	GetInsertionPoint(context.Context, *InsertionRequest) (*InsertionResponse, error)
+	LendRecord(context.Context, *Loan) (*Loan, error)
+	ReturnRecord(context.Context, *Loan) (*Loan, error)
+	ListLoans(context.Context, *LoanRequest) (*LoanList, error)
+	ExportCollection(*ExportRequest, DiscogsService_ExportCollectionServer) error
+	ImportDiscogsCSV(context.Context, *DiscogsImportRequest) (*ImportReport, error)
+	BackupCollection(*BackupRequest, DiscogsService_BackupCollectionServer) error
+	RestoreCollection(context.Context, *RestoreRequest) (*RestoreReport, error)
+	Search(context.Context, *SearchRequest) (*ReleaseList, error)
+}
+
+func RegisterDiscogsServiceServer(s *grpc.Server, srv DiscogsServiceServer) {
+	s.RegisterService(&_DiscogsService_serviceDesc, srv)
+}
+
+func _DiscogsService_GetCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DiscogsServiceServer).GetCollection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/discogsserver.DiscogsService/GetCollection",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DiscogsServiceServer).GetCollection(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DiscogsService_GetReleasesInFolder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FolderList)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DiscogsServiceServer).GetReleasesInFolder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/discogsserver.DiscogsService/GetReleasesInFolder",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DiscogsServiceServer).GetReleasesInFolder(ctx, req.(*FolderList))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DiscogsService_MoveToFolder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReleaseMove)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DiscogsServiceServer).MoveToFolder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/discogsserver.DiscogsService/MoveToFolder",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DiscogsServiceServer).MoveToFolder(ctx, req.(*ReleaseMove))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DiscogsService_AddToFolder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReleaseMove)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DiscogsServiceServer).AddToFolder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/discogsserver.DiscogsService/AddToFolder",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DiscogsServiceServer).AddToFolder(ctx, req.(*ReleaseMove))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DiscogsService_UpdateMetadata_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MetadataUpdate)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DiscogsServiceServer).UpdateMetadata(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/discogsserver.DiscogsService/UpdateMetadata",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DiscogsServiceServer).UpdateMetadata(ctx, req.(*MetadataUpdate))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DiscogsService_GetMetadata_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(godiscogs.Release)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DiscogsServiceServer).GetMetadata(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/discogsserver.DiscogsService/GetMetadata",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DiscogsServiceServer).GetMetadata(ctx, req.(*godiscogs.Release))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DiscogsService_UpdateRating_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(godiscogs.Release)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DiscogsServiceServer).UpdateRating(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/discogsserver.DiscogsService/UpdateRating",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DiscogsServiceServer).UpdateRating(ctx, req.(*godiscogs.Release))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DiscogsService_GetSingleRelease_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(godiscogs.Release)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DiscogsServiceServer).GetSingleRelease(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/discogsserver.DiscogsService/GetSingleRelease",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DiscogsServiceServer).GetSingleRelease(ctx, req.(*godiscogs.Release))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DiscogsService_GetWantlist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WantlistRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DiscogsServiceServer).GetWantlist(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/discogsserver.DiscogsService/GetWantlist",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DiscogsServiceServer).GetWantlist(ctx, req.(*WantlistRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DiscogsService_CollapseWantlist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CollapseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DiscogsServiceServer).CollapseWantlist(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/discogsserver.DiscogsService/CollapseWantlist",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DiscogsServiceServer).CollapseWantlist(ctx, req.(*CollapseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DiscogsService_RebuildWantlist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DiscogsServiceServer).RebuildWantlist(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/discogsserver.DiscogsService/RebuildWantlist",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DiscogsServiceServer).RebuildWantlist(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DiscogsService_GetSpend_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SpendRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DiscogsServiceServer).GetSpend(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/discogsserver.DiscogsService/GetSpend",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DiscogsServiceServer).GetSpend(ctx, req.(*SpendRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DiscogsService_EditWant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Want)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DiscogsServiceServer).EditWant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/discogsserver.DiscogsService/EditWant",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DiscogsServiceServer).EditWant(ctx, req.(*Want))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DiscogsService_DeleteWant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Want)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DiscogsServiceServer).DeleteWant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/discogsserver.DiscogsService/DeleteWant",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DiscogsServiceServer).DeleteWant(ctx, req.(*Want))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DiscogsService_AddWant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Want)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DiscogsServiceServer).AddWant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/discogsserver.DiscogsService/AddWant",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DiscogsServiceServer).AddWant(ctx, req.(*Want))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DiscogsService_ExpandWant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Want)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DiscogsServiceServer).ExpandWant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/discogsserver.DiscogsService/ExpandWant",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DiscogsServiceServer).ExpandWant(ctx, req.(*Want))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DiscogsService_SyncWithDiscogs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DiscogsServiceServer).SyncWithDiscogs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/discogsserver.DiscogsService/SyncWithDiscogs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DiscogsServiceServer).SyncWithDiscogs(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DiscogsService_DeleteInstance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(godiscogs.Release)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DiscogsServiceServer).DeleteInstance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/discogsserver.DiscogsService/DeleteInstance",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DiscogsServiceServer).DeleteInstance(ctx, req.(*godiscogs.Release))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DiscogsService_Sell_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(godiscogs.Release)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DiscogsServiceServer).Sell(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/discogsserver.DiscogsService/Sell",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DiscogsServiceServer).Sell(ctx, req.(*godiscogs.Release))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DiscogsService_GetIncompleteReleases_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DiscogsServiceServer).GetIncompleteReleases(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/discogsserver.DiscogsService/GetIncompleteReleases",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DiscogsServiceServer).GetIncompleteReleases(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DiscogsService_SellCandidates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SellCandidatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DiscogsServiceServer).SellCandidates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/discogsserver.DiscogsService/SellCandidates",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DiscogsServiceServer).SellCandidates(ctx, req.(*SellCandidatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DiscogsService_BulkSell_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BulkSellRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DiscogsServiceServer).BulkSell(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/discogsserver.DiscogsService/BulkSell",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DiscogsServiceServer).BulkSell(ctx, req.(*BulkSellRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DiscogsService_RecordPlay_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Play)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DiscogsServiceServer).RecordPlay(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/discogsserver.DiscogsService/RecordPlay",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DiscogsServiceServer).RecordPlay(ctx, req.(*Play))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DiscogsService_GetPlays_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PlayRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DiscogsServiceServer).GetPlays(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/discogsserver.DiscogsService/GetPlays",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DiscogsServiceServer).GetPlays(ctx, req.(*PlayRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DiscogsService_GetPlayCounts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PlayCountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DiscogsServiceServer).GetPlayCounts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/discogsserver.DiscogsService/GetPlayCounts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DiscogsServiceServer).GetPlayCounts(ctx, req.(*PlayCountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DiscogsService_GetNeverPlayed_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NeverPlayedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DiscogsServiceServer).GetNeverPlayed(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/discogsserver.DiscogsService/GetNeverPlayed",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DiscogsServiceServer).GetNeverPlayed(ctx, req.(*NeverPlayedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DiscogsService_GetStreaks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StreakRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DiscogsServiceServer).GetStreaks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/discogsserver.DiscogsService/GetStreaks",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DiscogsServiceServer).GetStreaks(ctx, req.(*StreakRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DiscogsService_GetListeningSuggestion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SuggestionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DiscogsServiceServer).GetListeningSuggestion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/discogsserver.DiscogsService/GetListeningSuggestion",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DiscogsServiceServer).GetListeningSuggestion(ctx, req.(*SuggestionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DiscogsService_AddField_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FieldDefinition)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DiscogsServiceServer).AddField(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/discogsserver.DiscogsService/AddField",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DiscogsServiceServer).AddField(ctx, req.(*FieldDefinition))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DiscogsService_DeleteField_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FieldDefinition)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DiscogsServiceServer).DeleteField(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/discogsserver.DiscogsService/DeleteField",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DiscogsServiceServer).DeleteField(ctx, req.(*FieldDefinition))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DiscogsService_GetFields_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DiscogsServiceServer).GetFields(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/discogsserver.DiscogsService/GetFields",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DiscogsServiceServer).GetFields(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DiscogsService_AddTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DiscogsServiceServer).AddTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/discogsserver.DiscogsService/AddTags",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DiscogsServiceServer).AddTags(ctx, req.(*TagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DiscogsService_RemoveTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DiscogsServiceServer).RemoveTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/discogsserver.DiscogsService/RemoveTags",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DiscogsServiceServer).RemoveTags(ctx, req.(*TagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DiscogsService_ListTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DiscogsServiceServer).ListTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/discogsserver.DiscogsService/ListTags",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DiscogsServiceServer).ListTags(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DiscogsService_GetReleasesByTag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TagQuery)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DiscogsServiceServer).GetReleasesByTag(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/discogsserver.DiscogsService/GetReleasesByTag",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DiscogsServiceServer).GetReleasesByTag(ctx, req.(*TagQuery))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DiscogsService_RenameTag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TagRename)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DiscogsServiceServer).RenameTag(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/discogsserver.DiscogsService/RenameTag",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DiscogsServiceServer).RenameTag(ctx, req.(*TagRename))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DiscogsService_AddSmartFolder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SmartFolder)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DiscogsServiceServer).AddSmartFolder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/discogsserver.DiscogsService/AddSmartFolder",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DiscogsServiceServer).AddSmartFolder(ctx, req.(*SmartFolder))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *discogsServiceClient) GetIncompleteReleases(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ReleaseList, error) {
-	out := new(ReleaseList)
-	err := grpc.Invoke(ctx, "/discogsserver.DiscogsService/GetIncompleteReleases", in, out, c.cc, opts...)
-	if err != nil {
+func _DiscogsService_UpdateSmartFolder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SmartFolder)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
-}
-
-// Server API for DiscogsService service
-
-type DiscogsServiceServer interface {
-	GetCollection(context.Context, *Empty) (*ReleaseList, error)
-	GetReleasesInFolder(context.Context, *FolderList) (*RecordList, error)
-	MoveToFolder(context.Context, *ReleaseMove) (*Empty, error)
-	AddToFolder(context.Context, *ReleaseMove) (*Empty, error)
-	UpdateMetadata(context.Context, *MetadataUpdate) (*ReleaseMetadata, error)
-	GetMetadata(context.Context, *godiscogs.Release) (*ReleaseMetadata, error)
-	UpdateRating(context.Context, *godiscogs.Release) (*Empty, error)
-	GetSingleRelease(context.Context, *godiscogs.Release) (*godiscogs.Release, error)
-	GetWantlist(context.Context, *Empty) (*Wantlist, error)
-	CollapseWantlist(context.Context, *Empty) (*Wantlist, error)
-	RebuildWantlist(context.Context, *Empty) (*Wantlist, error)
-	GetSpend(context.Context, *SpendRequest) (*SpendResponse, error)
-	EditWant(context.Context, *Want) (*Want, error)
-	DeleteWant(context.Context, *Want) (*Wantlist, error)
-	AddWant(context.Context, *Want) (*Empty, error)
-	SyncWithDiscogs(context.Context, *Empty) (*Empty, error)
-	DeleteInstance(context.Context, *godiscogs.Release) (*Empty, error)
-	Sell(context.Context, *godiscogs.Release) (*Empty, error)
-	GetIncompleteReleases(context.Context, *Empty) (*ReleaseList, error)
+	if interceptor == nil {
+		return srv.(DiscogsServiceServer).UpdateSmartFolder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/discogsserver.DiscogsService/UpdateSmartFolder",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DiscogsServiceServer).UpdateSmartFolder(ctx, req.(*SmartFolder))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func RegisterDiscogsServiceServer(s *grpc.Server, srv DiscogsServiceServer) {
-	s.RegisterService(&_DiscogsService_serviceDesc, srv)
+func _DiscogsService_DeleteSmartFolder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(godiscogs.Folder)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DiscogsServiceServer).DeleteSmartFolder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/discogsserver.DiscogsService/DeleteSmartFolder",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DiscogsServiceServer).DeleteSmartFolder(ctx, req.(*godiscogs.Folder))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _DiscogsService_GetCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _DiscogsService_GetSmartFolders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DiscogsServiceServer).GetCollection(ctx, in)
+		return srv.(DiscogsServiceServer).GetSmartFolders(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/discogsserver.DiscogsService/GetCollection",
+		FullMethod: "/discogsserver.DiscogsService/GetSmartFolders",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DiscogsServiceServer).GetCollection(ctx, req.(*Empty))
+		return srv.(DiscogsServiceServer).GetSmartFolders(ctx, req.(*Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DiscogsService_GetReleasesInFolder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(FolderList)
+func _DiscogsService_CreateFolder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(godiscogs.Folder)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DiscogsServiceServer).GetReleasesInFolder(ctx, in)
+		return srv.(DiscogsServiceServer).CreateFolder(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/discogsserver.DiscogsService/GetReleasesInFolder",
+		FullMethod: "/discogsserver.DiscogsService/CreateFolder",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DiscogsServiceServer).GetReleasesInFolder(ctx, req.(*FolderList))
+		return srv.(DiscogsServiceServer).CreateFolder(ctx, req.(*godiscogs.Folder))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DiscogsService_MoveToFolder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ReleaseMove)
+func _DiscogsService_RenameFolder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(godiscogs.Folder)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DiscogsServiceServer).MoveToFolder(ctx, in)
+		return srv.(DiscogsServiceServer).RenameFolder(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/discogsserver.DiscogsService/MoveToFolder",
+		FullMethod: "/discogsserver.DiscogsService/RenameFolder",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DiscogsServiceServer).MoveToFolder(ctx, req.(*ReleaseMove))
+		return srv.(DiscogsServiceServer).RenameFolder(ctx, req.(*godiscogs.Folder))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DiscogsService_AddToFolder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ReleaseMove)
+func _DiscogsService_DeleteFolder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FolderDelete)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DiscogsServiceServer).AddToFolder(ctx, in)
+		return srv.(DiscogsServiceServer).DeleteFolder(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/discogsserver.DiscogsService/AddToFolder",
+		FullMethod: "/discogsserver.DiscogsService/DeleteFolder",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DiscogsServiceServer).AddToFolder(ctx, req.(*ReleaseMove))
+		return srv.(DiscogsServiceServer).DeleteFolder(ctx, req.(*FolderDelete))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DiscogsService_UpdateMetadata_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(MetadataUpdate)
+func _DiscogsService_BulkMove_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BulkMoveRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DiscogsServiceServer).UpdateMetadata(ctx, in)
+		return srv.(DiscogsServiceServer).BulkMove(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/discogsserver.DiscogsService/UpdateMetadata",
+		FullMethod: "/discogsserver.DiscogsService/BulkMove",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DiscogsServiceServer).UpdateMetadata(ctx, req.(*MetadataUpdate))
+		return srv.(DiscogsServiceServer).BulkMove(ctx, req.(*BulkMoveRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DiscogsService_GetMetadata_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(godiscogs.Release)
+func _DiscogsService_BulkUpdateMetadata_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BulkMetadataRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DiscogsServiceServer).GetMetadata(ctx, in)
+		return srv.(DiscogsServiceServer).BulkUpdateMetadata(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/discogsserver.DiscogsService/GetMetadata",
+		FullMethod: "/discogsserver.DiscogsService/BulkUpdateMetadata",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DiscogsServiceServer).GetMetadata(ctx, req.(*godiscogs.Release))
+		return srv.(DiscogsServiceServer).BulkUpdateMetadata(ctx, req.(*BulkMetadataRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DiscogsService_UpdateRating_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(godiscogs.Release)
+func _DiscogsService_AddFolderRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FolderRule)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DiscogsServiceServer).UpdateRating(ctx, in)
+		return srv.(DiscogsServiceServer).AddFolderRule(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/discogsserver.DiscogsService/UpdateRating",
+		FullMethod: "/discogsserver.DiscogsService/AddFolderRule",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DiscogsServiceServer).UpdateRating(ctx, req.(*godiscogs.Release))
+		return srv.(DiscogsServiceServer).AddFolderRule(ctx, req.(*FolderRule))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DiscogsService_GetSingleRelease_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(godiscogs.Release)
+func _DiscogsService_DeleteFolderRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FolderRule)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DiscogsServiceServer).GetSingleRelease(ctx, in)
+		return srv.(DiscogsServiceServer).DeleteFolderRule(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/discogsserver.DiscogsService/GetSingleRelease",
+		FullMethod: "/discogsserver.DiscogsService/DeleteFolderRule",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DiscogsServiceServer).GetSingleRelease(ctx, req.(*godiscogs.Release))
+		return srv.(DiscogsServiceServer).DeleteFolderRule(ctx, req.(*FolderRule))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DiscogsService_GetWantlist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _DiscogsService_GetFolderRules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DiscogsServiceServer).GetWantlist(ctx, in)
+		return srv.(DiscogsServiceServer).GetFolderRules(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/discogsserver.DiscogsService/GetWantlist",
+		FullMethod: "/discogsserver.DiscogsService/GetFolderRules",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DiscogsServiceServer).GetWantlist(ctx, req.(*Empty))
+		return srv.(DiscogsServiceServer).GetFolderRules(ctx, req.(*Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DiscogsService_CollapseWantlist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _DiscogsService_PreviewFolderRules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DiscogsServiceServer).CollapseWantlist(ctx, in)
+		return srv.(DiscogsServiceServer).PreviewFolderRules(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/discogsserver.DiscogsService/CollapseWantlist",
+		FullMethod: "/discogsserver.DiscogsService/PreviewFolderRules",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DiscogsServiceServer).CollapseWantlist(ctx, req.(*Empty))
+		return srv.(DiscogsServiceServer).PreviewFolderRules(ctx, req.(*Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DiscogsService_RebuildWantlist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _DiscogsService_GetRuleAudit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DiscogsServiceServer).RebuildWantlist(ctx, in)
+		return srv.(DiscogsServiceServer).GetRuleAudit(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/discogsserver.DiscogsService/RebuildWantlist",
+		FullMethod: "/discogsserver.DiscogsService/GetRuleAudit",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DiscogsServiceServer).RebuildWantlist(ctx, req.(*Empty))
+		return srv.(DiscogsServiceServer).GetRuleAudit(ctx, req.(*Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DiscogsService_GetSpend_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SpendRequest)
+func _DiscogsService_SetShelfPosition_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShelfPosition)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DiscogsServiceServer).GetSpend(ctx, in)
+		return srv.(DiscogsServiceServer).SetShelfPosition(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/discogsserver.DiscogsService/GetSpend",
+		FullMethod: "/discogsserver.DiscogsService/SetShelfPosition",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DiscogsServiceServer).GetSpend(ctx, req.(*SpendRequest))
+		return srv.(DiscogsServiceServer).SetShelfPosition(ctx, req.(*ShelfPosition))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DiscogsService_EditWant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Want)
+func _DiscogsService_OrderFolder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OrderRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DiscogsServiceServer).EditWant(ctx, in)
+		return srv.(DiscogsServiceServer).OrderFolder(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/discogsserver.DiscogsService/EditWant",
+		FullMethod: "/discogsserver.DiscogsService/OrderFolder",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DiscogsServiceServer).EditWant(ctx, req.(*Want))
+		return srv.(DiscogsServiceServer).OrderFolder(ctx, req.(*OrderRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DiscogsService_DeleteWant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Want)
+func _DiscogsService_GetInsertionPoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InsertionRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DiscogsServiceServer).DeleteWant(ctx, in)
+		return srv.(DiscogsServiceServer).GetInsertionPoint(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/discogsserver.DiscogsService/DeleteWant",
+		FullMethod: "/discogsserver.DiscogsService/GetInsertionPoint",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DiscogsServiceServer).DeleteWant(ctx, req.(*Want))
+		return srv.(DiscogsServiceServer).GetInsertionPoint(ctx, req.(*InsertionRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DiscogsService_AddWant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Want)
+func _DiscogsService_LendRecord_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Loan)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DiscogsServiceServer).AddWant(ctx, in)
+		return srv.(DiscogsServiceServer).LendRecord(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/discogsserver.DiscogsService/AddWant",
+		FullMethod: "/discogsserver.DiscogsService/LendRecord",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DiscogsServiceServer).AddWant(ctx, req.(*Want))
+		return srv.(DiscogsServiceServer).LendRecord(ctx, req.(*Loan))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DiscogsService_SyncWithDiscogs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Empty)
+func _DiscogsService_ReturnRecord_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Loan)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DiscogsServiceServer).SyncWithDiscogs(ctx, in)
+		return srv.(DiscogsServiceServer).ReturnRecord(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/discogsserver.DiscogsService/SyncWithDiscogs",
+		FullMethod: "/discogsserver.DiscogsService/ReturnRecord",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DiscogsServiceServer).SyncWithDiscogs(ctx, req.(*Empty))
+		return srv.(DiscogsServiceServer).ReturnRecord(ctx, req.(*Loan))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DiscogsService_DeleteInstance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(godiscogs.Release)
+func _DiscogsService_ListLoans_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoanRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DiscogsServiceServer).DeleteInstance(ctx, in)
+		return srv.(DiscogsServiceServer).ListLoans(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/discogsserver.DiscogsService/DeleteInstance",
+		FullMethod: "/discogsserver.DiscogsService/ListLoans",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DiscogsServiceServer).DeleteInstance(ctx, req.(*godiscogs.Release))
+		return srv.(DiscogsServiceServer).ListLoans(ctx, req.(*LoanRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DiscogsService_Sell_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(godiscogs.Release)
+func _DiscogsService_ExportCollection_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DiscogsServiceServer).ExportCollection(m, &discogsServiceExportCollectionServer{stream})
+}
+
+type DiscogsService_ExportCollectionServer interface {
+	Send(*ExportRow) error
+	grpc.ServerStream
+}
+
+type discogsServiceExportCollectionServer struct {
+	grpc.ServerStream
+}
+
+func (x *discogsServiceExportCollectionServer) Send(m *ExportRow) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _DiscogsService_ImportDiscogsCSV_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiscogsImportRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DiscogsServiceServer).Sell(ctx, in)
+		return srv.(DiscogsServiceServer).ImportDiscogsCSV(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/discogsserver.DiscogsService/Sell",
+		FullMethod: "/discogsserver.DiscogsService/ImportDiscogsCSV",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DiscogsServiceServer).Sell(ctx, req.(*godiscogs.Release))
+		return srv.(DiscogsServiceServer).ImportDiscogsCSV(ctx, req.(*DiscogsImportRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DiscogsService_GetIncompleteReleases_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Empty)
+func _DiscogsService_BackupCollection_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(BackupRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DiscogsServiceServer).BackupCollection(m, &discogsServiceBackupCollectionServer{stream})
+}
+
+type DiscogsService_BackupCollectionServer interface {
+	Send(*ExportRow) error
+	grpc.ServerStream
+}
+
+type discogsServiceBackupCollectionServer struct {
+	grpc.ServerStream
+}
+
+func (x *discogsServiceBackupCollectionServer) Send(m *ExportRow) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _DiscogsService_RestoreCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DiscogsServiceServer).GetIncompleteReleases(ctx, in)
+		return srv.(DiscogsServiceServer).RestoreCollection(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/discogsserver.DiscogsService/GetIncompleteReleases",
+		FullMethod: "/discogsserver.DiscogsService/RestoreCollection",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DiscogsServiceServer).GetIncompleteReleases(ctx, req.(*Empty))
+		return srv.(DiscogsServiceServer).RestoreCollection(ctx, req.(*RestoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DiscogsService_Search_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DiscogsServiceServer).Search(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/discogsserver.DiscogsService/Search",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DiscogsServiceServer).Search(ctx, req.(*SearchRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -1111,6 +4755,10 @@ var _DiscogsService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "AddWant",
 			Handler:    _DiscogsService_AddWant_Handler,
 		},
+		{
+			MethodName: "ExpandWant",
+			Handler:    _DiscogsService_ExpandWant_Handler,
+		},
 		{
 			MethodName: "SyncWithDiscogs",
 			Handler:    _DiscogsService_SyncWithDiscogs_Handler,
@@ -1127,74 +4775,448 @@ var _DiscogsService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetIncompleteReleases",
 			Handler:    _DiscogsService_GetIncompleteReleases_Handler,
 		},
+		{
+			MethodName: "SellCandidates",
+			Handler:    _DiscogsService_SellCandidates_Handler,
+		},
+		{
+			MethodName: "BulkSell",
+			Handler:    _DiscogsService_BulkSell_Handler,
+		},
+		{
+			MethodName: "RecordPlay",
+			Handler:    _DiscogsService_RecordPlay_Handler,
+		},
+		{
+			MethodName: "GetPlays",
+			Handler:    _DiscogsService_GetPlays_Handler,
+		},
+		{
+			MethodName: "GetPlayCounts",
+			Handler:    _DiscogsService_GetPlayCounts_Handler,
+		},
+		{
+			MethodName: "GetNeverPlayed",
+			Handler:    _DiscogsService_GetNeverPlayed_Handler,
+		},
+		{
+			MethodName: "GetStreaks",
+			Handler:    _DiscogsService_GetStreaks_Handler,
+		},
+		{
+			MethodName: "GetListeningSuggestion",
+			Handler:    _DiscogsService_GetListeningSuggestion_Handler,
+		},
+		{
+			MethodName: "AddField",
+			Handler:    _DiscogsService_AddField_Handler,
+		},
+		{
+			MethodName: "DeleteField",
+			Handler:    _DiscogsService_DeleteField_Handler,
+		},
+		{
+			MethodName: "GetFields",
+			Handler:    _DiscogsService_GetFields_Handler,
+		},
+		{
+			MethodName: "AddTags",
+			Handler:    _DiscogsService_AddTags_Handler,
+		},
+		{
+			MethodName: "RemoveTags",
+			Handler:    _DiscogsService_RemoveTags_Handler,
+		},
+		{
+			MethodName: "ListTags",
+			Handler:    _DiscogsService_ListTags_Handler,
+		},
+		{
+			MethodName: "GetReleasesByTag",
+			Handler:    _DiscogsService_GetReleasesByTag_Handler,
+		},
+		{
+			MethodName: "RenameTag",
+			Handler:    _DiscogsService_RenameTag_Handler,
+		},
+		{
+			MethodName: "AddSmartFolder",
+			Handler:    _DiscogsService_AddSmartFolder_Handler,
+		},
+		{
+			MethodName: "UpdateSmartFolder",
+			Handler:    _DiscogsService_UpdateSmartFolder_Handler,
+		},
+		{
+			MethodName: "DeleteSmartFolder",
+			Handler:    _DiscogsService_DeleteSmartFolder_Handler,
+		},
+		{
+			MethodName: "GetSmartFolders",
+			Handler:    _DiscogsService_GetSmartFolders_Handler,
+		},
+		{
+			MethodName: "CreateFolder",
+			Handler:    _DiscogsService_CreateFolder_Handler,
+		},
+		{
+			MethodName: "RenameFolder",
+			Handler:    _DiscogsService_RenameFolder_Handler,
+		},
+		{
+			MethodName: "DeleteFolder",
+			Handler:    _DiscogsService_DeleteFolder_Handler,
+		},
+		{
+			MethodName: "BulkMove",
+			Handler:    _DiscogsService_BulkMove_Handler,
+		},
+		{
+			MethodName: "BulkUpdateMetadata",
+			Handler:    _DiscogsService_BulkUpdateMetadata_Handler,
+		},
+		{
+			MethodName: "AddFolderRule",
+			Handler:    _DiscogsService_AddFolderRule_Handler,
+		},
+		{
+			MethodName: "DeleteFolderRule",
+			Handler:    _DiscogsService_DeleteFolderRule_Handler,
+		},
+		{
+			MethodName: "GetFolderRules",
+			Handler:    _DiscogsService_GetFolderRules_Handler,
+		},
+		{
+			MethodName: "PreviewFolderRules",
+			Handler:    _DiscogsService_PreviewFolderRules_Handler,
+		},
+		{
+			MethodName: "GetRuleAudit",
+			Handler:    _DiscogsService_GetRuleAudit_Handler,
+		},
+		{
+			MethodName: "SetShelfPosition",
+			Handler:    _DiscogsService_SetShelfPosition_Handler,
+		},
+		{
+			MethodName: "OrderFolder",
+			Handler:    _DiscogsService_OrderFolder_Handler,
+		},
+		{
+			MethodName: "GetInsertionPoint",
+			Handler:    _DiscogsService_GetInsertionPoint_Handler,
+		},
+		{
+			MethodName: "LendRecord",
+			Handler:    _DiscogsService_LendRecord_Handler,
+		},
+		{
+			MethodName: "ReturnRecord",
+			Handler:    _DiscogsService_ReturnRecord_Handler,
+		},
+		{
+			MethodName: "ListLoans",
+			Handler:    _DiscogsService_ListLoans_Handler,
+		},
+		{
+			MethodName: "ImportDiscogsCSV",
+			Handler:    _DiscogsService_ImportDiscogsCSV_Handler,
+		},
+		{
+			MethodName: "RestoreCollection",
+			Handler:    _DiscogsService_RestoreCollection_Handler,
+		},
+		{
+			MethodName: "Search",
+			Handler:    _DiscogsService_Search_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ExportCollection",
+			Handler:       _DiscogsService_ExportCollection_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "BackupCollection",
+			Handler:       _DiscogsService_BackupCollection_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "server.proto",
 }
 
 func init() { proto.RegisterFile("server.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 972 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x56, 0x6d, 0x6f, 0x1a, 0x47,
-	0x10, 0xf6, 0x19, 0x83, 0x61, 0x30, 0xc4, 0xdd, 0x24, 0x2d, 0x21, 0x75, 0x83, 0x56, 0x8a, 0x4a,
-	0xd5, 0x0a, 0xab, 0x58, 0xb5, 0x94, 0xa8, 0x69, 0xe5, 0x38, 0x29, 0x42, 0xad, 0xa5, 0x76, 0x49,
-	0x94, 0x8f, 0xe8, 0x7c, 0x3b, 0x86, 0x53, 0x8f, 0xdb, 0xcb, 0xed, 0x82, 0xe5, 0x3f, 0xd7, 0xbf,
-	0xd0, 0x5f, 0x54, 0xa9, 0xda, 0x97, 0x03, 0x72, 0x3d, 0xdc, 0xe0, 0x7c, 0x63, 0x66, 0x9f, 0x99,
-	0x79, 0xe6, 0xf5, 0x80, 0x03, 0x89, 0xe9, 0x02, 0xd3, 0x5e, 0x92, 0x0a, 0x25, 0x48, 0x83, 0x87,
-	0x32, 0x10, 0x13, 0x69, 0x95, 0xed, 0xef, 0x27, 0xa1, 0x9a, 0xce, 0x2f, 0x7b, 0x81, 0x98, 0x1d,
-	0x5f, 0xa6, 0x42, 0x4d, 0x31, 0x8d, 0xc4, 0x24, 0x0c, 0x8e, 0x27, 0xc2, 0x01, 0x57, 0xbf, 0xac,
-	0x07, 0x7a, 0x04, 0xe5, 0x37, 0xe2, 0x4f, 0x8c, 0xc9, 0x03, 0x28, 0x2b, 0xfd, 0xa3, 0xe5, 0x75,
-	0xbc, 0x6e, 0x8d, 0x59, 0x81, 0xfe, 0xe5, 0xc1, 0x21, 0xc3, 0x40, 0xa4, 0xfc, 0x5c, 0x44, 0x11,
-	0x06, 0x2a, 0x14, 0x31, 0x79, 0x06, 0xfb, 0x57, 0x22, 0xe2, 0x98, 0xca, 0x96, 0xd7, 0x29, 0x75,
-	0xeb, 0xfd, 0x27, 0xbd, 0x0f, 0x78, 0xf4, 0x56, 0xd8, 0x5f, 0x0c, 0x8e, 0x65, 0x78, 0xf2, 0x1c,
-	0xaa, 0x33, 0x54, 0x3e, 0xf7, 0x95, 0xdf, 0xda, 0x35, 0xb6, 0x5f, 0xe5, 0x6c, 0x19, 0x46, 0xe8,
-	0x4b, 0xbc, 0x70, 0x28, 0xb6, 0xc4, 0x93, 0x13, 0xa8, 0x5e, 0xfb, 0xb1, 0x8a, 0x42, 0xa9, 0x5a,
-	0xa5, 0x8e, 0xd7, 0xad, 0xf7, 0xbf, 0xc8, 0xd9, 0xbe, 0x73, 0xcf, 0x6c, 0x09, 0xa4, 0x73, 0x38,
-	0xcc, 0xb3, 0x21, 0xdf, 0x40, 0xc5, 0xf2, 0x31, 0xb9, 0xd6, 0xfb, 0x9f, 0xf5, 0x56, 0x55, 0x71,
-	0x84, 0x1d, 0x80, 0x9c, 0x42, 0x35, 0xb5, 0x84, 0x64, 0x6b, 0xd7, 0x80, 0xdb, 0xc5, 0x7c, 0x7f,
-	0x33, 0x61, 0x33, 0x2c, 0xfd, 0xdb, 0x83, 0x7b, 0xb9, 0x4c, 0xc8, 0x11, 0x00, 0xf7, 0x15, 0x8e,
-	0x7d, 0xce, 0x91, 0x9b, 0xd0, 0x25, 0x56, 0xd3, 0x9a, 0x33, 0xad, 0x20, 0x4f, 0xa1, 0x69, 0x9e,
-	0x53, 0xbc, 0x4a, 0x51, 0x4e, 0x91, 0x9b, 0x80, 0x25, 0xd6, 0xd0, 0x5a, 0x96, 0x29, 0xc9, 0x63,
-	0xa8, 0x5d, 0x85, 0x11, 0x8e, 0x13, 0x5f, 0x4d, 0x4d, 0x19, 0x6a, 0xac, 0xaa, 0x15, 0xbf, 0xfb,
-	0x6a, 0x4a, 0x08, 0xec, 0x05, 0x42, 0xaa, 0xd6, 0x5e, 0xc7, 0xeb, 0x96, 0x99, 0xf9, 0x4d, 0x3e,
-	0x87, 0x8a, 0x99, 0x04, 0xd9, 0x2a, 0x77, 0xbc, 0x6e, 0x95, 0x39, 0x89, 0x34, 0x61, 0x37, 0xe4,
-	0xad, 0x8a, 0x41, 0xee, 0x86, 0x5c, 0xd3, 0x8b, 0x7c, 0xa9, 0xc6, 0x81, 0x1f, 0x4c, 0xb1, 0xb5,
-	0x6f, 0xe9, 0x69, 0xcd, 0xb9, 0x56, 0xd0, 0x14, 0x2a, 0x76, 0x10, 0xc8, 0x77, 0xb0, 0xef, 0xf2,
-	0x74, 0xf5, 0x23, 0x6b, 0xf5, 0x73, 0x49, 0xb3, 0x0c, 0x92, 0xeb, 0xb8, 0xb7, 0x4d, 0xc7, 0xe9,
-	0x3e, 0x94, 0x5f, 0xcf, 0x12, 0x75, 0x43, 0x9f, 0x01, 0xd8, 0xc6, 0xe8, 0x32, 0x93, 0x6f, 0xf3,
-	0xf3, 0x57, 0xd0, 0xc0, 0x0c, 0x41, 0x5f, 0x40, 0x7d, 0xad, 0x45, 0xa4, 0xb7, 0xd6, 0x50, 0x6b,
-	0x5c, 0xc4, 0x7e, 0xd5, 0xc8, 0x17, 0x00, 0x36, 0x6d, 0x63, 0x7d, 0xac, 0x53, 0xd7, 0x52, 0x66,
-	0xfc, 0xf0, 0x3f, 0xb9, 0xe8, 0x57, 0x96, 0xa1, 0xe8, 0x78, 0x19, 0xfd, 0x42, 0x2c, 0x70, 0xcb,
-	0xd2, 0x51, 0x68, 0xc4, 0x78, 0x3d, 0xb6, 0x99, 0x8c, 0x43, 0x3b, 0x10, 0x65, 0x56, 0x8f, 0xf1,
-	0xda, 0x66, 0x39, 0xe4, 0x74, 0x01, 0xcd, 0xac, 0x70, 0x6f, 0x13, 0x3d, 0x29, 0x5b, 0xc6, 0x38,
-	0x85, 0xca, 0xdc, 0xd8, 0x7d, 0x64, 0x73, 0x1c, 0x9a, 0xbe, 0x85, 0x3d, 0xbd, 0x6d, 0x7a, 0x6a,
-	0x9c, 0x2b, 0x4d, 0xd0, 0x33, 0x04, 0x6b, 0x4e, 0x33, 0xe4, 0x7a, 0xf8, 0x16, 0x7e, 0x34, 0x77,
-	0xc3, 0x5c, 0x65, 0x4e, 0xd2, 0x7a, 0xbd, 0xa2, 0xc8, 0xcd, 0x08, 0x57, 0x99, 0x93, 0xe8, 0x09,
-	0x54, 0xb3, 0x25, 0x26, 0x5f, 0xc3, 0x9e, 0xd6, 0xba, 0x4a, 0xdf, 0x2f, 0xd8, 0x75, 0x66, 0x00,
-	0x94, 0xc3, 0xc1, 0x28, 0xc1, 0x98, 0x33, 0x7c, 0x3f, 0x47, 0xa9, 0xf4, 0x29, 0x9b, 0x89, 0x58,
-	0x4d, 0x1d, 0x1d, 0x2b, 0xe8, 0xdd, 0xb8, 0x41, 0x3f, 0x75, 0x45, 0x34, 0xbf, 0x35, 0x32, 0x12,
-	0xd7, 0x98, 0x1a, 0x16, 0x25, 0x66, 0x05, 0xad, 0x9d, 0x27, 0x09, 0xa6, 0x66, 0x8d, 0x4a, 0xcc,
-	0x0a, 0x74, 0x02, 0x0d, 0x17, 0x45, 0x26, 0x22, 0x96, 0x48, 0x9e, 0x40, 0x5d, 0x09, 0xe5, 0x47,
-	0x63, 0xa9, 0xd5, 0x2e, 0x18, 0x18, 0x95, 0x01, 0x92, 0x1f, 0xa0, 0x62, 0x9e, 0xa4, 0x3b, 0x75,
-	0x47, 0xb9, 0x14, 0x3e, 0x6c, 0x1c, 0x73, 0x60, 0xfa, 0x14, 0x1a, 0x23, 0xf4, 0xd3, 0x60, 0xba,
-	0x96, 0xcf, 0xfb, 0x39, 0xa6, 0x37, 0xd9, 0x69, 0x36, 0x42, 0xff, 0x9f, 0x1a, 0x34, 0x5f, 0x59,
-	0x7f, 0x23, 0x4c, 0x17, 0x61, 0x80, 0xe4, 0x1c, 0x1a, 0x03, 0x54, 0x6b, 0x97, 0xfa, 0x41, 0x2e,
-	0xa2, 0xd9, 0xa6, 0xf6, 0x2d, 0x27, 0x8c, 0xee, 0x90, 0x0b, 0xb8, 0x3f, 0x40, 0xe5, 0x74, 0x72,
-	0x98, 0x1d, 0xcd, 0x47, 0x39, 0xa3, 0xd5, 0x3e, 0xb6, 0x1f, 0x15, 0x2e, 0x81, 0x73, 0xf7, 0x12,
-	0x0e, 0xf4, 0xe8, 0xbf, 0x11, 0xce, 0xcf, 0x86, 0xe0, 0x1a, 0xd3, 0x2e, 0xa4, 0x4b, 0x77, 0xc8,
-	0x19, 0xd4, 0xcf, 0x38, 0xff, 0x24, 0x17, 0x7f, 0x40, 0xd3, 0x96, 0x79, 0x75, 0x8e, 0x6f, 0xed,
-	0x46, 0xfb, 0x7f, 0x16, 0x81, 0xee, 0x90, 0x73, 0xa8, 0x0f, 0x50, 0x2d, 0xfd, 0x15, 0xac, 0xd9,
-	0x47, 0x38, 0x79, 0x0e, 0x07, 0xae, 0xfd, 0xbe, 0x0a, 0xe3, 0x49, 0xa1, 0x97, 0x4d, 0x39, 0xfd,
-	0x08, 0x87, 0x03, 0x54, 0xa3, 0x30, 0x9e, 0x44, 0xe8, 0xb0, 0x85, 0xf6, 0x05, 0x3a, 0xba, 0x43,
-	0x7e, 0x32, 0xf4, 0x97, 0xdb, 0x56, 0x3c, 0x2a, 0x9b, 0xbe, 0xb0, 0x26, 0x7d, 0xf3, 0x65, 0xf5,
-	0x13, 0x89, 0x77, 0x77, 0xf2, 0x52, 0x7f, 0x26, 0x2f, 0xe7, 0x61, 0xc4, 0xef, 0xee, 0x63, 0x00,
-	0x55, 0x5d, 0x06, 0xb3, 0x72, 0x8f, 0x73, 0xb0, 0xf5, 0xbb, 0xd0, 0xfe, 0xb2, 0xf8, 0xd1, 0xae,
-	0x33, 0xdd, 0xd1, 0x1f, 0xfb, 0xd7, 0x3c, 0x34, 0x25, 0x21, 0x45, 0xe7, 0xa6, 0x5d, 0xa4, 0x34,
-	0x7d, 0x80, 0x57, 0x18, 0xa1, 0xc2, 0xcd, 0x96, 0xb7, 0xd0, 0x3f, 0x85, 0xfd, 0x33, 0xce, 0x37,
-	0x9b, 0x6e, 0xea, 0xfe, 0xcf, 0x70, 0x6f, 0x74, 0x13, 0x07, 0xef, 0x42, 0x35, 0x75, 0x67, 0x60,
-	0x43, 0xe9, 0x36, 0x8f, 0x4f, 0xd3, 0xd2, 0x1e, 0xc6, 0x52, 0xf9, 0x71, 0x80, 0x5b, 0x0d, 0x5f,
-	0x1f, 0xf6, 0x46, 0x18, 0x45, 0x5b, 0xd9, 0xfc, 0x0a, 0x0f, 0x07, 0xa8, 0x86, 0x71, 0x20, 0x66,
-	0x89, 0x0e, 0x9c, 0x1d, 0x99, 0xbb, 0xdc, 0xa9, 0xcb, 0x8a, 0xf9, 0x03, 0x7b, 0xf2, 0x6f, 0x00,
-	0x00, 0x00, 0xff, 0xff, 0x93, 0x36, 0x29, 0x1b, 0x12, 0x0b, 0x00, 0x00,
+	// 4281 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0x03, 0xbd, 0x3b, 0x5d, 0x73, 0x23, 0xc7,
+	0x71, 0x02, 0x01, 0x90, 0x40, 0xe3, 0x83, 0xe0, 0xea, 0x0b, 0x07, 0xdd, 0x9d, 0xe4, 0xb5, 0x92,
+	0xc8, 0x27, 0xf9, 0xce, 0xba, 0xb3, 0x65, 0x59, 0x91, 0x7c, 0x01, 0x41, 0x90, 0x82, 0x44, 0x82,
+	0xd4, 0x00, 0xa7, 0xd3, 0xb9, 0xca, 0x05, 0xef, 0x01, 0x4b, 0x72, 0xeb, 0xc0, 0x5d, 0x78, 0x77,
+	0x71, 0x27, 0xbe, 0xd9, 0x6f, 0x29, 0x57, 0xd9, 0x2f, 0xae, 0xe4, 0xc5, 0x55, 0xa9, 0x3c, 0xe4,
+	0x25, 0xbf, 0x20, 0x95, 0xa4, 0x52, 0xf9, 0x49, 0xa9, 0x3c, 0xe7, 0x25, 0xdd, 0x3d, 0x33, 0x8b,
+	0xc5, 0x62, 0x01, 0x1c, 0xef, 0x64, 0xbf, 0x10, 0x33, 0x3d, 0x3d, 0x3d, 0xdd, 0x3d, 0x3d, 0x3d,
+	0xdd, 0xbd, 0x43, 0x28, 0x07, 0xb6, 0xff, 0xd4, 0xf6, 0x6f, 0x4f, 0x7c, 0x2f, 0xf4, 0x8c, 0xca,
+	0xc8, 0x09, 0x86, 0xde, 0x59, 0x20, 0x81, 0x8d, 0x0f, 0xcf, 0x9c, 0xf0, 0x7c, 0xfa, 0xf8, 0xf6,
+	0xd0, 0xbb, 0xb8, 0xf3, 0x18, 0x11, 0xce, 0x6d, 0x7f, 0xec, 0x9d, 0x39, 0xc3, 0x3b, 0x67, 0x9e,
+	0x42, 0x9c, 0xb5, 0x24, 0x05, 0xf3, 0x06, 0xe4, 0xfb, 0xde, 0x13, 0xdb, 0x35, 0x5e, 0x83, 0x7c,
+	0x48, 0x8d, 0x7a, 0xe6, 0x9d, 0xcc, 0x7b, 0x45, 0x21, 0x3b, 0xe6, 0xff, 0xe4, 0xa0, 0x26, 0xec,
+	0xa1, 0xe7, 0x8f, 0x5a, 0xde, 0x78, 0x6c, 0x0f, 0x43, 0xc7, 0x73, 0x8d, 0x9f, 0xc1, 0xd6, 0xa9,
+	0x37, 0x1e, 0xd9, 0x7e, 0x80, 0xc8, 0xd9, 0xf7, 0x4a, 0x77, 0xdf, 0xbe, 0x3d, 0xc7, 0xc7, 0xed,
+	0x19, 0xee, 0x3e, 0xe3, 0x09, 0x8d, 0x6f, 0x7c, 0x02, 0x85, 0x0b, 0x3b, 0xb4, 0x46, 0x56, 0x68,
+	0xd5, 0x37, 0x78, 0xee, 0xcd, 0xc4, 0x5c, 0x61, 0x8f, 0x6d, 0x2b, 0xb0, 0x8f, 0x14, 0x96, 0x88,
+	0xf0, 0x8d, 0x7b, 0x50, 0x78, 0x66, 0xb9, 0xe1, 0xd8, 0x09, 0xc2, 0x7a, 0x16, 0x99, 0x2c, 0xdd,
+	0x7d, 0x33, 0x31, 0xf7, 0xa1, 0x1a, 0x16, 0x11, 0xa2, 0xf1, 0x23, 0xc8, 0x0f, 0xad, 0xe1, 0xb9,
+	0x5d, 0xcf, 0xf1, 0x8c, 0x46, 0xfa, 0x6a, 0x87, 0x34, 0x49, 0x22, 0x1a, 0x3f, 0x80, 0xfc, 0x64,
+	0x6c, 0x5d, 0x06, 0xf5, 0x3c, 0xf3, 0xf7, 0x6a, 0x62, 0xc6, 0x09, 0x8e, 0x09, 0x89, 0x61, 0xfc,
+	0x2d, 0x94, 0x82, 0xe9, 0xd9, 0x99, 0x1d, 0x90, 0xa8, 0x41, 0x7d, 0x93, 0x27, 0x5c, 0x4b, 0x4c,
+	0xe8, 0x45, 0x18, 0x22, 0x8e, 0x6d, 0xdc, 0x85, 0xcd, 0x00, 0xd7, 0xbb, 0xb0, 0xea, 0x5b, 0xa9,
+	0xac, 0xed, 0x3b, 0xf6, 0x78, 0xd4, 0x63, 0x0c, 0xa1, 0x30, 0x8d, 0xfb, 0x50, 0x09, 0x2e, 0x2c,
+	0x3f, 0x1c, 0x68, 0xfd, 0x17, 0x78, 0xc9, 0xe4, 0xd4, 0x1e, 0xe1, 0x28, 0xd5, 0x97, 0x83, 0x59,
+	0x27, 0x30, 0x3e, 0x85, 0xb2, 0x9c, 0x3a, 0xf0, 0xa7, 0x63, 0x3b, 0xa8, 0x17, 0x53, 0x59, 0x56,
+	0x53, 0x11, 0x43, 0x94, 0x4e, 0xa3, 0x76, 0x60, 0xfc, 0x14, 0x80, 0xa6, 0x0d, 0xac, 0xe9, 0xc8,
+	0x09, 0xeb, 0xc0, 0x73, 0xeb, 0x49, 0x8d, 0x22, 0x42, 0x93, 0xc6, 0x45, 0xd1, 0xd7, 0x4d, 0xd2,
+	0xe9, 0xd8, 0xb3, 0x50, 0x45, 0xa5, 0x54, 0x9d, 0x1e, 0xe2, 0x98, 0x90, 0x18, 0xe6, 0x14, 0x6a,
+	0x49, 0xf3, 0xc1, 0xe9, 0x9b, 0x92, 0x0d, 0x36, 0xce, 0xd2, 0xdd, 0x9d, 0xdb, 0x33, 0x33, 0x56,
+	0xbc, 0x2a, 0x04, 0xe3, 0x23, 0x28, 0xf8, 0x72, 0x4f, 0x03, 0x34, 0xb0, 0x75, 0x5b, 0x1e, 0xe1,
+	0x9a, 0xff, 0x9b, 0x85, 0xed, 0x84, 0xe9, 0x19, 0x37, 0x00, 0xf0, 0x17, 0xc5, 0x1d, 0x8d, 0xec,
+	0x11, 0x2f, 0x9d, 0x15, 0x45, 0x82, 0x34, 0x09, 0x60, 0xfc, 0x15, 0x54, 0x79, 0xd8, 0xb7, 0x4f,
+	0x7d, 0x3b, 0x38, 0x47, 0x94, 0x0d, 0x46, 0xa9, 0x10, 0x54, 0x68, 0xa0, 0xf1, 0x16, 0x14, 0x4f,
+	0x1d, 0x54, 0xda, 0xc4, 0x0a, 0xcf, 0xd9, 0x6e, 0x8b, 0xa2, 0x40, 0x80, 0x13, 0xec, 0x1b, 0x06,
+	0xe4, 0x86, 0x1e, 0xda, 0x33, 0x59, 0x67, 0x5e, 0x70, 0xdb, 0x78, 0x03, 0x36, 0xf9, 0xe8, 0x92,
+	0x05, 0x66, 0xde, 0x2b, 0x08, 0xd5, 0x33, 0xaa, 0xb0, 0xe1, 0x8c, 0xd0, 0xc8, 0x08, 0x13, 0x5b,
+	0xc4, 0xde, 0xd8, 0x0a, 0xc2, 0x81, 0xb4, 0xef, 0x2d, 0xc9, 0x1e, 0x41, 0x5a, 0x6c, 0xc7, 0xdf,
+	0x83, 0x32, 0x0f, 0x87, 0xde, 0x74, 0x48, 0xcc, 0x15, 0x18, 0xa1, 0x44, 0xb0, 0xbe, 0x04, 0x91,
+	0x04, 0x74, 0x50, 0x06, 0xa7, 0xd3, 0x31, 0x72, 0x34, 0x46, 0xa4, 0xa2, 0x94, 0x80, 0xa0, 0xfb,
+	0x1a, 0x48, 0x0b, 0x31, 0xda, 0xc4, 0x77, 0x86, 0x36, 0x6e, 0x3b, 0x31, 0x50, 0x24, 0xc8, 0x09,
+	0x01, 0xc8, 0x90, 0x87, 0xd3, 0x20, 0xf4, 0x2e, 0xd4, 0xee, 0x26, 0x15, 0xde, 0xe2, 0x41, 0x36,
+	0x67, 0xa1, 0x30, 0x8d, 0xbf, 0x86, 0x5c, 0x68, 0x9d, 0x05, 0xf5, 0x32, 0xcf, 0x30, 0x12, 0x33,
+	0xfa, 0xd6, 0x99, 0xe0, 0x71, 0xa3, 0x0d, 0xdb, 0xa8, 0xc4, 0xf1, 0xe9, 0x60, 0xe2, 0x05, 0x8e,
+	0x3c, 0x65, 0x15, 0x9e, 0x72, 0x3d, 0x69, 0xf2, 0x84, 0x75, 0xa2, 0x90, 0x44, 0x35, 0x88, 0x77,
+	0x03, 0x72, 0x6e, 0xae, 0x17, 0xa2, 0x49, 0x54, 0xa5, 0x73, 0xe3, 0x8e, 0xf9, 0xdb, 0x0c, 0x54,
+	0xe6, 0xe6, 0x91, 0xa4, 0xca, 0x22, 0x06, 0x8e, 0xdc, 0x71, 0x94, 0x54, 0x41, 0x3a, 0x23, 0xe3,
+	0x6d, 0x28, 0x39, 0x6e, 0x10, 0x5a, 0xee, 0x90, 0xc7, 0x37, 0x78, 0x1c, 0x34, 0x08, 0x11, 0x70,
+	0x1d, 0x5e, 0x59, 0xed, 0xb3, 0xec, 0x18, 0x0d, 0x28, 0x68, 0xf6, 0xd5, 0x46, 0x47, 0x7d, 0xf3,
+	0xef, 0x33, 0x50, 0x3e, 0xf6, 0xc9, 0x82, 0xed, 0x5f, 0x4f, 0xd1, 0x35, 0xb0, 0xb9, 0xc8, 0x13,
+	0x1a, 0x71, 0x50, 0x90, 0x00, 0xa4, 0x7f, 0x07, 0xf2, 0x1e, 0x21, 0xf3, 0xd2, 0xd5, 0x45, 0x57,
+	0x43, 0xcb, 0x49, 0x6a, 0x12, 0x8f, 0x18, 0xb2, 0x26, 0x93, 0xf1, 0x25, 0x33, 0x54, 0x10, 0xb2,
+	0x33, 0x63, 0x33, 0x17, 0x63, 0xd3, 0x0c, 0x01, 0x98, 0x40, 0xdb, 0x0d, 0xfd, 0x4b, 0xe3, 0x03,
+	0xd8, 0x52, 0x82, 0xab, 0x43, 0x67, 0xc4, 0x0e, 0x9d, 0x3a, 0x29, 0x42, 0xa3, 0x18, 0x1f, 0xc7,
+	0x44, 0x94, 0xc7, 0x6e, 0xf5, 0x06, 0xcd, 0x14, 0xf0, 0x77, 0x50, 0xe4, 0x21, 0x3a, 0x8f, 0xe8,
+	0xe2, 0xb7, 0x6c, 0x5c, 0xdd, 0xb1, 0xf5, 0xcd, 0x92, 0x2a, 0x21, 0x33, 0x28, 0x34, 0xa6, 0xf9,
+	0x87, 0x0c, 0xd4, 0x3a, 0x2e, 0x22, 0x30, 0x65, 0xa5, 0xc6, 0xab, 0xb1, 0x3f, 0xa7, 0xf4, 0x8d,
+	0x65, 0x4a, 0xcf, 0x3e, 0x9f, 0xd2, 0xcd, 0xff, 0xc8, 0xc0, 0x4e, 0x8c, 0xa1, 0x60, 0x82, 0x26,
+	0x68, 0xcf, 0x59, 0x41, 0x66, 0xde, 0x0a, 0x8c, 0x5b, 0xb0, 0xf9, 0xd8, 0x3e, 0xf5, 0x7c, 0x5b,
+	0x29, 0x2f, 0x8d, 0x59, 0x85, 0x61, 0xbc, 0x87, 0x5b, 0x7a, 0x1a, 0x2a, 0x76, 0xd2, 0x51, 0x25,
+	0x02, 0x69, 0x33, 0x38, 0x77, 0xb0, 0x39, 0xc2, 0x8d, 0x5e, 0xa7, 0x4d, 0x85, 0x69, 0x7e, 0x02,
+	0x59, 0x3c, 0x7e, 0xe4, 0x98, 0x5c, 0xeb, 0xc2, 0x56, 0xd1, 0x00, 0xb7, 0xd7, 0x9a, 0xbf, 0xe9,
+	0x01, 0xd0, 0xd1, 0x7d, 0xa1, 0x2d, 0x30, 0x94, 0x47, 0xa0, 0xa8, 0xa0, 0xa8, 0x4e, 0x7f, 0x62,
+	0xc1, 0xec, 0xc2, 0x82, 0x37, 0xa1, 0x80, 0x0b, 0x7e, 0x35, 0xb5, 0xd1, 0x60, 0x35, 0x81, 0xcc,
+	0x8c, 0x80, 0x79, 0x07, 0x8a, 0xcc, 0x10, 0xb3, 0x8f, 0x08, 0xa7, 0x3e, 0x7a, 0x29, 0x25, 0x12,
+	0xb5, 0xc9, 0xa7, 0x86, 0x1e, 0x4b, 0x52, 0x14, 0xd8, 0x32, 0xef, 0x32, 0xc1, 0x96, 0x37, 0x75,
+	0x43, 0xa3, 0x06, 0x59, 0x24, 0xa2, 0xd0, 0xa9, 0x49, 0xe7, 0x66, 0x48, 0x43, 0x4a, 0x74, 0xd9,
+	0x31, 0xef, 0x43, 0x59, 0xcf, 0x61, 0x23, 0xbe, 0x83, 0xfe, 0x90, 0x3a, 0xda, 0x86, 0xdf, 0x5c,
+	0xf4, 0x6e, 0x8c, 0x2c, 0x14, 0x9a, 0x79, 0x01, 0xdb, 0xec, 0x1d, 0xf7, 0xec, 0x53, 0xc7, 0x95,
+	0x06, 0x91, 0xa6, 0xfe, 0x0f, 0x50, 0xc0, 0xcb, 0x89, 0xad, 0xce, 0x7e, 0x3d, 0x2d, 0x5c, 0xe8,
+	0xe3, 0xb8, 0x60, 0x2c, 0xa3, 0x0e, 0x5b, 0xde, 0x44, 0x7a, 0xcc, 0x2c, 0x6b, 0x44, 0x77, 0xcd,
+	0x36, 0x94, 0x62, 0xb1, 0x05, 0xde, 0x98, 0x9b, 0xa7, 0xd4, 0xd5, 0xec, 0xde, 0x4c, 0x23, 0x3c,
+	0x63, 0x4d, 0x28, 0x6c, 0xf3, 0x1b, 0x28, 0xc5, 0x3c, 0x7b, 0x2a, 0xc7, 0xa8, 0xaf, 0xa7, 0xd6,
+	0x78, 0x6a, 0x2b, 0x05, 0xcb, 0xce, 0xfa, 0x5d, 0xfd, 0x53, 0x06, 0x36, 0x65, 0xd0, 0x79, 0x45,
+	0x1b, 0x9a, 0x8f, 0x2e, 0x33, 0x57, 0x8a, 0x2e, 0xff, 0x06, 0x72, 0x14, 0x80, 0xa8, 0x53, 0x95,
+	0x1a, 0xa1, 0x30, 0x82, 0xb9, 0x05, 0xf9, 0xf6, 0xc5, 0x24, 0xbc, 0x34, 0x7f, 0x06, 0x20, 0x83,
+	0x0f, 0xde, 0xf5, 0xf7, 0x93, 0x41, 0x71, 0x4a, 0x90, 0xa2, 0x31, 0xcc, 0xcf, 0xa0, 0x14, 0x0b,
+	0x43, 0x8c, 0xdb, 0xb1, 0xa0, 0x25, 0xa3, 0x6e, 0xc4, 0x45, 0x31, 0x67, 0xc1, 0xca, 0x67, 0x00,
+	0x52, 0x3f, 0xca, 0xde, 0x50, 0x01, 0xd4, 0xd3, 0x93, 0x5f, 0x5f, 0x10, 0x9a, 0x46, 0x85, 0xc6,
+	0x32, 0x07, 0xd1, 0xea, 0x47, 0xde, 0x53, 0xfb, 0x8a, 0x3a, 0x36, 0xa1, 0xe2, 0xda, 0xcf, 0x06,
+	0x49, 0x77, 0x59, 0x42, 0xe0, 0xbe, 0xf2, 0x98, 0xe6, 0xaf, 0xa0, 0x2c, 0xdb, 0x7b, 0x38, 0x29,
+	0xb4, 0xaf, 0x12, 0xbf, 0x51, 0x50, 0x45, 0x11, 0xb2, 0x6b, 0x91, 0xb1, 0xcd, 0xe8, 0x57, 0x62,
+	0x50, 0x5c, 0xe1, 0x29, 0x54, 0xf5, 0x1e, 0x3e, 0x98, 0x50, 0xbc, 0x75, 0x45, 0x29, 0xd0, 0xe8,
+	0xa7, 0x3c, 0xef, 0x39, 0xed, 0x44, 0x61, 0x9b, 0x2d, 0xd8, 0xde, 0x9d, 0x8e, 0x9f, 0x90, 0xde,
+	0xb4, 0x9b, 0xc3, 0x0c, 0xe3, 0x02, 0xbb, 0x5a, 0xf9, 0x4b, 0xc2, 0x4d, 0x9e, 0x21, 0x11, 0xcd,
+	0x2e, 0xbc, 0xca, 0x44, 0x34, 0x71, 0x45, 0xe8, 0xa7, 0xb0, 0x25, 0x57, 0xd1, 0xa4, 0x6e, 0x24,
+	0x48, 0xcd, 0x4b, 0x2c, 0x34, 0x36, 0xba, 0xdd, 0xed, 0xe3, 0x89, 0xed, 0x5b, 0xea, 0xba, 0x99,
+	0x8e, 0xaf, 0xea, 0x7b, 0xd1, 0x57, 0x04, 0xd3, 0xe1, 0xd0, 0x0e, 0x64, 0xcc, 0x5c, 0x10, 0xba,
+	0x4b, 0x27, 0xd8, 0xf6, 0x7d, 0xcf, 0xd7, 0x01, 0x0d, 0x77, 0xcc, 0xcf, 0xa1, 0x4c, 0x02, 0x44,
+	0x57, 0xdb, 0xc7, 0xb4, 0x1a, 0xad, 0xbb, 0xcc, 0x87, 0x24, 0xd8, 0x13, 0x1a, 0xdd, 0xfc, 0xbf,
+	0x0d, 0xc8, 0x51, 0xd6, 0xb6, 0x2e, 0xf2, 0xc2, 0x98, 0x98, 0x9d, 0xc7, 0x48, 0x31, 0xa8, 0x7a,
+	0x04, 0xa7, 0x40, 0xd4, 0x1e, 0xa9, 0x00, 0x47, 0xf5, 0xf8, 0xb2, 0xf5, 0x1d, 0xcf, 0x77, 0xc2,
+	0xcb, 0x28, 0xe4, 0x52, 0x7d, 0xf6, 0x54, 0x18, 0xff, 0x71, 0x74, 0x4d, 0x9e, 0x0a, 0xdb, 0x14,
+	0x00, 0x5c, 0x58, 0xdf, 0xaa, 0x08, 0x57, 0x86, 0xd8, 0x05, 0x04, 0xc8, 0x00, 0x77, 0x3e, 0x0f,
+	0xd8, 0x4a, 0xe6, 0x01, 0xfa, 0xe2, 0x29, 0xc4, 0x6e, 0x2e, 0xa6, 0x17, 0x84, 0xf2, 0x84, 0x14,
+	0x35, 0x3d, 0x02, 0x48, 0x61, 0xf0, 0x26, 0xbf, 0xb0, 0x42, 0x8e, 0xa5, 0x8b, 0x42, 0xf5, 0x68,
+	0x1b, 0xf8, 0x46, 0xf0, 0x2f, 0x31, 0x92, 0xa6, 0x01, 0xdd, 0x35, 0xae, 0xa1, 0x63, 0x73, 0xdc,
+	0xc1, 0xa5, 0x6d, 0xf9, 0x18, 0x32, 0x13, 0xb5, 0x2d, 0xec, 0x3f, 0xc2, 0x2e, 0x0f, 0x21, 0xe7,
+	0x3c, 0x54, 0x51, 0x43, 0xd6, 0xb7, 0x3c, 0x84, 0x8e, 0x56, 0x9a, 0xc8, 0x00, 0x97, 0x7e, 0x82,
+	0xb1, 0x2f, 0xf1, 0x07, 0x12, 0x74, 0x84, 0x10, 0xf3, 0x1f, 0x33, 0xb0, 0x1d, 0xe5, 0xcc, 0xca,
+	0x0a, 0x31, 0x6d, 0xa0, 0xa5, 0x22, 0xed, 0xc9, 0xad, 0x28, 0x21, 0xec, 0x24, 0xa6, 0xc0, 0xb4,
+	0xab, 0x5a, 0xaa, 0x7e, 0xe0, 0xb9, 0x51, 0xb8, 0x09, 0x12, 0x74, 0x8c, 0x10, 0xf4, 0x8f, 0xb9,
+	0xc0, 0xf3, 0x65, 0xa6, 0x53, 0x4d, 0xcd, 0xdc, 0x7b, 0x38, 0x2c, 0x18, 0x09, 0x83, 0xc2, 0x6d,
+	0x4a, 0x02, 0xad, 0x49, 0x10, 0x1d, 0xb3, 0x1f, 0x82, 0xa1, 0x79, 0x1a, 0x84, 0xe7, 0x94, 0x5b,
+	0xa1, 0xc3, 0x50, 0xdc, 0xed, 0xe8, 0x91, 0xbe, 0x1e, 0x30, 0xef, 0x41, 0x41, 0x4b, 0x46, 0xae,
+	0x9d, 0x18, 0x51, 0xb6, 0xf9, 0x6a, 0xca, 0xd2, 0x82, 0x11, 0xcc, 0x6f, 0xa1, 0xdc, 0x9b, 0xd8,
+	0xee, 0x48, 0xaf, 0xf9, 0x1a, 0x1d, 0x6d, 0x17, 0xd3, 0x36, 0xb9, 0x8c, 0xec, 0x90, 0xf8, 0xac,
+	0x6d, 0xe9, 0x98, 0xb8, 0x4d, 0x98, 0x63, 0xef, 0x99, 0x0a, 0xca, 0xb2, 0x42, 0x76, 0x08, 0x3a,
+	0x9d, 0xa0, 0xe9, 0xb3, 0xd0, 0x08, 0xe5, 0x4e, 0xa4, 0xbe, 0x7c, 0x2c, 0x50, 0x39, 0xc3, 0x4c,
+	0x44, 0xae, 0xac, 0x8e, 0x14, 0xea, 0x33, 0xf4, 0x42, 0x6b, 0x3c, 0x08, 0x08, 0xac, 0x18, 0x00,
+	0x06, 0x31, 0xa2, 0xf1, 0x13, 0xd8, 0xe4, 0xa1, 0x40, 0xd5, 0x51, 0xd6, 0x38, 0x0b, 0x85, 0x8c,
+	0xb1, 0x46, 0xa5, 0x87, 0x0c, 0x0f, 0xcf, 0x63, 0x32, 0xfe, 0x9a, 0xe2, 0x27, 0x5d, 0xf7, 0xe1,
+	0x0e, 0xe5, 0x74, 0x2a, 0x28, 0xd8, 0x58, 0x9f, 0xd3, 0x49, 0xcc, 0x48, 0xae, 0x6c, 0x4c, 0xae,
+	0xfb, 0x98, 0x53, 0x5c, 0xba, 0x43, 0xe5, 0x95, 0x3e, 0xc4, 0x30, 0x3b, 0x4a, 0x35, 0x57, 0xec,
+	0xc6, 0x0c, 0xcb, 0xfc, 0xaf, 0x0c, 0xbc, 0xde, 0xb3, 0xc7, 0xe3, 0x96, 0xe5, 0x8e, 0x1c, 0x76,
+	0x77, 0x9a, 0x71, 0x3c, 0x95, 0x64, 0xf8, 0xe4, 0x57, 0xdc, 0x33, 0xed, 0x31, 0x10, 0x22, 0x18,
+	0x60, 0x7c, 0x1f, 0x2a, 0x78, 0x76, 0x64, 0xa2, 0x8b, 0xb7, 0x95, 0xaf, 0x92, 0xf3, 0x72, 0x04,
+	0xdc, 0xf7, 0x7c, 0x3e, 0xa6, 0x68, 0xec, 0x32, 0x48, 0xa1, 0xad, 0xdb, 0x10, 0x74, 0xd0, 0xbe,
+	0xe6, 0x38, 0x05, 0x2d, 0xce, 0x71, 0x87, 0xe3, 0xe9, 0xc8, 0x1e, 0x8c, 0xa6, 0x93, 0xb1, 0x33,
+	0x64, 0xd7, 0x9c, 0x63, 0xcb, 0xde, 0x51, 0x23, 0x7b, 0xd1, 0x00, 0x9b, 0x80, 0x73, 0xe1, 0x84,
+	0xec, 0x57, 0xd0, 0x58, 0xb8, 0x63, 0xfe, 0x37, 0xe5, 0x98, 0x71, 0xfe, 0xff, 0x82, 0x21, 0x4d,
+	0x14, 0x7e, 0x49, 0xc9, 0x54, 0xf8, 0x45, 0xc9, 0xdf, 0x90, 0x52, 0x0d, 0xe9, 0x17, 0x65, 0x87,
+	0x7c, 0x8f, 0x8f, 0x64, 0x28, 0x5c, 0x94, 0x76, 0xa9, 0xbb, 0xe6, 0x57, 0xb0, 0x33, 0x27, 0x00,
+	0xc7, 0x1c, 0x9f, 0x02, 0x0c, 0xa3, 0x1d, 0x51, 0x5b, 0xb9, 0x90, 0xf1, 0xc5, 0x67, 0x89, 0x18,
+	0xbe, 0xd9, 0x94, 0xb7, 0x28, 0x21, 0xe8, 0xdd, 0xbc, 0x6a, 0x08, 0xf4, 0x9b, 0x0c, 0x5a, 0x16,
+	0xcf, 0x7f, 0x81, 0xfb, 0x0e, 0x55, 0x20, 0x3d, 0xfd, 0x86, 0x54, 0x0c, 0x77, 0xe2, 0xb7, 0x60,
+	0x76, 0xc9, 0x2d, 0x98, 0x8b, 0xdf, 0x82, 0x07, 0x50, 0x9b, 0x49, 0xa1, 0x8e, 0xed, 0xbd, 0xe4,
+	0x4d, 0x78, 0x2d, 0x45, 0x29, 0xc9, 0x4b, 0xf0, 0x9f, 0x33, 0x90, 0xa3, 0xb2, 0xe2, 0x4b, 0x97,
+	0x1f, 0xf0, 0x04, 0x72, 0x4c, 0x23, 0x9d, 0x10, 0xb7, 0x09, 0x16, 0x38, 0x23, 0x5b, 0xb1, 0xce,
+	0x6d, 0xae, 0xf5, 0xfa, 0xd6, 0xf0, 0x89, 0x36, 0x55, 0xee, 0xd0, 0x9d, 0x49, 0xee, 0xd2, 0x76,
+	0xd1, 0x61, 0x6d, 0xca, 0x3a, 0x95, 0xee, 0x9b, 0x3f, 0x81, 0x02, 0x71, 0xc8, 0x7b, 0x1f, 0x15,
+	0x48, 0x33, 0xeb, 0x0a, 0xa4, 0xe6, 0x3f, 0x64, 0xa0, 0xc4, 0xfd, 0xd9, 0x99, 0x7d, 0xe9, 0xfa,
+	0x0a, 0x9e, 0x3c, 0x2d, 0xa1, 0xec, 0xb0, 0x9b, 0x75, 0x43, 0x67, 0x1c, 0xb9, 0x59, 0xea, 0xcc,
+	0x89, 0x93, 0x4f, 0x88, 0xd3, 0x81, 0x1a, 0xb1, 0x25, 0xd3, 0x30, 0xc5, 0x1b, 0x2e, 0x4e, 0x7c,
+	0x84, 0x83, 0x53, 0xc7, 0x0f, 0x42, 0x66, 0x0e, 0x6f, 0x30, 0x06, 0xed, 0x13, 0x64, 0x76, 0xc0,
+	0x37, 0xe2, 0x07, 0x7c, 0x02, 0xc5, 0x88, 0xd4, 0xd5, 0xcd, 0x70, 0x31, 0x9d, 0x64, 0x3e, 0x88,
+	0x0d, 0xd2, 0xa0, 0x8a, 0x6b, 0xb2, 0x82, 0x2b, 0x7d, 0x27, 0x0c, 0xc1, 0xd3, 0x53, 0x89, 0x56,
+	0x3c, 0x94, 0x35, 0xee, 0xf9, 0x84, 0xb3, 0x9e, 0xb2, 0x23, 0xf3, 0x19, 0xe7, 0x87, 0x60, 0x74,
+	0x6d, 0x1c, 0x92, 0x14, 0x63, 0xa5, 0x27, 0xef, 0x99, 0xab, 0xdc, 0xa5, 0x2c, 0x77, 0x16, 0x18,
+	0x80, 0xae, 0xd2, 0x7c, 0x1f, 0xfd, 0x58, 0x88, 0x3e, 0xe1, 0x89, 0xc6, 0x8e, 0xeb, 0x37, 0x93,
+	0xd0, 0xef, 0xef, 0x32, 0x50, 0xd5, 0xd8, 0xea, 0x64, 0x50, 0x70, 0x33, 0xf5, 0x7d, 0xdb, 0x0d,
+	0xd5, 0xbe, 0xeb, 0x2e, 0x8d, 0x8c, 0x3d, 0x97, 0xea, 0xe2, 0x4a, 0x11, 0xba, 0x4b, 0x3e, 0x5c,
+	0x35, 0x07, 0x68, 0x02, 0x7e, 0xa8, 0x94, 0x51, 0x56, 0xc0, 0x1e, 0xc1, 0x58, 0x5f, 0x0a, 0x89,
+	0x6e, 0xca, 0x9c, 0xd2, 0x97, 0x04, 0xb5, 0xdd, 0x91, 0xf9, 0x9f, 0x19, 0xf4, 0x60, 0xb3, 0x22,
+	0xbc, 0x62, 0xbf, 0x3e, 0x9f, 0xaf, 0xe5, 0x67, 0xdf, 0x28, 0x3e, 0x83, 0x42, 0x80, 0x27, 0x22,
+	0xb4, 0xcf, 0x2e, 0x55, 0xae, 0xfd, 0xbd, 0xa5, 0x25, 0xfd, 0x9e, 0x42, 0x14, 0xd1, 0x14, 0xda,
+	0xd5, 0x33, 0xdb, 0xf5, 0x6d, 0x1d, 0x32, 0x73, 0x87, 0x2d, 0x37, 0xbc, 0x1c, 0xdb, 0x51, 0xc9,
+	0x8d, 0x3a, 0x14, 0x6c, 0x59, 0x4f, 0x3d, 0x67, 0x34, 0x78, 0xe6, 0xb8, 0x23, 0xef, 0x19, 0xdb,
+	0x69, 0x56, 0x94, 0x18, 0xf6, 0x90, 0x41, 0x98, 0x4b, 0xc1, 0x6c, 0xb9, 0x3f, 0x87, 0x87, 0x30,
+	0x7f, 0x9f, 0x81, 0x62, 0xcb, 0x43, 0xe7, 0xcc, 0x2b, 0xdc, 0x81, 0x3c, 0xdf, 0xe7, 0x4c, 0x3c,
+	0xa5, 0xda, 0xc5, 0x5f, 0x13, 0xf8, 0xde, 0x97, 0x78, 0x06, 0xa6, 0xc1, 0x43, 0xef, 0x62, 0x62,
+	0xf9, 0x4e, 0xa0, 0x8a, 0x7f, 0x8b, 0xb3, 0x5a, 0x11, 0x82, 0x88, 0x21, 0xcf, 0x5f, 0x50, 0xba,
+	0x3e, 0x60, 0xfa, 0x50, 0x8a, 0x7d, 0xc0, 0xb8, 0x4a, 0xf2, 0xf8, 0x31, 0xb1, 0xa2, 0x04, 0xd1,
+	0x91, 0x4b, 0x7d, 0x81, 0x15, 0x85, 0x20, 0x62, 0xb8, 0xe8, 0xcb, 0xb7, 0x63, 0x6b, 0xf2, 0xa9,
+	0xfa, 0x71, 0x32, 0xa1, 0x5f, 0xf5, 0x95, 0x25, 0xca, 0xec, 0x9f, 0xe9, 0xa2, 0x00, 0x7d, 0x07,
+	0x49, 0x2d, 0x8a, 0xbc, 0x30, 0x93, 0xf3, 0x55, 0xca, 0xec, 0x7c, 0x95, 0x12, 0xbd, 0x42, 0x75,
+	0xb6, 0xb0, 0xaa, 0x0b, 0xe4, 0xe5, 0x47, 0x9e, 0xcc, 0xba, 0x8f, 0x3c, 0x12, 0x8f, 0x62, 0x95,
+	0x62, 0xf4, 0xf9, 0x86, 0x78, 0x27, 0xb0, 0xe6, 0x9d, 0xda, 0x71, 0xff, 0xb6, 0xb1, 0xde, 0xbf,
+	0xbd, 0x0b, 0x55, 0x2a, 0xb2, 0x0d, 0x92, 0x4c, 0x97, 0x09, 0xaa, 0x8b, 0x05, 0xc6, 0x3b, 0x50,
+	0x0e, 0xbd, 0x18, 0x4e, 0x4e, 0x87, 0xba, 0x11, 0x86, 0x36, 0xda, 0x7c, 0xec, 0x5a, 0x8b, 0xae,
+	0xe4, 0xcd, 0xf8, 0x95, 0x8c, 0xae, 0x31, 0x12, 0x40, 0xbb, 0x46, 0xfe, 0x58, 0xb5, 0xcc, 0x35,
+	0xce, 0xbe, 0x56, 0x29, 0x3c, 0xf3, 0x5f, 0xf0, 0x32, 0xa6, 0x6a, 0xcf, 0x4b, 0x1f, 0x35, 0xf4,
+	0x8f, 0x8f, 0x3d, 0xe4, 0x4a, 0x67, 0x05, 0xe8, 0x1f, 0x75, 0x9f, 0x24, 0x1a, 0x93, 0x27, 0x94,
+	0xce, 0x8a, 0xdb, 0x54, 0x6e, 0x1c, 0x4d, 0xb5, 0x90, 0xd4, 0x24, 0x0a, 0xbe, 0x1d, 0x4e, 0x7d,
+	0xf4, 0xc0, 0x2c, 0x66, 0x56, 0x44, 0x7d, 0xf3, 0xdf, 0xf0, 0x66, 0xe5, 0xa2, 0xd4, 0x77, 0x74,
+	0xb3, 0xae, 0xe2, 0x16, 0xbd, 0x94, 0x87, 0xca, 0x42, 0x96, 0x64, 0x72, 0x27, 0x43, 0xe0, 0x92,
+	0x82, 0x71, 0x76, 0xf7, 0x03, 0xa8, 0xe9, 0x58, 0x39, 0x62, 0x59, 0x7e, 0xbd, 0xda, 0x56, 0x70,
+	0xa1, 0x39, 0xc7, 0x50, 0x82, 0x18, 0xd7, 0xa1, 0x84, 0xfc, 0x2e, 0x98, 0x59, 0xfb, 0x5d, 0xf0,
+	0x0b, 0xa8, 0xb4, 0xbf, 0x9d, 0x50, 0x8a, 0x38, 0x73, 0xe0, 0x43, 0x6f, 0x3c, 0xbd, 0x70, 0x75,
+	0xc9, 0x57, 0x77, 0x49, 0x58, 0xce, 0x7a, 0x55, 0x92, 0x2d, 0x8b, 0x8f, 0x9c, 0xc2, 0xef, 0x33,
+	0xc4, 0x7c, 0x1b, 0x8a, 0x8a, 0x96, 0xf7, 0x8c, 0xf7, 0xc2, 0x71, 0x23, 0x3b, 0xa7, 0x36, 0xda,
+	0xd1, 0x6b, 0x7b, 0x92, 0x93, 0xce, 0x45, 0x7c, 0x4d, 0xdc, 0xa3, 0x61, 0xf0, 0x54, 0x97, 0x84,
+	0xb1, 0x69, 0xbc, 0x09, 0x5b, 0x23, 0xff, 0x72, 0xe0, 0x4f, 0x5d, 0x5d, 0x99, 0xc0, 0xae, 0x98,
+	0xba, 0xe6, 0x3f, 0x65, 0xa0, 0x2c, 0x27, 0xb7, 0xce, 0x2d, 0xbc, 0x8b, 0xbe, 0x8b, 0xd8, 0x47,
+	0x3a, 0x66, 0xe5, 0x2c, 0xa5, 0xf7, 0xa5, 0x3b, 0x7b, 0x3c, 0x52, 0x19, 0x8c, 0xbc, 0x5b, 0x0a,
+	0x08, 0x90, 0x19, 0x0c, 0x0e, 0x52, 0xad, 0x4e, 0x0e, 0xaa, 0x18, 0x08, 0x01, 0x3c, 0x68, 0xfe,
+	0x52, 0xf3, 0x27, 0x6c, 0xfa, 0x8b, 0x09, 0xe5, 0xd6, 0x90, 0x39, 0xd5, 0xbb, 0xf1, 0x56, 0x62,
+	0x37, 0xe2, 0xd2, 0x08, 0x8d, 0x4b, 0xc5, 0x0c, 0x3e, 0x7b, 0xba, 0x1c, 0xa0, 0x7a, 0xe6, 0x1e,
+	0x54, 0x76, 0x31, 0xaa, 0x9c, 0x4e, 0xb4, 0xee, 0xee, 0x45, 0x55, 0x0f, 0x79, 0xb3, 0x24, 0xc9,
+	0x4b, 0x6c, 0xb9, 0x43, 0xba, 0x24, 0x62, 0xfe, 0x2b, 0x06, 0x12, 0x18, 0x42, 0x84, 0x98, 0xa2,
+	0x68, 0x3a, 0xd2, 0x1b, 0x58, 0x7a, 0xbf, 0xd4, 0xd3, 0x80, 0xcd, 0xd8, 0x66, 0x3f, 0x1f, 0x6d,
+	0xca, 0xa0, 0x27, 0x1e, 0x66, 0x6f, 0x97, 0xea, 0xc3, 0xce, 0x8d, 0x45, 0x27, 0x7c, 0x8a, 0xc3,
+	0xe1, 0x09, 0x23, 0x09, 0x85, 0x1c, 0xdf, 0xf1, 0xdc, 0xdc, 0x8e, 0x1f, 0xa1, 0xf3, 0x91, 0xac,
+	0xaa, 0x1d, 0x47, 0x4e, 0x9f, 0x38, 0x2a, 0x79, 0x47, 0x4e, 0xa9, 0x4d, 0x16, 0xf4, 0xc4, 0xbe,
+	0x54, 0x36, 0x49, 0x4d, 0x52, 0xa0, 0xc5, 0x1f, 0xbb, 0xd5, 0xc6, 0xaa, 0x1e, 0x5e, 0x49, 0x95,
+	0x48, 0x72, 0xde, 0xa0, 0x8f, 0x92, 0x1b, 0x74, 0x7d, 0x21, 0x13, 0x8c, 0xad, 0x1e, 0xed, 0xd0,
+	0xad, 0xcf, 0xd5, 0x77, 0x3d, 0xfe, 0x46, 0x65, 0x54, 0xa0, 0xb8, 0xfb, 0x68, 0xd0, 0x14, 0xfd,
+	0x4e, 0xaf, 0x5f, 0x7b, 0xc5, 0x28, 0x43, 0x01, 0xbb, 0x87, 0xcd, 0xdd, 0xf6, 0x61, 0x2d, 0x63,
+	0xec, 0xe0, 0xa6, 0x3d, 0x1a, 0xec, 0x35, 0xfb, 0xed, 0x41, 0x73, 0x6f, 0xaf, 0xbd, 0x57, 0xdb,
+	0x30, 0x00, 0x36, 0x5b, 0x0f, 0x7a, 0xfd, 0xe3, 0xa3, 0x5a, 0xf6, 0xd6, 0xcf, 0xa1, 0x18, 0x7d,
+	0x66, 0xa0, 0x81, 0x5e, 0x5f, 0x74, 0xba, 0x07, 0x48, 0x65, 0x0b, 0xb2, 0x9d, 0x6e, 0x1f, 0x09,
+	0x14, 0x20, 0x47, 0xb3, 0x71, 0x1e, 0xb6, 0xda, 0xdd, 0x07, 0x38, 0x8b, 0x5a, 0xbb, 0xc7, 0xc7,
+	0x87, 0xb5, 0xdc, 0xad, 0xae, 0x2c, 0xca, 0x50, 0xa1, 0x87, 0x16, 0x7e, 0xd0, 0xed, 0x1d, 0x8b,
+	0x3e, 0xae, 0xf2, 0x8a, 0xb1, 0x0d, 0x25, 0x5c, 0xf8, 0x44, 0x74, 0x8e, 0x45, 0xa7, 0xff, 0x28,
+	0x9d, 0x93, 0x1a, 0x94, 0x11, 0x74, 0xd4, 0xfc, 0x86, 0xf0, 0x5a, 0x6d, 0xe4, 0xe7, 0x1c, 0x8c,
+	0xc5, 0x50, 0xcc, 0xb8, 0x0e, 0xf5, 0xc3, 0x76, 0xb3, 0xd7, 0x1f, 0x88, 0x76, 0xab, 0xdd, 0xed,
+	0x1f, 0x3e, 0x1a, 0xf4, 0x1e, 0x1c, 0x1c, 0xb4, 0x7b, 0x72, 0xa5, 0x37, 0xc0, 0x78, 0xd8, 0xee,
+	0x1c, 0x7c, 0x8e, 0xbd, 0x01, 0x92, 0x13, 0xcd, 0x3e, 0x89, 0x90, 0x21, 0xea, 0xdd, 0xf6, 0x43,
+	0xc4, 0x1a, 0xec, 0x77, 0x04, 0xaa, 0x86, 0x25, 0x17, 0xcd, 0xee, 0x1e, 0x4b, 0xfe, 0x47, 0x4a,
+	0x37, 0xa3, 0xd0, 0x47, 0x0e, 0xf5, 0xa5, 0xec, 0x28, 0x48, 0xf3, 0xa0, 0x8d, 0xac, 0x3e, 0xea,
+	0x21, 0x99, 0x22, 0xe4, 0xa5, 0x32, 0x79, 0xbe, 0x52, 0x73, 0x96, 0xc0, 0xfd, 0x4e, 0xff, 0xb0,
+	0x5d, 0xcb, 0x51, 0xf3, 0xa0, 0xdd, 0x15, 0xed, 0x5a, 0x9e, 0x9a, 0xbd, 0xfe, 0x23, 0x84, 0x6e,
+	0x12, 0xf2, 0xfe, 0xb1, 0x38, 0x6a, 0xf6, 0x6b, 0x5b, 0xa4, 0xcd, 0x7e, 0xf3, 0xa0, 0x56, 0x90,
+	0xc0, 0xc3, 0xbd, 0xb6, 0xa8, 0x15, 0x49, 0x8b, 0xad, 0x63, 0xa4, 0x05, 0xd4, 0x7a, 0xd4, 0x6e,
+	0x8a, 0x5a, 0x09, 0xe5, 0x87, 0x59, 0x64, 0x45, 0xd8, 0xed, 0xaf, 0x1e, 0x34, 0x0f, 0x7b, 0xc8,
+	0x54, 0x15, 0xa0, 0x7b, 0xdc, 0x1f, 0xa8, 0x7e, 0xc6, 0x28, 0xc1, 0xd6, 0x81, 0x68, 0xa3, 0x36,
+	0x05, 0x32, 0x46, 0x1c, 0xf7, 0x07, 0xac, 0x23, 0xb9, 0x3d, 0x87, 0xed, 0x5e, 0x0f, 0x39, 0x43,
+	0x24, 0x84, 0x1f, 0xd1, 0x2a, 0x79, 0x42, 0x6a, 0x1d, 0x77, 0xfb, 0xcd, 0x4e, 0xb7, 0x57, 0xdb,
+	0xbc, 0xf5, 0x2e, 0xea, 0x3e, 0x76, 0x86, 0x68, 0xd2, 0x17, 0xbd, 0xe3, 0x2e, 0xae, 0x84, 0xab,
+	0x76, 0xf7, 0xb8, 0x9d, 0xb9, 0xf5, 0x63, 0xa8, 0xce, 0x1f, 0x1a, 0xc2, 0xeb, 0x7d, 0xd9, 0x39,
+	0x41, 0x3c, 0xb4, 0xbb, 0xe3, 0xaf, 0xdb, 0xe2, 0x21, 0xee, 0x6f, 0x5b, 0xea, 0xe9, 0xa8, 0x2d,
+	0x0e, 0xd0, 0x52, 0xee, 0xfe, 0xbb, 0x09, 0x55, 0xe5, 0x6d, 0x7b, 0x68, 0xc8, 0x94, 0x8a, 0xb7,
+	0xa0, 0x72, 0x60, 0x87, 0xb1, 0x27, 0x47, 0xaf, 0x25, 0x4c, 0x9d, 0xbf, 0xc0, 0x34, 0x56, 0x3c,
+	0xed, 0x30, 0x5f, 0x31, 0x8e, 0xe0, 0x55, 0x24, 0xa2, 0x60, 0x41, 0x47, 0x3f, 0x26, 0x49, 0x8f,
+	0x83, 0x68, 0x4e, 0xe3, 0x5a, 0xea, 0x87, 0x13, 0x45, 0x6e, 0x17, 0xca, 0x54, 0xc4, 0xef, 0xab,
+	0xb8, 0xc4, 0x58, 0x51, 0xe8, 0x6f, 0xa4, 0xb2, 0x8b, 0x34, 0x9a, 0x50, 0x6a, 0x8e, 0x46, 0x2f,
+	0x45, 0xe2, 0x2b, 0xa8, 0xca, 0x92, 0xde, 0xec, 0x99, 0xca, 0xca, 0xca, 0x5f, 0x63, 0x4d, 0xbd,
+	0x08, 0x49, 0xb6, 0xa0, 0x84, 0x8a, 0x8a, 0xe8, 0xa5, 0xc4, 0x74, 0xcf, 0x41, 0xe4, 0x13, 0x28,
+	0xab, 0x52, 0xa3, 0xac, 0xbf, 0xa5, 0x51, 0x59, 0x26, 0xd3, 0xa7, 0x50, 0x43, 0x06, 0x7a, 0x38,
+	0x0d, 0x83, 0x51, 0xfd, 0x3d, 0x38, 0x65, 0x7e, 0x0a, 0x0c, 0x67, 0x7f, 0xce, 0xec, 0x47, 0xd5,
+	0xde, 0x9b, 0xcb, 0x1e, 0x85, 0xc9, 0xfb, 0xa3, 0xb1, 0xec, 0xd1, 0x18, 0x5b, 0x4c, 0x4d, 0x97,
+	0x9d, 0x97, 0x92, 0x4b, 0xd4, 0xa5, 0x57, 0x91, 0xdb, 0xa5, 0x27, 0x45, 0x8f, 0xa7, 0xce, 0x78,
+	0x14, 0x51, 0x4b, 0xb7, 0xe3, 0x15, 0x34, 0x0e, 0xa0, 0x40, 0xaa, 0xe1, 0x92, 0x6f, 0xf2, 0x56,
+	0x8b, 0xd7, 0xaa, 0x1b, 0xd7, 0xd3, 0x07, 0x65, 0xf6, 0x8d, 0x84, 0x3e, 0x82, 0x42, 0x1b, 0x03,
+	0x5c, 0xfe, 0xd8, 0x92, 0x56, 0x74, 0x6d, 0xa4, 0x01, 0x79, 0x6f, 0x40, 0x7e, 0xc5, 0x5b, 0x3e,
+	0x73, 0x05, 0xfb, 0x78, 0x67, 0xa1, 0xc1, 0x2f, 0x9f, 0xba, 0xdc, 0x22, 0x00, 0x23, 0x34, 0xcb,
+	0x1d, 0xbd, 0xd0, 0xaa, 0x7b, 0x98, 0xcd, 0x5d, 0xba, 0xc3, 0x87, 0x4e, 0x78, 0xae, 0x1c, 0xcb,
+	0x12, 0xc5, 0x2f, 0x64, 0xb7, 0x51, 0xad, 0x9a, 0x79, 0xa8, 0x4a, 0xc9, 0x3b, 0x2a, 0x08, 0xbb,
+	0x92, 0x4d, 0xdf, 0x45, 0xcf, 0x67, 0x8f, 0xc7, 0x57, 0x9a, 0xf3, 0x25, 0xbc, 0x8e, 0x9b, 0xdd,
+	0x71, 0x29, 0x45, 0xa6, 0x85, 0xb5, 0xef, 0x7a, 0x21, 0xf7, 0xf7, 0x0b, 0xa8, 0xce, 0x17, 0xce,
+	0x8d, 0x77, 0x57, 0x15, 0x68, 0x75, 0x5d, 0xbd, 0xf1, 0xce, 0x2a, 0xac, 0xc8, 0xb5, 0x16, 0x74,
+	0xe9, 0x73, 0xe1, 0x80, 0x24, 0x2a, 0xbb, 0x8d, 0xb7, 0x97, 0x8e, 0x47, 0xb6, 0xf9, 0xb1, 0xfe,
+	0x9e, 0xcd, 0x55, 0xd0, 0xb4, 0x82, 0x62, 0x23, 0x0d, 0xc8, 0x0e, 0x95, 0x8e, 0xc7, 0x09, 0xbf,
+	0xc6, 0x6c, 0xa4, 0x15, 0x22, 0x97, 0x9c, 0x52, 0x5d, 0xcc, 0x44, 0x12, 0x27, 0x7c, 0xd7, 0x44,
+	0x35, 0x32, 0x4c, 0x1f, 0x96, 0x96, 0xcf, 0x96, 0x1c, 0xb5, 0xb9, 0x6a, 0x9c, 0x74, 0xd1, 0x48,
+	0x31, 0x56, 0x60, 0x33, 0x92, 0x05, 0xa4, 0xc5, 0xe2, 0xdb, 0x9a, 0xcd, 0xfc, 0x12, 0x80, 0xdc,
+	0x00, 0x97, 0xd4, 0x02, 0x63, 0xe1, 0xac, 0xc7, 0x0b, 0x73, 0x8d, 0x1b, 0x4b, 0x46, 0x23, 0x75,
+	0xf7, 0xe0, 0x0d, 0x24, 0x76, 0xc8, 0xc5, 0x3a, 0xf4, 0xb9, 0xb1, 0xf2, 0xd2, 0x3b, 0xcb, 0xdf,
+	0xae, 0x2a, 0xe2, 0xe9, 0x6f, 0x0b, 0xd8, 0x0b, 0x17, 0xf0, 0xa4, 0xcb, 0xf0, 0x68, 0xcd, 0x13,
+	0x92, 0xc6, 0x8a, 0xa7, 0xae, 0x2c, 0x6b, 0x49, 0x9e, 0xbb, 0xef, 0x82, 0xd8, 0x7d, 0x28, 0xa2,
+	0xac, 0xfb, 0xf2, 0x0b, 0xd5, 0xf3, 0x1d, 0xa3, 0x79, 0x02, 0xfb, 0xec, 0xc1, 0xfa, 0xf4, 0x8d,
+	0xf3, 0x5a, 0xca, 0x33, 0x45, 0xa5, 0x96, 0xf5, 0xf7, 0x63, 0x87, 0x6c, 0x9c, 0xbe, 0xff, 0xbf,
+	0x3c, 0xa9, 0xfb, 0x98, 0x41, 0xe3, 0xe6, 0x31, 0xa1, 0x74, 0x91, 0xde, 0x5a, 0xf2, 0xe4, 0x48,
+	0x59, 0x53, 0x87, 0xef, 0x5b, 0xed, 0x5d, 0x76, 0x2f, 0xe9, 0xc1, 0x57, 0xca, 0x2b, 0x25, 0x7e,
+	0x57, 0xb5, 0xc6, 0x30, 0xf7, 0xa0, 0x28, 0x9f, 0x57, 0x11, 0x8d, 0x7a, 0x9a, 0x54, 0x34, 0xb8,
+	0x8e, 0xa1, 0x2f, 0xa0, 0x8a, 0x4a, 0x8e, 0x57, 0xfd, 0x56, 0x14, 0xdb, 0x1a, 0x2b, 0xc6, 0xd8,
+	0x37, 0xed, 0xc8, 0x40, 0xe4, 0xbb, 0x21, 0xf7, 0x73, 0xd8, 0x91, 0xd6, 0x18, 0x27, 0xb7, 0x58,
+	0x83, 0x5c, 0xea, 0xd3, 0x3b, 0xb0, 0x4d, 0x27, 0x37, 0xfe, 0x08, 0x3b, 0x7d, 0xcf, 0x6e, 0x2e,
+	0x67, 0xe3, 0x50, 0x5f, 0xa6, 0xe5, 0x16, 0x1e, 0x65, 0x2a, 0x63, 0x2c, 0xe3, 0x62, 0x11, 0x24,
+	0xe7, 0xc9, 0x6d, 0xb8, 0xe2, 0xbc, 0x16, 0x94, 0xd5, 0x41, 0x94, 0xf3, 0xde, 0x4a, 0x8d, 0x9c,
+	0x25, 0xca, 0x0a, 0xf9, 0x0b, 0xfa, 0xc5, 0x4c, 0xea, 0x55, 0x11, 0x7b, 0x4a, 0xb3, 0x60, 0x25,
+	0xf1, 0x47, 0x26, 0x48, 0xea, 0x21, 0x18, 0x04, 0x49, 0x84, 0xbf, 0x66, 0x1a, 0xd1, 0xf9, 0xa7,
+	0x35, 0xeb, 0x08, 0x7f, 0x09, 0x15, 0xf2, 0x5d, 0xb3, 0xba, 0xed, 0xf2, 0x5a, 0xe9, 0x82, 0x77,
+	0x9d, 0x2f, 0xba, 0x22, 0xb1, 0x2e, 0xd4, 0xe2, 0x5a, 0x7b, 0x69, 0x7a, 0x07, 0x7c, 0x9b, 0xec,
+	0xc7, 0x9e, 0xe1, 0xa7, 0xdb, 0xcf, 0x5a, 0x42, 0x5f, 0x80, 0x71, 0xe2, 0xdb, 0x4f, 0x1d, 0xfd,
+	0x50, 0x6b, 0x15, 0xb1, 0xeb, 0xcb, 0xea, 0xa4, 0xd1, 0xb1, 0x2f, 0x93, 0x07, 0x89, 0x8a, 0xc5,
+	0x2f, 0x46, 0x45, 0x40, 0xad, 0x87, 0x67, 0x63, 0xee, 0x09, 0xf6, 0xca, 0x77, 0xc3, 0xcf, 0xe1,
+	0x1c, 0xf7, 0xa1, 0xc4, 0x85, 0x8e, 0x25, 0x36, 0x1b, 0x7f, 0x6b, 0xdd, 0xa8, 0xa7, 0xad, 0xa5,
+	0x78, 0xfb, 0x06, 0x76, 0x38, 0x16, 0x53, 0xcf, 0x78, 0x4f, 0x3c, 0x87, 0xbe, 0xcd, 0x25, 0x4b,
+	0x62, 0x89, 0x67, 0xc7, 0x0b, 0xc1, 0xd3, 0xc2, 0x33, 0x60, 0x19, 0xed, 0x1c, 0x72, 0x6c, 0xce,
+	0x2f, 0x1c, 0xd3, 0x6a, 0x9e, 0x8d, 0x34, 0xa0, 0xcc, 0xb1, 0x64, 0x19, 0xf5, 0x05, 0xe6, 0xee,
+	0x42, 0x91, 0x24, 0xa3, 0xde, 0x62, 0xa8, 0x14, 0xab, 0x24, 0x2f, 0x84, 0x4a, 0xba, 0x58, 0x2b,
+	0x4d, 0x5b, 0xd6, 0x4d, 0x63, 0x99, 0x79, 0x72, 0xbf, 0xe6, 0x8a, 0xb4, 0x0b, 0x1a, 0x8e, 0xca,
+	0xae, 0xe6, 0x2b, 0x3f, 0xca, 0x18, 0x5f, 0x43, 0x4d, 0x16, 0x15, 0x55, 0x94, 0xde, 0xea, 0x7d,
+	0x6d, 0x7c, 0x3f, 0x31, 0x23, 0xad, 0x0e, 0xdb, 0x48, 0x2f, 0x4d, 0xca, 0x3a, 0x99, 0xe4, 0x53,
+	0x56, 0x2b, 0x56, 0xf0, 0x39, 0x57, 0x9c, 0x5c, 0xc3, 0xa7, 0x80, 0x1d, 0x5d, 0x5b, 0x9b, 0x11,
+	0xbc, 0x91, 0x5e, 0x7d, 0x5b, 0x16, 0x24, 0xce, 0xd5, 0xf2, 0xf8, 0x04, 0x6d, 0xca, 0x87, 0x38,
+	0x8b, 0x16, 0x1f, 0x7f, 0x9f, 0xb3, 0xfa, 0xfa, 0x7d, 0xbc, 0xc9, 0xff, 0xc5, 0x75, 0xef, 0xff,
+	0x01, 0x40, 0x0d, 0x71, 0xaa, 0x17, 0x36, 0x00, 0x00,
 }
@@ -0,0 +1,210 @@
+package main
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/brotherlogic/discogssyncer/server"
+	pbd "github.com/brotherlogic/godiscogs"
+)
+
+func (syncer *Syncer) shelfPosition(rel *pbd.Release) *pb.ShelfPosition {
+	for _, p := range syncer.findMetadata(rel.Id).GetShelfPositions() {
+		if p.InstanceId == rel.InstanceId {
+			return p
+		}
+	}
+	return nil
+}
+
+func (syncer *Syncer) setShelfPosition(md *pb.ReleaseMetadata, pos *pb.ShelfPosition) {
+	for i, p := range md.ShelfPositions {
+		if p.InstanceId == pos.InstanceId {
+			md.ShelfPositions[i] = pos
+			return
+		}
+	}
+	md.ShelfPositions = append(md.ShelfPositions, pos)
+}
+
+// dateAdded treats anything we haven't recorded yet as arriving now
+func (syncer *Syncer) dateAdded(rel *pbd.Release) int64 {
+	if added := syncer.findMetadata(rel.Id).GetDateAdded(); added > 0 {
+		return added
+	}
+	return time.Now().Unix()
+}
+
+func labelKey(rel *pbd.Release) (string, string) {
+	if len(rel.Labels) == 0 {
+		return "", ""
+	}
+	return strings.ToLower(rel.Labels[0].Name), strings.ToLower(rel.Labels[0].Catno)
+}
+
+func artistLess(a, b *pbd.Release) bool {
+	aa, ba := strings.ToLower(pbd.GetReleaseArtist(*a)), strings.ToLower(pbd.GetReleaseArtist(*b))
+	if aa != ba {
+		return aa < ba
+	}
+	if at, bt := strings.ToLower(a.Title), strings.ToLower(b.Title); at != bt {
+		return at < bt
+	}
+	return a.Id < b.Id
+}
+
+func (syncer *Syncer) shelfLess(order pb.ShelfOrder) (func(a, b *pbd.Release) bool, error) {
+	switch order {
+	case pb.ShelfOrder_BY_ARTIST:
+		return artistLess, nil
+	case pb.ShelfOrder_BY_LABEL:
+		return func(a, b *pbd.Release) bool {
+			al, ac := labelKey(a)
+			bl, bc := labelKey(b)
+			if al != bl {
+				return al < bl
+			}
+			if ac != bc {
+				return ac < bc
+			}
+			return artistLess(a, b)
+		}, nil
+	case pb.ShelfOrder_BY_DATE_ADDED:
+		return func(a, b *pbd.Release) bool {
+			ad, bd := syncer.dateAdded(a), syncer.dateAdded(b)
+			if ad != bd {
+				return ad < bd
+			}
+			return a.Id < b.Id
+		}, nil
+	case pb.ShelfOrder_CUSTOM:
+		return func(a, b *pbd.Release) bool {
+			ap, bp := syncer.shelfPosition(a), syncer.shelfPosition(b)
+			if ap == nil || bp == nil {
+				return ap != nil
+			}
+			return ap.Position < bp.Position
+		}, nil
+	}
+	return nil, status.Errorf(codes.InvalidArgument, "Unknown shelf order %v", order)
+}
+
+// orderFolder sorts a copy of a folder's releases into shelf order
+func (syncer *Syncer) orderFolder(folderID int32, order pb.ShelfOrder) ([]*pbd.Release, error) {
+	folder := syncer.findFolder(folderID)
+	if folder == nil {
+		return nil, status.Errorf(codes.NotFound, "Unable to locate folder with id %v", folderID)
+	}
+	less, err := syncer.shelfLess(order)
+	if err != nil {
+		return nil, err
+	}
+
+	releases := append([]*pbd.Release{}, folder.GetReleases().GetReleases()...)
+	sort.Stable(releaseSorter{releases, less})
+	return releases, nil
+}
+
+// SetShelfPosition records where a copy of a release sits
+func (syncer *Syncer) SetShelfPosition(ctx context.Context, in *pb.ShelfPosition) (*pb.ReleaseMetadata, error) {
+	t := time.Now()
+	if in.Position <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "Shelf positions start at 1")
+	}
+	md := syncer.findMetadata(in.ReleaseId)
+	if md == nil || !syncer.holdsInstance(in.ReleaseId, in.InstanceId) {
+		return nil, status.Errorf(codes.NotFound, "Unable to find %v (instance %v) in the collection", in.ReleaseId, in.InstanceId)
+	}
+
+	syncer.setShelfPosition(md, in)
+	syncer.saveCollection()
+	syncer.LogFunction("SetShelfPosition", t)
+	return md, nil
+}
+
+// OrderFolder works out the shelf order for a folder, storing it if asked
+func (syncer *Syncer) OrderFolder(ctx context.Context, in *pb.OrderRequest) (*pb.ShelfList, error) {
+	t := time.Now()
+	releases, err := syncer.orderFolder(in.FolderId, in.Order)
+	if err != nil {
+		return nil, err
+	}
+
+	shelf := in.Shelf
+	if shelf == "" {
+		shelf = syncer.findFolder(in.FolderId).Folder.Name
+	}
+
+	list := &pb.ShelfList{}
+	for i, rel := range releases {
+		pos := &pb.ShelfPosition{ReleaseId: rel.Id, InstanceId: rel.InstanceId, Shelf: shelf, Position: int32(i + 1)}
+		if in.Apply {
+			if md := syncer.findMetadata(rel.Id); md != nil {
+				syncer.setShelfPosition(md, pos)
+			}
+		}
+		list.Entries = append(list.Entries, &pb.ShelfEntry{Release: rel, Position: pos})
+	}
+
+	if in.Apply {
+		syncer.saveCollection()
+	}
+	syncer.LogFunction("OrderFolder", t)
+	return list, nil
+}
+
+// GetInsertionPoint works out where a new record should go on a shelf and what has to shift to make room
+func (syncer *Syncer) GetInsertionPoint(ctx context.Context, in *pb.InsertionRequest) (*pb.InsertionResponse, error) {
+	t := time.Now()
+	if in.Release == nil {
+		return nil, status.Error(codes.InvalidArgument, "No release to insert")
+	}
+	rel, err := syncer.GetSingleRelease(ctx, in.Release)
+	if err != nil {
+		return nil, err
+	}
+
+	ordered, err := syncer.orderFolder(in.FolderId, in.Order)
+	if err != nil {
+		return nil, err
+	}
+
+	var shelf []*pbd.Release
+	for _, r := range ordered {
+		if r.Id != rel.Id || r.InstanceId != in.Release.InstanceId {
+			shelf = append(shelf, r)
+		}
+	}
+
+	// Custom orders have no rule to follow so new records go on the end
+	index := len(shelf)
+	if in.Order != pb.ShelfOrder_CUSTOM {
+		less, _ := syncer.shelfLess(in.Order)
+		index = sort.Search(len(shelf), func(i int) bool { return less(rel, shelf[i]) })
+	}
+
+	resp := &pb.InsertionResponse{Position: int32(index + 1)}
+	if index > 0 {
+		resp.Before = shelf[index-1]
+	}
+	if index < len(shelf) {
+		resp.After = shelf[index]
+	}
+
+	folderName := syncer.findFolder(in.FolderId).Folder.Name
+	for i, r := range shelf[index:] {
+		name := folderName
+		if p := syncer.shelfPosition(r); p != nil {
+			name = p.Shelf
+		}
+		resp.Shifted = append(resp.Shifted, &pb.ShelfEntry{Release: r, Position: &pb.ShelfPosition{ReleaseId: r.Id, InstanceId: r.InstanceId, Shelf: name, Position: int32(index + i + 2)}})
+	}
+
+	syncer.LogFunction("GetInsertionPoint", t)
+	return resp, nil
+}
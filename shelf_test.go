@@ -0,0 +1,112 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+
+	pb "github.com/brotherlogic/discogssyncer/server"
+	pbd "github.com/brotherlogic/godiscogs"
+)
+
+func buildShelf(syncer *Syncer) {
+	syncer.SaveCollection()
+	syncer.saveRelease(&pbd.Release{FolderId: 25, Id: 41, InstanceId: 410, Title: "Speak No Evil", Artists: []*pbd.Artist{&pbd.Artist{Name: "Wayne Shorter"}}, Labels: []*pbd.Label{&pbd.Label{Name: "Blue Note", Catno: "BST 84194"}}}, 25)
+	syncer.saveRelease(&pbd.Release{FolderId: 25, Id: 42, InstanceId: 420, Title: "A Love Supreme", Artists: []*pbd.Artist{&pbd.Artist{Name: "John Coltrane"}}, Labels: []*pbd.Label{&pbd.Label{Name: "Impulse!", Catno: "A-77"}}}, 25)
+	syncer.saveRelease(&pbd.Release{FolderId: 25, Id: 43, InstanceId: 430, Title: "Blue Train", Artists: []*pbd.Artist{&pbd.Artist{Name: "John Coltrane"}}, Labels: []*pbd.Label{&pbd.Label{Name: "Blue Note", Catno: "BLP 1577"}}}, 25)
+	syncer.findMetadata(41).DateAdded = time.Now().AddDate(0, 0, -3).Unix()
+	syncer.findMetadata(42).DateAdded = time.Now().AddDate(0, 0, -1).Unix()
+	syncer.findMetadata(43).DateAdded = time.Now().AddDate(0, 0, -2).Unix()
+}
+
+func shelfIds(list *pb.ShelfList) []int32 {
+	var ids []int32
+	for _, e := range list.Entries {
+		ids = append(ids, e.Release.Id)
+	}
+	return ids
+}
+
+func TestOrderFolder(t *testing.T) {
+	syncer := GetTestSyncer(".testorderfolder", true)
+	buildShelf(syncer)
+
+	tests := []struct {
+		order pb.ShelfOrder
+		ids   []int32
+	}{
+		{pb.ShelfOrder_BY_ARTIST, []int32{42, 43, 41}},
+		{pb.ShelfOrder_BY_LABEL, []int32{43, 41, 42}},
+		{pb.ShelfOrder_BY_DATE_ADDED, []int32{41, 43, 42}},
+	}
+
+	for _, test := range tests {
+		list, err := syncer.OrderFolder(context.Background(), &pb.OrderRequest{FolderId: 25, Order: test.order})
+		if err != nil {
+			t.Fatalf("Error ordering folder: %v", err)
+		}
+		ids := shelfIds(list)
+		if len(ids) != 3 || ids[0] != test.ids[0] || ids[1] != test.ids[1] || ids[2] != test.ids[2] {
+			t.Errorf("Bad %v order: %v", test.order, ids)
+		}
+	}
+
+	if syncer.shelfPosition(&pbd.Release{Id: 41, InstanceId: 410}) != nil {
+		t.Errorf("Order has been stored without being applied")
+	}
+}
+
+func TestApplyAndCustomOrder(t *testing.T) {
+	syncer := GetTestSyncer(".testapplyorder", true)
+	buildShelf(syncer)
+
+	_, err := syncer.OrderFolder(context.Background(), &pb.OrderRequest{FolderId: 25, Order: pb.ShelfOrder_BY_ARTIST, Apply: true})
+	if err != nil {
+		t.Fatalf("Error ordering folder: %v", err)
+	}
+	pos := syncer.shelfPosition(&pbd.Release{Id: 41, InstanceId: 410})
+	if pos == nil || pos.Position != 3 || pos.Shelf != "TestingTwo" {
+		t.Errorf("Order has not been applied: %v", pos)
+	}
+
+	// Swap the first record to the end by hand
+	if _, err = syncer.SetShelfPosition(context.Background(), &pb.ShelfPosition{ReleaseId: 42, InstanceId: 420, Shelf: "TestingTwo", Position: 4}); err != nil {
+		t.Fatalf("Error setting position: %v", err)
+	}
+	list, err := syncer.OrderFolder(context.Background(), &pb.OrderRequest{FolderId: 25, Order: pb.ShelfOrder_CUSTOM})
+	ids := shelfIds(list)
+	if err != nil || ids[0] != 43 || ids[2] != 42 {
+		t.Errorf("Bad custom order: %v (%v)", ids, err)
+	}
+
+	if _, err = syncer.SetShelfPosition(context.Background(), &pb.ShelfPosition{ReleaseId: 42, InstanceId: 999, Position: 1}); errorCode(err) != codes.NotFound {
+		t.Errorf("Missing instance has been positioned: %v", err)
+	}
+	if _, err = syncer.SetShelfPosition(context.Background(), &pb.ShelfPosition{ReleaseId: 42, InstanceId: 420}); errorCode(err) != codes.InvalidArgument {
+		t.Errorf("Zero position has been accepted: %v", err)
+	}
+}
+
+func TestGetInsertionPoint(t *testing.T) {
+	syncer := GetTestSyncer(".testinsertionpoint", true)
+	buildShelf(syncer)
+
+	// Moving 43 in should slot it between 42 and 41
+	resp, err := syncer.GetInsertionPoint(context.Background(), &pb.InsertionRequest{Release: &pbd.Release{Id: 43, InstanceId: 430}, FolderId: 25, Order: pb.ShelfOrder_BY_ARTIST})
+	if err != nil {
+		t.Fatalf("Error getting insertion point: %v", err)
+	}
+	if resp.Position != 2 || resp.Before.Id != 42 || resp.After.Id != 41 {
+		t.Errorf("Bad insertion point: %v", resp)
+	}
+	if len(resp.Shifted) != 1 || resp.Shifted[0].Release.Id != 41 || resp.Shifted[0].Position.Position != 3 {
+		t.Errorf("Bad shifted records: %v", resp.Shifted)
+	}
+
+	resp, err = syncer.GetInsertionPoint(context.Background(), &pb.InsertionRequest{Release: &pbd.Release{Id: 43}, FolderId: 99})
+	if errorCode(err) != codes.NotFound {
+		t.Errorf("Insertion into a missing folder has not failed: %v (%v)", resp, err)
+	}
+}
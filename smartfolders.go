@@ -0,0 +1,242 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/brotherlogic/discogssyncer/server"
+	pbd "github.com/brotherlogic/godiscogs"
+)
+
+func numericField(field pb.SmartField) bool {
+	switch field {
+	case pb.SmartField_RATING, pb.SmartField_AGE_DAYS, pb.SmartField_FOLDER, pb.SmartField_COST, pb.SmartField_YEAR:
+		return true
+	}
+	return false
+}
+
+func validateCondition(c *pb.Condition) error {
+	if numericField(c.Field) {
+		if c.Comparison == pb.Comparison_CONTAINS {
+			return status.Errorf(codes.InvalidArgument, "%v cannot be matched with %v", c.Field, c.Comparison)
+		}
+		if _, err := strconv.ParseInt(c.Value, 10, 64); err != nil {
+			return status.Errorf(codes.InvalidArgument, "%v is not a number for %v", c.Value, c.Field)
+		}
+		return nil
+	}
+
+	switch c.Comparison {
+	case pb.Comparison_EQUALS, pb.Comparison_NOT_EQUALS, pb.Comparison_CONTAINS:
+		return nil
+	}
+	return status.Errorf(codes.InvalidArgument, "%v cannot be matched with %v", c.Field, c.Comparison)
+}
+
+func compareNumber(val int64, op pb.Comparison, target int64) bool {
+	switch op {
+	case pb.Comparison_EQUALS:
+		return val == target
+	case pb.Comparison_NOT_EQUALS:
+		return val != target
+	case pb.Comparison_GREATER:
+		return val > target
+	case pb.Comparison_AT_LEAST:
+		return val >= target
+	case pb.Comparison_LESS:
+		return val < target
+	case pb.Comparison_AT_MOST:
+		return val <= target
+	}
+	return false
+}
+
+// compareText matches if any of the values does, NOT_EQUALS needs all of them to differ
+func compareText(vals []string, op pb.Comparison, target string) bool {
+	for _, val := range vals {
+		switch op {
+		case pb.Comparison_EQUALS:
+			if strings.EqualFold(val, target) {
+				return true
+			}
+		case pb.Comparison_NOT_EQUALS:
+			if strings.EqualFold(val, target) {
+				return false
+			}
+		case pb.Comparison_CONTAINS:
+			if match(target, val) {
+				return true
+			}
+		}
+	}
+	return op == pb.Comparison_NOT_EQUALS
+}
+
+func textValues(field pb.SmartField, rel *pbd.Release, md *pb.ReleaseMetadata) []string {
+	var vals []string
+	switch field {
+	case pb.SmartField_LABEL:
+		for _, l := range rel.Labels {
+			vals = append(vals, l.Name)
+		}
+	case pb.SmartField_ARTIST:
+		for _, a := range rel.Artists {
+			vals = append(vals, a.Name)
+		}
+	case pb.SmartField_TITLE:
+		vals = append(vals, rel.Title)
+	case pb.SmartField_GENRE:
+		vals = rel.Genres
+	case pb.SmartField_STYLE:
+		vals = rel.Styles
+	case pb.SmartField_FORMAT:
+		for _, f := range rel.Formats {
+			vals = append(vals, f.Name)
+		}
+	case pb.SmartField_TAG:
+		vals = releaseTags(md, rel)
+	}
+	return vals
+}
+
+func meetsCondition(c *pb.Condition, rel *pbd.Release, md *pb.ReleaseMetadata, now time.Time) bool {
+	if !numericField(c.Field) {
+		return compareText(textValues(c.Field, rel, md), c.Comparison, c.Value)
+	}
+
+	target, _ := strconv.ParseInt(c.Value, 10, 64)
+	var val int64
+	switch c.Field {
+	case pb.SmartField_RATING:
+		val = int64(rel.Rating)
+	case pb.SmartField_AGE_DAYS:
+		if md.GetDateAdded() == 0 {
+			return false
+		}
+		val = (now.Unix() - md.GetDateAdded()) / day
+	case pb.SmartField_FOLDER:
+		val = int64(rel.FolderId)
+	case pb.SmartField_COST:
+		val = int64(md.GetCost())
+	case pb.SmartField_YEAR:
+		val = int64(releaseYear(rel))
+	}
+	return compareNumber(val, c.Comparison, target)
+}
+
+func (syncer *Syncer) findSmartFolder(folder *pbd.Folder) *pb.SmartFolder {
+	for _, sf := range syncer.collection.SmartFolders {
+		if sf.Folder.Id == folder.Id || (len(folder.Name) > 0 && sf.Folder.Name == folder.Name) {
+			return sf
+		}
+	}
+	return nil
+}
+
+// evaluateSmartFolder runs a smart folder's query against the live collection
+func (syncer *Syncer) evaluateSmartFolder(sf *pb.SmartFolder) []*pbd.Release {
+	t := time.Now()
+	col, _ := syncer.GetCollection(context.Background(), &pb.Empty{})
+
+	var releases []*pbd.Release
+	for _, rel := range col.Releases {
+		md := syncer.findMetadata(rel.Id)
+		matched := true
+		for _, c := range sf.Conditions {
+			if !meetsCondition(c, rel, md, t) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			releases = append(releases, rel)
+		}
+	}
+	return releases
+}
+
+func (syncer *Syncer) validateSmartFolder(sf *pb.SmartFolder) error {
+	if sf.Folder == nil || sf.Folder.Name == "" {
+		return status.Error(codes.InvalidArgument, "Smart folders need a name")
+	}
+	for _, c := range sf.Conditions {
+		if err := validateCondition(c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AddSmartFolder saves a new smart folder
+func (syncer *Syncer) AddSmartFolder(ctx context.Context, in *pb.SmartFolder) (*pb.SmartFolder, error) {
+	t := time.Now()
+	if err := syncer.validateSmartFolder(in); err != nil {
+		return nil, err
+	}
+
+	if syncer.folderNameUsed(in.Folder.Name) {
+		return nil, status.Errorf(codes.AlreadyExists, "There is already a folder called %v", in.Folder.Name)
+	}
+
+	in.Folder.Id = -1
+	for _, sf := range syncer.collection.SmartFolders {
+		if sf.Folder.Id <= in.Folder.Id {
+			in.Folder.Id = sf.Folder.Id - 1
+		}
+	}
+
+	syncer.collection.SmartFolders = append(syncer.collection.SmartFolders, in)
+	syncer.saveCollection()
+	syncer.LogFunction("AddSmartFolder", t)
+	return in, nil
+}
+
+// UpdateSmartFolder replaces the name and query of an existing smart folder
+func (syncer *Syncer) UpdateSmartFolder(ctx context.Context, in *pb.SmartFolder) (*pb.SmartFolder, error) {
+	t := time.Now()
+	if err := syncer.validateSmartFolder(in); err != nil {
+		return nil, err
+	}
+
+	for _, sf := range syncer.collection.SmartFolders {
+		if sf.Folder.Id == in.Folder.Id {
+			// A clash with a real folder would hide it, as smart folders are looked up first
+			if sf.Folder.Name != in.Folder.Name && syncer.folderNameUsed(in.Folder.Name) {
+				return nil, status.Errorf(codes.AlreadyExists, "There is already a folder called %v", in.Folder.Name)
+			}
+			sf.Folder.Name = in.Folder.Name
+			sf.Conditions = in.Conditions
+			syncer.saveCollection()
+			syncer.LogFunction("UpdateSmartFolder", t)
+			return sf, nil
+		}
+	}
+
+	return nil, status.Errorf(codes.NotFound, "Unable to find smart folder %v", in.Folder.Id)
+}
+
+// DeleteSmartFolder removes a smart folder
+func (syncer *Syncer) DeleteSmartFolder(ctx context.Context, in *pbd.Folder) (*pb.Empty, error) {
+	t := time.Now()
+	for i, sf := range syncer.collection.SmartFolders {
+		if sf.Folder.Id == in.Id {
+			syncer.collection.SmartFolders = append(syncer.collection.SmartFolders[:i], syncer.collection.SmartFolders[i+1:]...)
+			syncer.saveCollection()
+			syncer.LogFunction("DeleteSmartFolder", t)
+			return &pb.Empty{}, nil
+		}
+	}
+
+	return nil, status.Errorf(codes.NotFound, "Unable to find smart folder %v", in.Id)
+}
+
+// GetSmartFolders lists the smart folders
+func (syncer *Syncer) GetSmartFolders(ctx context.Context, in *pb.Empty) (*pb.SmartFolderList, error) {
+	return &pb.SmartFolderList{Folders: syncer.collection.SmartFolders}, nil
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+
+	pb "github.com/brotherlogic/discogssyncer/server"
+	pbd "github.com/brotherlogic/godiscogs"
+)
+
+func TestSmartFolderServedAsFolder(t *testing.T) {
+	syncer := GetTestSyncer(".testsmartfolderserved", true)
+	syncer.saveRelease(&pbd.Release{FolderId: 23, Id: 25, InstanceId: 37, Rating: 5}, 23)
+	syncer.saveRelease(&pbd.Release{FolderId: 23, Id: 27, InstanceId: 39, Rating: 4}, 23)
+	syncer.saveRelease(&pbd.Release{FolderId: 25, Id: 32, InstanceId: 40, Rating: 5}, 25)
+	syncer.findMetadata(25).DateAdded = time.Now().AddDate(0, 0, -10).Unix()
+	syncer.findMetadata(27).DateAdded = time.Now().AddDate(-1, 0, 0).Unix()
+	syncer.findMetadata(32).DateAdded = time.Now().AddDate(0, 0, -20).Unix()
+
+	sf, err := syncer.AddSmartFolder(context.Background(), &pb.SmartFolder{Folder: &pbd.Folder{Name: "Recent Favourites"}, Conditions: []*pb.Condition{
+		&pb.Condition{Field: pb.SmartField_RATING, Comparison: pb.Comparison_AT_LEAST, Value: "4"},
+		&pb.Condition{Field: pb.SmartField_AGE_DAYS, Comparison: pb.Comparison_AT_MOST, Value: "90"},
+	}})
+	if err != nil {
+		t.Fatalf("Error adding smart folder: %v", err)
+	}
+	if sf.Folder.Id >= 0 {
+		t.Errorf("Smart folder has a real folder id: %v", sf)
+	}
+
+	recs, err := syncer.GetReleasesInFolder(context.Background(), &pb.FolderList{Folders: []*pbd.Folder{&pbd.Folder{Name: "Recent Favourites"}}})
+	if err != nil {
+		t.Fatalf("Error getting smart folder: %v", err)
+	}
+	if len(recs.Records) != 2 || recs.Records[0].Metadata == nil {
+		t.Errorf("Smart folder has been badly evaluated: %v", recs)
+	}
+
+	// A fresh copy of the folder should pick up changes to the collection
+	syncer.saveRelease(&pbd.Release{FolderId: 23, Id: 27, InstanceId: 39, Rating: 1}, 23)
+	syncer.findMetadata(25).DateAdded = time.Now().AddDate(-1, 0, 0).Unix()
+	recs, err = syncer.GetReleasesInFolder(context.Background(), &pb.FolderList{Folders: []*pbd.Folder{&pbd.Folder{Id: sf.Folder.Id}}})
+	if err != nil || len(recs.Records) != 1 || recs.Records[0].Release.Id != 32 {
+		t.Errorf("Smart folder has not been re-evaluated: %v (%v)", recs, err)
+	}
+}
+
+func TestSmartFolderByLabel(t *testing.T) {
+	syncer := GetTestSyncer(".testsmartfolderlabel", true)
+	syncer.saveRelease(&pbd.Release{FolderId: 23, Id: 25, InstanceId: 37, Labels: []*pbd.Label{&pbd.Label{Name: "Blue Note"}}}, 23)
+	syncer.saveRelease(&pbd.Release{FolderId: 23, Id: 27, InstanceId: 39, Labels: []*pbd.Label{&pbd.Label{Name: "Impulse!"}}}, 23)
+
+	sf, err := syncer.AddSmartFolder(context.Background(), &pb.SmartFolder{Folder: &pbd.Folder{Name: "Blue Note"}, Conditions: []*pb.Condition{
+		&pb.Condition{Field: pb.SmartField_LABEL, Value: "blue note"},
+	}})
+	if err != nil {
+		t.Fatalf("Error adding smart folder: %v", err)
+	}
+
+	releases := syncer.evaluateSmartFolder(sf)
+	if len(releases) != 1 || releases[0].Id != 25 {
+		t.Errorf("Label folder is wrong: %v", releases)
+	}
+
+	sf.Conditions[0].Comparison = pb.Comparison_NOT_EQUALS
+	_, err = syncer.UpdateSmartFolder(context.Background(), sf)
+	if err != nil {
+		t.Fatalf("Error updating smart folder: %v", err)
+	}
+	releases = syncer.evaluateSmartFolder(sf)
+	if len(releases) != 1 || releases[0].Id != 27 {
+		t.Errorf("Updated label folder is wrong: %v", releases)
+	}
+}
+
+func TestSmartFolderValidation(t *testing.T) {
+	syncer := GetTestSyncer(".testsmartfoldervalidation", true)
+	syncer.SaveCollection()
+
+	var bad = []struct {
+		sf   *pb.SmartFolder
+		code codes.Code
+	}{
+		{&pb.SmartFolder{Folder: &pbd.Folder{}}, codes.InvalidArgument},
+		{&pb.SmartFolder{Folder: &pbd.Folder{Name: "Bad"}, Conditions: []*pb.Condition{&pb.Condition{Field: pb.SmartField_RATING, Value: "four"}}}, codes.InvalidArgument},
+		{&pb.SmartFolder{Folder: &pbd.Folder{Name: "Bad"}, Conditions: []*pb.Condition{&pb.Condition{Field: pb.SmartField_TITLE, Comparison: pb.Comparison_GREATER, Value: "A"}}}, codes.InvalidArgument},
+		{&pb.SmartFolder{Folder: &pbd.Folder{Name: "Testing"}}, codes.AlreadyExists},
+	}
+	for _, test := range bad {
+		if _, err := syncer.AddSmartFolder(context.Background(), test.sf); errorCode(err) != test.code {
+			t.Errorf("Bad smart folder has been added: %v (%v)", test.sf, err)
+		}
+	}
+}
+
+func TestSmartFolderCrud(t *testing.T) {
+	syncer := GetTestSyncer(".testsmartfoldercrud", true)
+	syncer.SaveCollection()
+	first, _ := syncer.AddSmartFolder(context.Background(), &pb.SmartFolder{Folder: &pbd.Folder{Name: "One"}})
+	second, _ := syncer.AddSmartFolder(context.Background(), &pb.SmartFolder{Folder: &pbd.Folder{Name: "Two"}})
+	if first.Folder.Id == second.Folder.Id {
+		t.Fatalf("Smart folders share an id: %v and %v", first, second)
+	}
+
+	if _, err := syncer.DeleteSmartFolder(context.Background(), first.Folder); err != nil {
+		t.Fatalf("Error deleting smart folder: %v", err)
+	}
+
+	list, _ := syncer.GetSmartFolders(context.Background(), &pb.Empty{})
+	if len(list.Folders) != 1 || list.Folders[0].Folder.Name != "Two" {
+		t.Errorf("Smart folder has not been deleted: %v", list)
+	}
+
+	if _, err := syncer.UpdateSmartFolder(context.Background(), first); errorCode(err) != codes.NotFound {
+		t.Errorf("Deleted smart folder has been updated: %v", err)
+	}
+
+	if _, err := syncer.UpdateSmartFolder(context.Background(), &pb.SmartFolder{Folder: &pbd.Folder{Id: second.Folder.Id, Name: "Two"}}); err != nil {
+		t.Errorf("Smart folder can't keep its own name: %v", err)
+	}
+	if _, err := syncer.UpdateSmartFolder(context.Background(), &pb.SmartFolder{Folder: &pbd.Folder{Id: second.Folder.Id, Name: "Testing"}}); errorCode(err) != codes.AlreadyExists || second.Folder.Name != "Two" {
+		t.Errorf("Smart folder has been renamed over a real folder: %v, %v", second, err)
+	}
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"math/rand"
+	"sort"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/brotherlogic/discogssyncer/server"
+	pbd "github.com/brotherlogic/godiscogs"
+)
+
+// How many suggestions we remember, which is plenty to cover any avoid window
+const maxSuggestions = 1000
+
+type releaseSorter struct {
+	releases []*pbd.Release
+	less     func(a, b *pbd.Release) bool
+}
+
+func (s releaseSorter) Len() int           { return len(s.releases) }
+func (s releaseSorter) Swap(i, j int)      { s.releases[i], s.releases[j] = s.releases[j], s.releases[i] }
+func (s releaseSorter) Less(i, j int) bool { return s.less(s.releases[i], s.releases[j]) }
+
+type instanceKey struct {
+	id       int32
+	instance int32
+}
+
+// lastSuggestions maps each copy we've suggested to when we last suggested it
+func (syncer *Syncer) lastSuggestions() map[instanceKey]int64 {
+	last := make(map[instanceKey]int64)
+	for _, s := range syncer.collection.Suggestions {
+		key := instanceKey{s.ReleaseId, s.InstanceId}
+		if s.Date > last[key] {
+			last[key] = s.Date
+		}
+	}
+	return last
+}
+
+func (syncer *Syncer) suggestionPool(req *pb.SuggestionRequest) []*pbd.Release {
+	var releases []*pbd.Release
+	if len(req.Folders) == 0 {
+		col, _ := syncer.GetCollection(context.Background(), &pb.Empty{})
+		releases = col.Releases
+	}
+	for _, folder := range req.Folders {
+		releases = append(releases, syncer.getReleases(folder).GetReleases()...)
+	}
+
+	var pool []*pbd.Release
+	for _, rel := range releases {
+		if req.Genre != "" && !hasTag(rel.Genres, req.Genre) {
+			continue
+		}
+		if req.Style != "" && !hasTag(rel.Styles, req.Style) {
+			continue
+		}
+		pool = append(pool, rel)
+	}
+	return pool
+}
+
+func pickWeighted(releases []*pbd.Release) *pbd.Release {
+	total := 0
+	for _, rel := range releases {
+		total += int(rel.Rating) + 1
+	}
+	n := rand.Intn(total)
+	for _, rel := range releases {
+		n -= int(rel.Rating) + 1
+		if n < 0 {
+			return rel
+		}
+	}
+	return releases[len(releases)-1]
+}
+
+// GetListeningSuggestion picks something for us to play next
+func (syncer *Syncer) GetListeningSuggestion(ctx context.Context, req *pb.SuggestionRequest) (*pb.Record, error) {
+	t := time.Now()
+	last := syncer.lastSuggestions()
+
+	var pool []*pbd.Release
+	for _, rel := range syncer.suggestionPool(req) {
+		when, ok := last[instanceKey{rel.Id, rel.InstanceId}]
+		if ok && req.AvoidWindow > 0 && t.Unix()-when < req.AvoidWindow {
+			continue
+		}
+		pool = append(pool, rel)
+	}
+
+	if len(pool) == 0 {
+		return nil, status.Error(codes.FailedPrecondition, "Unable to find anything to suggest")
+	}
+
+	var pick *pbd.Release
+	switch req.Strategy {
+	case pb.SuggestionStrategy_LEAST_RECENTLY_SUGGESTED:
+		sort.Stable(releaseSorter{pool, func(a, b *pbd.Release) bool {
+			return last[instanceKey{a.Id, a.InstanceId}] < last[instanceKey{b.Id, b.InstanceId}]
+		}})
+		pick = pool[0]
+	case pb.SuggestionStrategy_WEIGHTED_BY_RATING:
+		pick = pickWeighted(pool)
+	case pb.SuggestionStrategy_NEWEST_FIRST:
+		sort.Stable(releaseSorter{pool, func(a, b *pbd.Release) bool {
+			return syncer.findMetadata(a.Id).GetDateAdded() > syncer.findMetadata(b.Id).GetDateAdded()
+		}})
+		pick = pool[0]
+	case pb.SuggestionStrategy_RANDOM:
+		pick = pool[rand.Intn(len(pool))]
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "Unknown suggestion strategy %v", req.Strategy)
+	}
+
+	syncer.collection.Suggestions = append(syncer.collection.Suggestions, &pb.Suggestion{ReleaseId: pick.Id, InstanceId: pick.InstanceId, Date: t.Unix()})
+	if len(syncer.collection.Suggestions) > maxSuggestions {
+		syncer.collection.Suggestions = syncer.collection.Suggestions[len(syncer.collection.Suggestions)-maxSuggestions:]
+	}
+	syncer.saveCollection()
+	syncer.LogFunction("GetListeningSuggestion", t)
+	return &pb.Record{Release: pick, Metadata: syncer.findMetadata(pick.Id)}, nil
+}
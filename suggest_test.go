@@ -0,0 +1,107 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+
+	pb "github.com/brotherlogic/discogssyncer/server"
+	pbd "github.com/brotherlogic/godiscogs"
+)
+
+func TestSuggestLeastRecent(t *testing.T) {
+	syncer := GetTestSyncer(".testsuggestleastrecent", true)
+	syncer.saveRelease(&pbd.Release{FolderId: 23, Id: 25, InstanceId: 37}, 23)
+	syncer.saveRelease(&pbd.Release{FolderId: 23, Id: 27, InstanceId: 39}, 23)
+	syncer.saveRelease(&pbd.Release{FolderId: 25, Id: 32, InstanceId: 40}, 25)
+
+	seen := make(map[int32]bool)
+	for i := 0; i < 2; i++ {
+		rec, err := syncer.GetListeningSuggestion(context.Background(), &pb.SuggestionRequest{Folders: []int32{23}})
+		if err != nil {
+			t.Fatalf("Error getting suggestion: %v", err)
+		}
+		if rec.Release.FolderId != 23 || seen[rec.Release.Id] {
+			t.Errorf("Bad suggestion: %v", rec)
+		}
+		seen[rec.Release.Id] = true
+	}
+
+	if len(syncer.collection.Suggestions) != 2 {
+		t.Errorf("Suggestions have not been stored: %v", syncer.collection.Suggestions)
+	}
+}
+
+func TestSuggestAvoidsRepeats(t *testing.T) {
+	syncer := GetTestSyncer(".testsuggestavoidsrepeats", true)
+	syncer.saveRelease(&pbd.Release{FolderId: 23, Id: 25, InstanceId: 37, Rating: 5}, 23)
+
+	_, err := syncer.GetListeningSuggestion(context.Background(), &pb.SuggestionRequest{Strategy: pb.SuggestionStrategy_WEIGHTED_BY_RATING, AvoidWindow: 60 * 60})
+	if err != nil {
+		t.Fatalf("Error getting suggestion: %v", err)
+	}
+
+	_, err = syncer.GetListeningSuggestion(context.Background(), &pb.SuggestionRequest{Strategy: pb.SuggestionStrategy_WEIGHTED_BY_RATING, AvoidWindow: 60 * 60})
+	if errorCode(err) != codes.FailedPrecondition {
+		t.Errorf("Record has been suggested twice within the window: %v", err)
+	}
+
+	_, err = syncer.GetListeningSuggestion(context.Background(), &pb.SuggestionRequest{Strategy: pb.SuggestionStrategy_WEIGHTED_BY_RATING})
+	if err != nil {
+		t.Errorf("Record could not be suggested without a window: %v", err)
+	}
+}
+
+func TestSuggestNewest(t *testing.T) {
+	syncer := GetTestSyncer(".testsuggestnewest", true)
+	syncer.saveRelease(&pbd.Release{FolderId: 23, Id: 25, InstanceId: 37}, 23)
+	syncer.saveRelease(&pbd.Release{FolderId: 23, Id: 27, InstanceId: 39}, 23)
+	syncer.findMetadata(25).DateAdded = time.Now().AddDate(0, -1, 0).Unix()
+	syncer.findMetadata(27).DateAdded = time.Now().AddDate(0, 0, -1).Unix()
+
+	rec, err := syncer.GetListeningSuggestion(context.Background(), &pb.SuggestionRequest{Strategy: pb.SuggestionStrategy_NEWEST_FIRST})
+	if err != nil || rec.Release.Id != 27 {
+		t.Errorf("Newest record was not suggested: %v (%v)", rec, err)
+	}
+}
+
+func TestSuggestRandomInGenre(t *testing.T) {
+	syncer := GetTestSyncer(".testsuggestrandomgenre", true)
+	syncer.saveRelease(&pbd.Release{FolderId: 23, Id: 25, InstanceId: 37, Genres: []string{"Jazz"}}, 23)
+	syncer.saveRelease(&pbd.Release{FolderId: 23, Id: 27, InstanceId: 39, Genres: []string{"Rock"}, Styles: []string{"Punk"}}, 23)
+
+	rec, err := syncer.GetListeningSuggestion(context.Background(), &pb.SuggestionRequest{Strategy: pb.SuggestionStrategy_RANDOM, Genre: "Jazz"})
+	if err != nil || rec.Release.Id != 25 {
+		t.Errorf("Genre has not been respected: %v (%v)", rec, err)
+	}
+
+	rec, err = syncer.GetListeningSuggestion(context.Background(), &pb.SuggestionRequest{Strategy: pb.SuggestionStrategy_RANDOM, Style: "Punk"})
+	if err != nil || rec.Release.Id != 27 {
+		t.Errorf("Style has not been respected: %v (%v)", rec, err)
+	}
+
+	_, err = syncer.GetListeningSuggestion(context.Background(), &pb.SuggestionRequest{Genre: "Classical"})
+	if errorCode(err) != codes.FailedPrecondition {
+		t.Errorf("Empty genre produced a suggestion: %v", err)
+	}
+
+	_, err = syncer.GetListeningSuggestion(context.Background(), &pb.SuggestionRequest{Strategy: pb.SuggestionStrategy(99)})
+	if errorCode(err) != codes.InvalidArgument {
+		t.Errorf("Unknown strategy gave %v", err)
+	}
+}
+
+func TestSuggestionsAreCapped(t *testing.T) {
+	syncer := GetTestSyncer(".testsuggestcap", true)
+	syncer.saveRelease(&pbd.Release{FolderId: 23, Id: 25, InstanceId: 37}, 23)
+	for i := 0; i < maxSuggestions; i++ {
+		syncer.collection.Suggestions = append(syncer.collection.Suggestions, &pb.Suggestion{ReleaseId: 25, InstanceId: 37, Date: int64(i + 1)})
+	}
+
+	syncer.GetListeningSuggestion(context.Background(), &pb.SuggestionRequest{})
+	if len(syncer.collection.Suggestions) != maxSuggestions || syncer.collection.Suggestions[0].Date != 2 {
+		t.Errorf("Suggestions have been badly capped: %v from %v", len(syncer.collection.Suggestions), syncer.collection.Suggestions[0])
+	}
+}
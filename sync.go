@@ -4,13 +4,14 @@ import (
 	"errors"
 	"fmt"
 	"log"
-	"strconv"
 	"strings"
 	"time"
 
 	"github.com/brotherlogic/godiscogs"
 	"github.com/golang/protobuf/proto"
 	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	pb "github.com/brotherlogic/discogssyncer/server"
 	pbd "github.com/brotherlogic/godiscogs"
@@ -62,7 +63,7 @@ func (syncer *Syncer) GetRelease(id int32, folder int32) (*pbd.Release, *pb.Rele
 	}
 
 	//Recache the release if it's old
-	if metadata != nil && metadata.LastCache < time.Now().Add(time.Hour*24*14).Unix() {
+	if release != nil && metadata != nil && metadata.LastCache < time.Now().Add(time.Hour*24*14).Unix() {
 		syncer.mapM.Lock()
 		syncer.recacheList[int(release.Id)] = release
 		syncer.mapM.Unlock()
@@ -106,9 +107,22 @@ func (syncer *Syncer) GetIncompleteReleases(ctx context.Context, in *pb.Empty) (
 
 // MoveToFolder moves a release to the specified folder
 func (syncer *Syncer) MoveToFolder(ctx context.Context, in *pb.ReleaseMove) (*pb.Empty, error) {
+	err := syncer.moveRelease(in, true, false)
+	if err != nil {
+		return nil, err
+	}
+
+	syncer.saveCollection()
+
+	return &pb.Empty{}, nil
+}
+
+// moveRelease does a move without saving, refresh pulls the release fresh from discogs
+// and bulk throttles the discogs call when it's one of a run of moves
+func (syncer *Syncer) moveRelease(in *pb.ReleaseMove, refresh bool, bulk bool) error {
 	//Validate request
 	if in.Release == nil {
-		return nil, errors.New("Request to move with nil release?")
+		return status.Error(codes.InvalidArgument, "Request to move with nil release?")
 	}
 
 	//Before doing anything check that the new folder exists
@@ -120,21 +134,37 @@ func (syncer *Syncer) MoveToFolder(ctx context.Context, in *pb.ReleaseMove) (*pb
 	}
 
 	if !legit {
-		return nil, errors.New("Unable to locate folder with id " + strconv.Itoa(int(in.NewFolderId)))
+		return status.Errorf(codes.NotFound, "Unable to locate folder with id %v", in.NewFolderId)
 	}
 
+	if bulk {
+		syncer.throttle()
+	}
 	syncer.retr.MoveToFolder(int(in.Release.FolderId), int(in.Release.Id), int(in.Release.InstanceId), int(in.NewFolderId))
 	oldFolder := in.Release.FolderId
-	fullRelease, _ := syncer.retr.GetRelease(int(in.Release.Id))
+
+	var fullRelease pbd.Release
+	var local *pbd.Release
+	if !refresh {
+		for _, r := range syncer.getReleases(oldFolder).GetReleases() {
+			if r.Id == in.Release.Id {
+				local = r
+			}
+		}
+	}
+	if local != nil {
+		fullRelease = *local
+	} else {
+		fullRelease, _ = syncer.retr.GetRelease(int(in.Release.Id))
+	}
 	fullRelease.FolderId = int32(in.NewFolderId)
 
 	syncer.Log(fmt.Sprintf("Moving %v from %v to %v", in.Release.Id, in.Release.FolderId, in.NewFolderId))
 	syncer.saveRelease(&fullRelease, in.NewFolderId)
 	syncer.deleteRelease(&fullRelease, oldFolder)
+	syncer.touch(fullRelease.Id)
 
-	syncer.saveCollection()
-
-	return &pb.Empty{}, nil
+	return nil
 }
 
 func match(query string, str string) bool {
@@ -146,13 +176,23 @@ func (syncer *Syncer) Search(ctx context.Context, req *pb.SearchRequest) (*pb.Re
 	all, _ := syncer.GetCollection(ctx, &pb.Empty{})
 	fil := &pb.ReleaseList{}
 	for _, rel := range all.Releases {
-		if match(req.Query, rel.Title) || match(req.Query, pbd.GetReleaseArtist(*rel)) {
+		md := syncer.findMetadata(rel.Id)
+		if (match(req.Query, rel.Title) || match(req.Query, pbd.GetReleaseArtist(*rel))) && matchFields(md, rel, req.Fields) && matchTags(md, rel, req.Tags) {
 			fil.Releases = append(fil.Releases, rel)
 		}
 	}
 	return fil, nil
 }
 
+// spendCost is what we count a release as costing, records without a cost are
+// assumed to have been 30 quid
+func spendCost(metadata *pb.ReleaseMetadata) int32 {
+	if metadata.Cost == 0 {
+		return 3000
+	}
+	return metadata.Cost
+}
+
 // GetSpend gets the spend
 func (syncer *Syncer) GetSpend(ctx context.Context, req *pb.SpendRequest) (*pb.SpendResponse, error) {
 	spend := 0
@@ -161,12 +201,8 @@ func (syncer *Syncer) GetSpend(ctx context.Context, req *pb.SpendRequest) (*pb.S
 	for _, rel := range col.Releases {
 		_, metadata := syncer.GetRelease(rel.Id, rel.FolderId)
 		datev := time.Unix(metadata.DateAdded, 0)
-		if (req.Year <= 0 || datev.Year() == int(req.Year)) && (req.Month <= 0 || int32(datev.Month()) == req.Month) && (req.Lower <= 0 || (metadata.DateAdded >= req.Lower && metadata.DateAdded <= req.Upper)) {
-			if metadata.Cost == 0 {
-				spend += 3000
-			} else {
-				spend += int(metadata.Cost)
-			}
+		if (req.Year <= 0 || datev.Year() == int(req.Year)) && (req.Month <= 0 || int32(datev.Month()) == req.Month) && (req.Lower <= 0 || (metadata.DateAdded >= req.Lower && metadata.DateAdded <= req.Upper)) && matchTags(metadata, rel, req.Tags) {
+			spend += int(spendCost(metadata))
 			updates = append(updates, &pb.MetadataUpdate{Release: rel, Update: metadata})
 		}
 	}
@@ -176,14 +212,20 @@ func (syncer *Syncer) GetSpend(ctx context.Context, req *pb.SpendRequest) (*pb.S
 
 // AddWant adds a want to our list
 func (syncer *Syncer) AddWant(ctx context.Context, req *pb.Want) (*pb.Empty, error) {
-	//Add the want to discogs
-	syncer.retr.AddToWantlist(int(req.ReleaseId))
+	// Master wants only live locally until they're expanded
+	if req.ReleaseId != 0 {
+		//Add the want to discogs
+		syncer.retr.AddToWantlist(int(req.ReleaseId))
 
-	//Save and store the want
-	release, _ := syncer.retr.GetRelease(int(req.ReleaseId))
-	syncer.saveRelease(&release, -5)
+		//Save and store the want
+		release, _ := syncer.retr.GetRelease(int(req.ReleaseId))
+		syncer.cacheRelease(&release)
+	}
 
 	//Add the want internally
+	if req.DateAdded == 0 {
+		req.DateAdded = time.Now().Unix()
+	}
 	syncer.collection.Wantlist.Want = append(syncer.collection.Wantlist.Want, req)
 	return &pb.Empty{}, nil
 }
@@ -212,6 +254,73 @@ func (syncer *Syncer) saveMetadata(rel *godiscogs.Release) {
 	}
 }
 
+// maxCachedLookups is how many releases we hold on to from lookups of things we neither
+// own nor want; wanted releases are always cached
+const maxCachedLookups = 200
+
+// cacheRelease stores a release that isn't in the collection
+func (syncer *Syncer) cacheRelease(rel *pbd.Release) {
+	if syncer.collection.Cache == nil {
+		syncer.collection.Cache = &pb.ReleaseList{}
+	}
+
+	for i, r := range syncer.collection.Cache.Releases {
+		if r.Id == rel.Id {
+			syncer.collection.Cache.Releases[i] = rel
+			return
+		}
+	}
+	syncer.collection.Cache.Releases = append(syncer.collection.Cache.Releases, rel)
+	syncer.pruneCache()
+}
+
+// wantedRelease reports whether a release is on the wantlist
+func (syncer *Syncer) wantedRelease(id int32) bool {
+	for _, want := range syncer.collection.Wantlist.Want {
+		if want.ReleaseId == id {
+			return true
+		}
+	}
+	return false
+}
+
+// pruneCache drops the oldest lookups once there are more than maxCachedLookups of them
+func (syncer *Syncer) pruneCache() {
+	if syncer.collection.Cache == nil {
+		return
+	}
+
+	lookups := 0
+	for _, r := range syncer.collection.Cache.Releases {
+		if !syncer.wantedRelease(r.Id) {
+			lookups++
+		}
+	}
+
+	var kept []*pbd.Release
+	for _, r := range syncer.collection.Cache.Releases {
+		if lookups > maxCachedLookups && !syncer.wantedRelease(r.Id) {
+			lookups--
+			continue
+		}
+		kept = append(kept, r)
+	}
+	syncer.collection.Cache.Releases = kept
+}
+
+func (syncer *Syncer) uncacheRelease(id int32) {
+	if syncer.collection.Cache == nil {
+		return
+	}
+
+	for i, r := range syncer.collection.Cache.Releases {
+		if r.Id == id {
+			syncer.collection.Cache.Releases = append(syncer.collection.Cache.Releases[:i], syncer.collection.Cache.Releases[i+1:]...)
+			return
+		}
+	}
+}
+
 func (syncer *Syncer) saveRelease(rel *pbd.Release, folder int32) {
 	foundFolder := false
 	for _, f := range syncer.collection.Folders {
@@ -253,17 +362,74 @@ type saver interface {
 	AddToWantlist(releaseID int)
 	SellRecord(releaseID int, price float32, state string)
 	GetSalePrice(releaseID int) float32
+	GetMasterReleases(masterID int) ([]pbd.Release, error)
+	CreateFolder(name string) (pbd.Folder, error)
+	RenameFolder(folderID int, name string) error
+	DeleteFolder(folderID int) error
 }
 
 // EditWant edits a want in the wantlist
 func (syncer *Syncer) EditWant(ctx context.Context, wantIn *pb.Want) (*pb.Want, error) {
+	fields, err := editedWantFields(wantIn)
+	if err != nil {
+		return nil, err
+	}
+
 	for _, want := range syncer.collection.Wantlist.Want {
-		if want.ReleaseId == wantIn.ReleaseId {
-			want.Valued = wantIn.Valued
+		if sameWant(want, wantIn) {
+			for _, f := range fields {
+				f.set(want, wantIn)
+			}
+
+			syncer.saveCollection()
+			return want, nil
+		}
+	}
+
+	return nil, status.Errorf(codes.NotFound, "Unable to find want for release %v / master %v", wantIn.ReleaseId, wantIn.MasterId)
+}
+
+func (syncer *Syncer) findMetadata(id int32) *pb.ReleaseMetadata {
+	for _, m := range syncer.collection.Metadata {
+		if m.Id == id {
+			return m
+		}
+	}
+	return nil
+}
+
+// findInstance finds a copy of a release in the collection, any copy will do if instance is zero
+func (syncer *Syncer) findInstance(id int32, instance int32) *pbd.Release {
+	for _, f := range syncer.collection.Folders {
+		for _, r := range f.GetReleases().GetReleases() {
+			if r.Id == id && (instance == 0 || r.InstanceId == instance) {
+				return r
+			}
 		}
 	}
+	return nil
+}
 
-	return wantIn, nil
+// holdsInstance checks whether we already have a copy of a release in the collection
+func (syncer *Syncer) holdsInstance(id int32, instance int32) bool {
+	return syncer.findInstance(id, instance) != nil
+}
+
+// holdsAnything checks whether there's anything at all in the local collection
+func (syncer *Syncer) holdsAnything() bool {
+	for _, f := range syncer.collection.Folders {
+		if len(f.GetReleases().GetReleases()) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// touch records that we've just moved, rated, edited or played a release
+func (syncer *Syncer) touch(id int32) {
+	if m := syncer.findMetadata(id); m != nil {
+		m.LastTouched = time.Now().Unix()
+	}
 }
 
 func (syncer *Syncer) getRelease(rID int) (*pbd.Release, error) {
@@ -277,17 +443,29 @@ func (syncer *Syncer) getRelease(rID int) (*pbd.Release, error) {
 	return proto.Clone(&release).(*pbd.Release), err
 }
 
-// SaveCollection writes out the full collection to files.
-func (syncer *Syncer) SaveCollection() {
+// SaveCollection writes out the full collection to files, returning any wants
+// which have turned up in the collection.
+func (syncer *Syncer) SaveCollection() []*pb.Want {
 	releases := syncer.retr.GetCollection()
 	masterMap := make(map[int32][]int32)
 	rMap := make(map[int32][]int32)
+	var owned []*pbd.Release
+	var purchases []*pbd.Release
+
+	// With nothing held locally (a first sync, or a lost keystore) we can't tell new
+	// purchases from the records we've always had
+	seen := syncer.holdsAnything()
 	for _, release := range releases {
+		purchased := seen && release.FolderId == uncategorized && !syncer.holdsInstance(release.Id, release.InstanceId)
 		fullRelease, _ := syncer.getRelease(int(release.Id))
 		fullRelease.InstanceId = release.InstanceId
 		fullRelease.FolderId = release.FolderId
 		fullRelease.Rating = release.Rating
 		syncer.saveRelease(fullRelease, release.FolderId)
+		owned = append(owned, fullRelease)
+		if purchased {
+			purchases = append(purchases, fullRelease)
+		}
 		if _, ok := masterMap[fullRelease.MasterId]; ok {
 			masterMap[fullRelease.MasterId] = append(masterMap[fullRelease.MasterId], fullRelease.Id)
 			rMap[fullRelease.Id] = append(rMap[fullRelease.Id], release.FolderId)
@@ -314,6 +492,8 @@ func (syncer *Syncer) SaveCollection() {
 		}
 	}
 
+	fulfilled := syncer.reconcileWants(owned)
+
 	//Process out the multi release map
 	for key, value := range masterMap {
 		for _, rel := range value {
@@ -323,7 +503,6 @@ func (syncer *Syncer) SaveCollection() {
 			} else {
 				meta.Others = false
 			}
-			syncer.doMetadataUpdate(&pb.MetadataUpdate{Release: &godiscogs.Release{Id: rel}, Update: meta})
 		}
 	}
 
@@ -337,11 +516,15 @@ func (syncer *Syncer) SaveCollection() {
 			}
 		}
 		if !found {
-			syncer.collection.Folders = append(syncer.collection.Folders, &pb.CollectionFolder{Folder: &f, Releases: &pb.ReleaseList{Releases: make([]*pbd.Release, 0)}})
+			folder := f
+			syncer.collection.Folders = append(syncer.collection.Folders, &pb.CollectionFolder{Folder: &folder, Releases: &pb.ReleaseList{Releases: make([]*pbd.Release, 0)}})
 		}
 	}
 
+	syncer.applyRules(purchases)
+
 	syncer.saveCollection()
+	return fulfilled
 }
 
 // SyncWantlist syncs the wantlist with the server
@@ -361,15 +544,18 @@ func (syncer *Syncer) SyncWantlist() {
 		if seen {
 			val.Wanted = true
 		} else {
-			syncer.collection.Wantlist.Want = append(syncer.collection.Wantlist.Want, &pb.Want{ReleaseId: want.Id, Valued: false, Wanted: true})
+			syncer.collection.Wantlist.Want = append(syncer.collection.Wantlist.Want, &pb.Want{ReleaseId: want.Id, Valued: false, Wanted: true, DateAdded: time.Now().Unix()})
 		}
 	}
 
 	// Cache the want list releases
 	for _, want := range syncer.collection.Wantlist.Want {
-		release, _ := syncer.getRelease(int(want.ReleaseId))
-		syncer.saveRelease(release, -5)
+		if want.ReleaseId != 0 {
+			release, _ := syncer.getRelease(int(want.ReleaseId))
+			syncer.cacheRelease(release)
+		}
 	}
+	syncer.pruneCache()
 
 	syncer.saveCollection()
 }
@@ -394,17 +580,31 @@ func (syncer *Syncer) GetSingleRelease(ctx context.Context, in *pbd.Release) (*p
 		}
 	}
 
-	//Let's reach out to discogs and see if this is there
+	for _, rel := range syncer.collection.GetCache().GetReleases() {
+		if rel.Id == in.Id {
+			syncer.LogFunction("GetSingleRelease-cache", t1)
+			return rel, nil
+		}
+	}
+
+	//Let's reach out to discogs and see if this is there, caching it for next time
 	frel, err := syncer.retr.GetRelease(int(in.Id))
+	if err != nil {
+		syncer.LogFunction("GetSingleRelease-fail", t1)
+		return nil, status.Errorf(codes.NotFound, "Unable to get release %v from discogs: %v", in.Id, err)
+	}
+	syncer.cacheRelease(&frel)
 	syncer.LogFunction("GetSingleRelease-discogs", t1)
-	return &frel, err
+	return &frel, nil
 }
 
 // CollapseWantlist collapses the wantlist
-func (syncer *Syncer) CollapseWantlist(ctx context.Context, in *pb.Empty) (*pb.Wantlist, error) {
+func (syncer *Syncer) CollapseWantlist(ctx context.Context, in *pb.CollapseRequest) (*pb.Wantlist, error) {
 	for _, want := range syncer.collection.Wantlist.Want {
-		if !want.Valued {
-			syncer.retr.RemoveFromWantlist(int(want.ReleaseId))
+		if (in.PriorityThreshold > 0 && want.Priority < in.PriorityThreshold) || (in.PriorityThreshold <= 0 && !want.Valued) {
+			if want.ReleaseId != 0 {
+				syncer.retr.RemoveFromWantlist(int(want.ReleaseId))
+			}
 			want.Wanted = false
 		}
 	}
@@ -415,7 +615,9 @@ func (syncer *Syncer) CollapseWantlist(ctx context.Context, in *pb.Empty) (*pb.W
 // RebuildWantlist rebuilds the wantlist
 func (syncer *Syncer) RebuildWantlist(ctx context.Context, in *pb.Empty) (*pb.Wantlist, error) {
 	for _, want := range syncer.collection.Wantlist.Want {
-		syncer.retr.AddToWantlist(int(want.ReleaseId))
+		if want.ReleaseId != 0 {
+			syncer.retr.AddToWantlist(int(want.ReleaseId))
+		}
 		want.Wanted = true
 	}
 
@@ -426,8 +628,7 @@ func (syncer *Syncer) RebuildWantlist(ctx context.Context, in *pb.Empty) (*pb.Wa
 func (syncer *Syncer) AddToFolder(ctx context.Context, in *pb.ReleaseMove) (*pb.Empty, error) {
 	syncer.retr.AddToFolder(int(in.NewFolderId), int(in.Release.Id))
 	fullRelease, _ := syncer.retr.GetRelease(int(in.Release.Id))
-	syncer.
-		fullRelease.FolderId = int32(in.NewFolderId)
+	fullRelease.FolderId = int32(in.NewFolderId)
 	syncer.saveRelease(&fullRelease, in.NewFolderId)
 	syncer.saveCollection()
 	return &pb.Empty{}, nil
@@ -439,23 +640,43 @@ func (syncer *Syncer) UpdateRating(ctx context.Context, in *pbd.Release) (*pb.Em
 	fullRelease, _ := syncer.GetRelease(in.Id, in.FolderId)
 	fullRelease.Rating = int32(in.Rating)
 	syncer.saveRelease(fullRelease, fullRelease.FolderId)
+	syncer.touch(fullRelease.Id)
 	return &pb.Empty{}, nil
 }
 
 func (syncer *Syncer) doMetadataUpdate(in *pb.MetadataUpdate) (*pb.ReleaseMetadata, error) {
+	if in.Release == nil || in.Update == nil {
+		return nil, status.Error(codes.InvalidArgument, "Metadata updates need a release and an update")
+	}
+
+	for _, field := range in.Update.Custom {
+		if err := syncer.validateField(field); err != nil {
+			return nil, err
+		}
+	}
+
 	_, metadata := syncer.GetRelease(in.Release.Id, in.Release.FolderId)
 
 	if metadata == nil {
-		return nil, errors.New("Unable to locate metadata")
+		return nil, status.Error(codes.NotFound, "Unable to locate metadata")
 	}
 
-	proto.Merge(metadata, in.Update)
+	// Custom fields are merged by name rather than appended
+	update := *in.Update
+	update.Custom = nil
+	proto.Merge(metadata, &update)
+	metadata.Custom = mergeFields(metadata.Custom, in.Update.Custom)
 
 	// Manual set of boolean fields
 	if !in.Update.Others {
 		metadata.Others = false
 	}
 
+	// We only know what we paid against a want once we know the cost
+	if metadata.WantFulfilled > 0 && metadata.WantPrice == 0 {
+		metadata.WantPrice = metadata.Cost
+	}
+
 	return metadata, nil
 }
 
@@ -468,6 +689,7 @@ func (syncer *Syncer) UpdateMetadata(ctx context.Context, in *pb.MetadataUpdate)
 	if err != nil {
 		return m, err
 	}
+	syncer.touch(m.Id)
 
 	syncer.saveCollection()
 	syncer.LogFunction("UpdateMetadata", t)
@@ -475,15 +697,26 @@ func (syncer *Syncer) UpdateMetadata(ctx context.Context, in *pb.MetadataUpdate)
 }
 
 // GetWantlist gets the wantlist
-func (syncer *Syncer) GetWantlist(ctx context.Context, in *pb.Empty) (*pb.Wantlist, error) {
-	return syncer.collection.Wantlist, nil
+func (syncer *Syncer) GetWantlist(ctx context.Context, in *pb.WantlistRequest) (*pb.Wantlist, error) {
+	wants := &pb.Wantlist{}
+	for _, want := range syncer.collection.Wantlist.Want {
+		if matchWant(want, in) {
+			wants.Want = append(wants.Want, want)
+		}
+	}
+
+	sortWants(wants.Want, in.Sort)
+	return wants, nil
 }
 
 // GetMetadata gets the metadata for a given release
 func (syncer *Syncer) GetMetadata(ctx context.Context, in *pbd.Release) (*pb.ReleaseMetadata, error) {
 	t := time.Now()
 
-	if m, ok := syncer.mMap[in.Id]; ok {
+	syncer.mapM.Lock()
+	m, ok := syncer.mMap[in.Id]
+	syncer.mapM.Unlock()
+	if ok {
 		syncer.LogFunction("GetMetadata-cache", t)
 		return m, nil
 	}
@@ -491,10 +724,12 @@ func (syncer *Syncer) GetMetadata(ctx context.Context, in *pbd.Release) (*pb.Rel
 	_, metadata := syncer.GetRelease(in.Id, in.FolderId)
 	if metadata == nil {
 		syncer.LogFunction("GetMetadata-fail", t)
-		return nil, errors.New("Failed  to get metadata for release")
+		return nil, status.Error(codes.NotFound, "Failed  to get metadata for release")
 	}
 	syncer.LogFunction("GetMetadata", t)
+	syncer.mapM.Lock()
 	syncer.mMap[in.Id] = metadata
+	syncer.mapM.Unlock()
 	return metadata, nil
 }
 
@@ -503,6 +738,11 @@ func (syncer *Syncer) GetReleasesInFolder(ctx context.Context, in *pb.FolderList
 	t := time.Now()
 	releases := pb.ReleaseList{}
 	for _, folderSpec := range in.Folders {
+		if sf := syncer.findSmartFolder(folderSpec); sf != nil {
+			releases.Releases = append(releases.Releases, syncer.evaluateSmartFolder(sf)...)
+			continue
+		}
+
 		folders := syncer.getFolders()
 		for _, folder := range folders.Folders {
 			if (len(folder.Name) > 0 && folder.Name == folderSpec.Name) || folder.Id == folderSpec.Id {
@@ -517,7 +757,7 @@ func (syncer *Syncer) GetReleasesInFolder(ctx context.Context, in *pb.FolderList
 	for _, r := range releases.Releases {
 		log.Printf("GETTTING METADATA: %v", r)
 		metadata, _ := syncer.GetMetadata(ctx, r)
-		records.Records = append(records.Records, &pb.Record{Release: r, Metadata: metadata})
+		records.Records = append(records.Records, &pb.Record{Release: r, Metadata: metadata, Loan: syncer.currentLoan(r)})
 	}
 
 	syncer.LogFunction("GetReleasesInFolder", t)
@@ -538,9 +778,7 @@ func (syncer *Syncer) GetCollection(ctx context.Context, in *pb.Empty) (*pb.Rele
 	t1 := time.Now()
 	releases := &pb.ReleaseList{}
 	for _, f := range syncer.collection.Folders {
-		if f.Folder.Id != -5 {
-			releases.Releases = append(releases.Releases, f.Releases.Releases...)
-		}
+		releases.Releases = append(releases.Releases, f.Releases.Releases...)
 	}
 	syncer.LogFunction("GetCollection", t1)
 	return releases, nil
@@ -551,32 +789,42 @@ func (syncer *Syncer) DeleteWant(ctx context.Context, in *pb.Want) (*pb.Wantlist
 	//Remove the want file and remove from
 	index := -1
 	for i, val := range syncer.collection.Wantlist.Want {
-		if val.ReleaseId == in.ReleaseId {
+		if sameWant(val, in) {
 			index = i
 		}
 	}
 
-	if index >= 0 {
-		syncer.collection.Wantlist.Want = append(syncer.collection.Wantlist.Want[:index], syncer.collection.Wantlist.Want[index+1:]...)
+	if index < 0 {
+		return nil, status.Errorf(codes.NotFound, "Unable to find want for release %v / master %v", in.ReleaseId, in.MasterId)
 	}
 
-	syncer.retr.RemoveFromWantlist(int(in.ReleaseId))
+	syncer.collection.Wantlist.Want = append(syncer.collection.Wantlist.Want[:index], syncer.collection.Wantlist.Want[index+1:]...)
+	syncer.uncacheRelease(in.ReleaseId)
+
+	if in.ReleaseId != 0 {
+		syncer.retr.RemoveFromWantlist(int(in.ReleaseId))
+	}
 	syncer.saveCollection()
 	return syncer.collection.Wantlist, nil
 }
 
 //Sell sells the record
 func (syncer *Syncer) Sell(ctx context.Context, in *pbd.Release) (*pb.Empty, error) {
-	price := syncer.retr.GetSalePrice(int(in.Id))
-	syncer.retr.SellRecord(int(in.Id), price, "For Sale")
+	syncer.sellRelease(in.Id)
 	return &pb.Empty{}, nil
 }
 
+func (syncer *Syncer) sellRelease(id int32) float32 {
+	price := syncer.retr.GetSalePrice(int(id))
+	syncer.retr.SellRecord(int(id), price, "For Sale")
+	return price
+}
+
 //SyncWithDiscogs Syncs everything with discogs
-func (syncer *Syncer) SyncWithDiscogs(ctx context.Context, in *pb.Empty) (*pb.Empty, error) {
+func (syncer *Syncer) SyncWithDiscogs(ctx context.Context, in *pb.Empty) (*pb.SyncResult, error) {
 	t := time.Now()
-	syncer.SaveCollection()
+	fulfilled := syncer.SaveCollection()
 	syncer.SyncWantlist()
 	syncer.LogFunction("SyncWithDiscogs", t)
-	return &pb.Empty{}, nil
+	return &pb.SyncResult{Fulfilled: fulfilled}, nil
 }
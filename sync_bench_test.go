@@ -0,0 +1,80 @@
+package main
+
+import (
+	"io/ioutil"
+	"log"
+	"os"
+	"testing"
+
+	"golang.org/x/net/context"
+
+	pb "github.com/brotherlogic/discogssyncer/server"
+	pbd "github.com/brotherlogic/godiscogs"
+)
+
+const benchSize = 5000
+
+func benchSyncer(b *testing.B, foldername string) *Syncer {
+	log.SetOutput(ioutil.Discard)
+	os.RemoveAll(foldername)
+	return offlineSyncer(foldername, generate(syntheticConfig{releases: benchSize, wants: benchSize / 10, seed: 1}))
+}
+
+func BenchmarkGetCollection(b *testing.B) {
+	syncer := benchSyncer(b, ".benchcollection")
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		syncer.GetCollection(context.Background(), &pb.Empty{})
+	}
+}
+
+func BenchmarkGetReleasesInFolder(b *testing.B) {
+	syncer := benchSyncer(b, ".benchfolder")
+	folder := syncer.collection.Folders[0].Folder.Id
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		syncer.GetReleasesInFolder(context.Background(), &pb.FolderList{Folders: []*pbd.Folder{&pbd.Folder{Id: folder}}})
+	}
+}
+
+func BenchmarkSearch(b *testing.B) {
+	syncer := benchSyncer(b, ".benchsearch")
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		syncer.Search(context.Background(), &pb.SearchRequest{Query: "Title 42"})
+	}
+}
+
+func BenchmarkGetSpend(b *testing.B) {
+	syncer := benchSyncer(b, ".benchspend")
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		syncer.GetSpend(context.Background(), &pb.SpendRequest{Year: 2010})
+	}
+}
+
+func BenchmarkGetRelease(b *testing.B) {
+	syncer := benchSyncer(b, ".benchrelease")
+	rel := syncer.collection.Folders[0].Releases.Releases[0]
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		syncer.GetRelease(rel.Id, rel.FolderId)
+	}
+}
+
+func BenchmarkUpdateMetadata(b *testing.B) {
+	syncer := benchSyncer(b, ".benchupdate")
+	rel := syncer.collection.Folders[0].Releases.Releases[0]
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		syncer.UpdateMetadata(context.Background(), &pb.MetadataUpdate{Release: &pbd.Release{Id: rel.Id, FolderId: rel.FolderId}, Update: &pb.ReleaseMetadata{Cost: int32(i)}})
+	}
+}
+
+func BenchmarkSaveCollection(b *testing.B) {
+	syncer := benchSyncer(b, ".benchsave")
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		syncer.saveCollection()
+	}
+}
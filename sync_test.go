@@ -10,12 +10,19 @@ import (
 
 	"github.com/brotherlogic/keystore/client"
 	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	pb "github.com/brotherlogic/discogssyncer/server"
 	pbd "github.com/brotherlogic/godiscogs"
 	"github.com/brotherlogic/goserver"
 )
 
+func errorCode(err error) codes.Code {
+	st, _ := status.FromError(err)
+	return st.Code()
+}
+
 type testDiscogsRetriever struct {
 	count bool
 }
@@ -95,6 +102,29 @@ func (testDiscogsRetriever) SellRecord(releaseID int, price float32, state strin
 	// Do nothing
 }
 
+func (testDiscogsRetriever) GetMasterReleases(masterID int) ([]pbd.Release, error) {
+	if masterID == 234 {
+		return []pbd.Release{
+			pbd.Release{Id: 400, MasterId: 234, Country: "US", Released: "1991-03-27"},
+			pbd.Release{Id: 401, MasterId: 234, Country: "UK", Released: "1991"},
+			pbd.Release{Id: 402, MasterId: 234, Country: "US", Released: "2014"},
+		}, nil
+	}
+	return nil, errors.New("Unable to locate master")
+}
+
+func (testDiscogsRetriever) CreateFolder(name string) (pbd.Folder, error) {
+	return pbd.Folder{Id: 40, Name: name}, nil
+}
+
+func (testDiscogsRetriever) RenameFolder(folderID int, name string) error {
+	return nil
+}
+
+func (testDiscogsRetriever) DeleteFolder(folderID int) error {
+	return nil
+}
+
 func TestSellRecord(t *testing.T) {
 	syncer := GetTestSyncer(".testRemoveInstance", true)
 	syncer.SaveCollection()
@@ -316,7 +346,7 @@ func TestGetWantlist(t *testing.T) {
 	syncer := GetTestSyncer(".testwantlist", true)
 	syncer.collection.Wantlist.Want = append(syncer.collection.Wantlist.Want, &pb.Want{ReleaseId: 25})
 	syncer.SyncWantlist()
-	wantlist, err := syncer.GetWantlist(context.Background(), &pb.Empty{})
+	wantlist, err := syncer.GetWantlist(context.Background(), &pb.WantlistRequest{})
 
 	if err != nil {
 		t.Errorf("Error getting wantlist: %v", err)
@@ -335,7 +365,7 @@ func TestAddWant(t *testing.T) {
 		t.Errorf("Error adding want: %v", err)
 	}
 
-	wantlist, err := syncer.GetWantlist(context.Background(), &pb.Empty{})
+	wantlist, err := syncer.GetWantlist(context.Background(), &pb.WantlistRequest{})
 	if err != nil {
 		t.Errorf("Error getting wantlist: %v", err)
 	}
@@ -355,7 +385,7 @@ func TestAddWant(t *testing.T) {
 func TestDeleteWantFully(t *testing.T) {
 	syncer := GetTestSyncer(".testwantlistfully", true)
 	syncer.SyncWantlist()
-	wantlist, err := syncer.GetWantlist(context.Background(), &pb.Empty{})
+	wantlist, err := syncer.GetWantlist(context.Background(), &pb.WantlistRequest{})
 	if err != nil {
 		t.Errorf("Error getting wantlist: %v", err)
 	}
@@ -365,7 +395,7 @@ func TestDeleteWantFully(t *testing.T) {
 	}
 
 	syncer.DeleteWant(context.Background(), &pb.Want{ReleaseId: 256})
-	wantlist, err = syncer.GetWantlist(context.Background(), &pb.Empty{})
+	wantlist, err = syncer.GetWantlist(context.Background(), &pb.WantlistRequest{})
 	if err != nil {
 		t.Errorf("Error getting wantlist: %v", err)
 	}
@@ -374,7 +404,7 @@ func TestDeleteWantFully(t *testing.T) {
 	}
 
 	nsyncer := GetTestSyncerNoDelete(".testwantlistfully")
-	wantlist, err = nsyncer.GetWantlist(context.Background(), &pb.Empty{})
+	wantlist, err = nsyncer.GetWantlist(context.Background(), &pb.WantlistRequest{})
 	if err != nil {
 		t.Errorf("Error getting wantlist: %v", err)
 	}
@@ -390,7 +420,7 @@ func TestSetWant(t *testing.T) {
 	wantedit := &pb.Want{ReleaseId: 256, Valued: true}
 	syncer.EditWant(context.Background(), wantedit)
 
-	wantlist, err := syncer.GetWantlist(context.Background(), &pb.Empty{})
+	wantlist, err := syncer.GetWantlist(context.Background(), &pb.WantlistRequest{})
 	if err != nil {
 		t.Errorf("Error getting wantlist: %v", err)
 	}
@@ -406,7 +436,7 @@ func TestSetWant(t *testing.T) {
 	wantedit.Valued = false
 	syncer.EditWant(context.Background(), wantedit)
 
-	wantlist, err = syncer.GetWantlist(context.Background(), &pb.Empty{})
+	wantlist, err = syncer.GetWantlist(context.Background(), &pb.WantlistRequest{})
 	if err != nil {
 		t.Errorf("Error getting wantlist: %v", err)
 	}
@@ -418,16 +448,12 @@ func TestSetWant(t *testing.T) {
 
 func TestSaveWantDoesNotSaveMetadata(t *testing.T) {
 	syncer := GetTestSyncer(".testsavewantdoesnotsavemetadata", true)
-	r := &pbd.Release{Id: 25, Title: "MadeUpRelease", FolderId: -5}
-	syncer.saveRelease(r, -5)
+	r := &pbd.Release{Id: 25, Title: "MadeUpRelease"}
+	syncer.cacheRelease(r)
 
-	meta, err := syncer.GetMetadata(context.Background(), r)
-	if err != nil {
-		t.Errorf("Failure in get metadata: %v", err)
-	} else {
-		if meta.DateAdded > 0 {
-			t.Errorf("Wantlist sync has set the date added: %v", meta)
-		}
+	meta := syncer.findMetadata(25)
+	if meta != nil && meta.DateAdded > 0 {
+		t.Errorf("Wantlist sync has set the date added: %v", meta)
 	}
 	log.Printf("META = %v", meta)
 
@@ -448,7 +474,7 @@ func TestCollapseWantlist(t *testing.T) {
 	syncer.collection.Wantlist.Want = append(syncer.collection.Wantlist.Want, &pb.Want{ReleaseId: 256, Wanted: true})
 	syncer.collection.Wantlist.Want = append(syncer.collection.Wantlist.Want, &pb.Want{ReleaseId: 257, Valued: true, Wanted: true})
 	syncer.SyncWantlist()
-	wantlist, err := syncer.GetWantlist(context.Background(), &pb.Empty{})
+	wantlist, err := syncer.GetWantlist(context.Background(), &pb.WantlistRequest{})
 	if err != nil {
 		t.Errorf("Error getting wantlist: %v", err)
 	}
@@ -456,7 +482,7 @@ func TestCollapseWantlist(t *testing.T) {
 		t.Errorf("Initial want is not wanted: %v", wantlist)
 	}
 
-	nwantlist, err := syncer.CollapseWantlist(context.Background(), &pb.Empty{})
+	nwantlist, err := syncer.CollapseWantlist(context.Background(), &pb.CollapseRequest{})
 	if err != nil {
 		t.Errorf("Error collapseing wantlist: %v", err)
 	}
@@ -486,13 +512,17 @@ func TestDeleteWant(t *testing.T) {
 	deleteWant := &pb.Want{ReleaseId: 256}
 	syncer.DeleteWant(context.Background(), deleteWant)
 
-	wantlist, err := syncer.GetWantlist(context.Background(), &pb.Empty{})
+	wantlist, err := syncer.GetWantlist(context.Background(), &pb.WantlistRequest{})
 	if err != nil {
 		t.Errorf("Error getting wantlist: %v", err)
 	}
 	if len(wantlist.Want) != 0 {
 		t.Errorf("Wrong number of wants returned: %v", wantlist)
 	}
+
+	if _, err := syncer.DeleteWant(context.Background(), deleteWant); errorCode(err) != codes.NotFound {
+		t.Errorf("Deleting a missing want has not failed: %v", err)
+	}
 }
 
 func TestAddToFolder(t *testing.T) {
@@ -571,8 +601,8 @@ func TestGetReleaseFail(t *testing.T) {
 	syncer := GetTestSyncerNoDelete(".testGetNoRelease")
 	release := &pbd.Release{Id: 250}
 	newRelease, err := syncer.GetSingleRelease(context.Background(), release)
-	if err == nil {
-		t.Errorf("Failed to error on release: %v", newRelease)
+	if errorCode(err) != codes.NotFound {
+		t.Errorf("Failed to error on release: %v (%v)", newRelease, err)
 	}
 }
 
@@ -740,6 +770,7 @@ func GetTestSyncer(foldername string, delete bool) *Syncer {
 
 	syncer.readRecordCollection()
 	syncer.mapM = &sync.Mutex{}
+	syncer.throttleM = &sync.Mutex{}
 
 	return syncer
 }
@@ -920,3 +951,72 @@ func TestGetMetadataFail(t *testing.T) {
 		t.Errorf("Get Metadata of unknown release did not fail")
 	}
 }
+
+func TestMigrateWantFolderToCache(t *testing.T) {
+	syncer := GetTestSyncer(".testmigratewantfolder", true)
+	syncer.collection.Folders = append(syncer.collection.Folders, &pb.CollectionFolder{Folder: &pbd.Folder{Id: -5}, Releases: &pb.ReleaseList{Releases: []*pbd.Release{&pbd.Release{Id: 256}}}})
+	syncer.collection.Folders = append(syncer.collection.Folders, &pb.CollectionFolder{Folder: &pbd.Folder{Id: 23}, Releases: &pb.ReleaseList{Releases: []*pbd.Release{&pbd.Release{Id: 25, FolderId: 23}}}})
+	syncer.saveCollection()
+
+	nsyncer := GetTestSyncerNoDelete(".testmigratewantfolder")
+	if len(nsyncer.collection.Folders) != 1 || nsyncer.collection.Folders[0].Folder.Id != 23 {
+		t.Errorf("Want folder has not been removed: %v", nsyncer.collection.Folders)
+	}
+
+	if len(nsyncer.collection.Cache.Releases) != 1 || nsyncer.collection.Cache.Releases[0].Id != 256 {
+		t.Errorf("Wants have not been moved to the cache: %v", nsyncer.collection.Cache)
+	}
+}
+
+func TestSyncWantlistPrunesCache(t *testing.T) {
+	syncer := GetTestSyncer(".testprunecache", true)
+	syncer.cacheRelease(&pbd.Release{Id: 12345})
+	for i := 0; i < maxCachedLookups; i++ {
+		syncer.collection.Cache.Releases = append(syncer.collection.Cache.Releases, &pbd.Release{Id: int32(20000 + i)})
+	}
+	syncer.SyncWantlist()
+
+	for _, r := range syncer.collection.GetCache().GetReleases() {
+		if r.Id == 12345 {
+			t.Errorf("Oldest lookup is still cached: %v", len(syncer.collection.Cache.Releases))
+		}
+	}
+}
+
+func TestGetSingleReleaseCachesLookups(t *testing.T) {
+	syncer := GetTestSyncer(".testgetsinglereleasecache", true)
+	syncer.collection.Wantlist.Want = append(syncer.collection.Wantlist.Want, &pb.Want{ReleaseId: 33})
+	syncer.GetSingleRelease(context.Background(), &pbd.Release{Id: 33})
+
+	_, err := syncer.GetSingleRelease(context.Background(), &pbd.Release{Id: 32})
+	if err != nil {
+		t.Fatalf("Error getting release: %v", err)
+	}
+	if len(syncer.collection.GetCache().GetReleases()) != 2 {
+		t.Fatalf("Lookup has not been cached: %v", syncer.collection.Cache)
+	}
+
+	// Lookups are evicted oldest first, but wants stay put
+	for i := 0; i < maxCachedLookups; i++ {
+		syncer.GetSingleRelease(context.Background(), &pbd.Release{Id: int32(1000 + i)})
+	}
+	cached := make(map[int32]bool)
+	for _, r := range syncer.collection.GetCache().GetReleases() {
+		cached[r.Id] = true
+	}
+	if len(cached) != maxCachedLookups+1 || cached[32] || !cached[33] || !cached[1000] {
+		t.Errorf("Cache has been badly pruned: %v releases, 32 %v, 33 %v", len(cached), cached[32], cached[33])
+	}
+
+	syncer.DeleteWant(context.Background(), &pb.Want{ReleaseId: 33})
+	for _, r := range syncer.collection.GetCache().GetReleases() {
+		if r.Id == 33 {
+			t.Errorf("Deleted want is still cached")
+		}
+	}
+
+	folders := syncer.getFolders()
+	if len(folders.Folders) != 0 {
+		t.Errorf("Lookup has ended up in a folder: %v", folders)
+	}
+}
@@ -2,8 +2,10 @@ package main
 
 import (
 	"flag"
+	"fmt"
 	"io/ioutil"
 	"log"
+	"os"
 	"sync"
 	"time"
 
@@ -27,6 +29,14 @@ type Syncer struct {
 	recacheList map[int]*pbd.Release
 	mapM        *sync.Mutex
 	lastResync  time.Time
+
+	// Treat any pressing of a wanted master as fulfilling the want
+	matchWantsByMaster bool
+
+	// The minimum gap between the discogs calls we make in bulk operations
+	discogsDelay    time.Duration
+	lastDiscogsCall time.Time
+	throttleM       *sync.Mutex
 }
 
 var (
@@ -60,12 +70,26 @@ func (s *Syncer) readRecordCollection() error {
 		}
 	}
 
+	// Wants used to live in a fake folder with id -5, move them to the cache
+	for i, f := range s.collection.Folders {
+		if f.GetFolder().GetId() == -5 {
+			for _, r := range f.GetReleases().GetReleases() {
+				s.cacheRelease(r)
+			}
+			s.collection.Folders = append(s.collection.Folders[:i], s.collection.Folders[i+1:]...)
+			break
+		}
+	}
+
 	// Build out the release map
 	for _, f := range s.collection.Folders {
 		for _, r := range f.Releases.Releases {
 			s.rMap[int(r.Id)] = r
 		}
 	}
+	for _, r := range s.collection.GetCache().GetReleases() {
+		s.rMap[int(r.Id)] = r
+	}
 
 	return nil
 }
@@ -112,6 +136,7 @@ func InitServer() *Syncer {
 	syncer.PrepServer()
 	syncer.GoServer.KSclient = *keystoreclient.GetClient(syncer.GetIP)
 	syncer.mapM = &sync.Mutex{}
+	syncer.throttleM = &sync.Mutex{}
 
 	return syncer
 }
@@ -135,6 +160,20 @@ func (s Syncer) ReportHealth() bool {
 func main() {
 	var quiet = flag.Bool("quiet", true, "Show all output")
 	var token = flag.String("token", "", "Discogs token")
+	var matchMasters = flag.Bool("match_masters", false, "Remove wants when we own any pressing of the same master")
+	var discogsDelay = flag.Duration("discogs_delay", time.Second, "The minimum gap between discogs calls in bulk moves and imports")
+	var load = flag.Bool("load_test", false, "Run a load test rather than serving")
+	var loadTarget = flag.String("load_target", "", "host:port to load test, an in process server with a synthetic collection if not set")
+	var loadSize = flag.Int("load_size", 5000, "The number of releases in the synthetic collection")
+	var loadSeed = flag.Int64("load_seed", 1, "Seed for the synthetic collection and the workload")
+	var loadWorkers = flag.Int("load_workers", 8, "The number of concurrent callers")
+	var loadDuration = flag.Duration("load_duration", time.Second*30, "How long to run the load test for")
+	var loadWrites = flag.Bool("load_writes", false, "Include metadata and rating updates in the load, don't use against a server you care about")
+	var dev = flag.Bool("dev", false, "Serve a made up collection without a discogs token")
+	var devSize = flag.Int("dev_size", 2000, "The number of releases in the made up collection")
+	var devSeed = flag.Int64("dev_seed", 1, "Seed for the made up collection")
+	var devDir = flag.String("dev_dir", ".dev", "Where to keep the made up collection")
+	var httpAddress = flag.String("http", "127.0.0.1:8085", "Address to serve the JSON gateway and web UI (under /ui/) on, empty to turn it off; neither is authenticated, so keep it local")
 	flag.Parse()
 
 	//Turn off logging
@@ -143,7 +182,31 @@ func main() {
 		log.SetOutput(ioutil.Discard)
 	}
 
+	if *load {
+		err := loadTest(*loadTarget, *loadSize, loadConfig{workers: *loadWorkers, duration: *loadDuration, seed: *loadSeed, writes: *loadWrites}, os.Stdout)
+		if err != nil {
+			// -quiet discards the log, so this has to go straight to stderr
+			fmt.Fprintf(os.Stderr, "Load test failed: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	syncer := InitServer()
+	syncer.matchWantsByMaster = *matchMasters
+	syncer.discogsDelay = *discogsDelay
+
+	if *dev {
+		syncer.startDev(*devDir, syntheticConfig{releases: *devSize, wants: *devSize / 10, seed: *devSeed})
+		syncer.RegisterServingTask(syncer.recacheLoop)
+		if len(*httpAddress) > 0 {
+			syncer.RegisterServingTask(syncer.serveHTTP(*httpAddress))
+		}
+		syncer.Register = syncer
+		syncer.RegisterServer("discogssyncer-dev", false)
+		syncer.Serve()
+		return
+	}
 
 	if len(*token) > 0 {
 		syncer.KSclient.Save(TOKEN, &pb.Token{Token: *token})
@@ -157,9 +220,12 @@ func main() {
 	}
 
 	sToken := tResp.(*pb.Token).Token
-	syncer.retr = pbd.NewDiscogsRetriever(sToken)
+	syncer.retr = newDiscogsAPI(sToken)
 	syncer.token = sToken
 	syncer.RegisterServingTask(syncer.recacheLoop)
+	if len(*httpAddress) > 0 {
+		syncer.RegisterServingTask(syncer.serveHTTP(*httpAddress))
+	}
 
 	syncer.Register = syncer
 	syncer.RegisterServer("discogssyncer", false)
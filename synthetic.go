@@ -0,0 +1,463 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/brotherlogic/keystore/client"
+
+	pb "github.com/brotherlogic/discogssyncer/server"
+	pbd "github.com/brotherlogic/godiscogs"
+)
+
+// syntheticConfig describes the collection to make up
+type syntheticConfig struct {
+	releases int
+	wants    int
+	seed     int64
+}
+
+// synthetic is a made up collection along with every release we made up, owned or not
+type synthetic struct {
+	collection *pb.RecordCollection
+	catalog    []*pbd.Release
+	folders    []*pbd.Folder
+}
+
+var (
+	synthAdjectives = []string{"Blue", "Silent", "Electric", "Golden", "Broken", "Velvet", "Midnight", "Crimson", "Hollow", "Northern", "Wild", "Paper", "Glass", "Lonely", "Burning"}
+	synthNouns      = []string{"Train", "River", "Garden", "Machine", "Mirror", "Horizon", "Engine", "Orchard", "Signal", "Harbour", "Window", "Canyon", "Lantern", "Tide", "Satellite"}
+	synthFirst      = []string{"Miles", "Nina", "Arthur", "Dolores", "Sonny", "Grace", "Otis", "Ella", "Lee", "Alice", "Curtis", "June", "Harold", "Mavis", "Wes"}
+	synthLast       = []string{"Coleman", "Reed", "Hawkins", "Simone", "Mayfield", "Parker", "Jordan", "Holiday", "Morgan", "Baker", "Staples", "Turner", "Shaw", "Young", "Davis"}
+	synthEnsembles  = []string{"Quartet", "Trio", "Orchestra", "Band", "Sextet"}
+	synthLabels     = []string{"Blue Note", "Impulse!", "Verve", "Stax", "Rough Trade", "Factory", "Warp", "4AD", "Sub Pop", "Island", "Chess", "Atlantic", "Mute", "Creation", "Prestige"}
+	synthCountries  = []string{"US", "UK", "UK", "US", "Germany", "France", "Japan", "Netherlands", "Canada", "Europe"}
+	synthGenres     = map[string][]string{
+		"Jazz":        {"Hard Bop", "Modal", "Free Jazz", "Cool Jazz", "Soul-Jazz"},
+		"Rock":        {"Indie Rock", "Post-Punk", "Shoegaze", "Psychedelic Rock", "Garage Rock"},
+		"Electronic":  {"Techno", "Ambient", "IDM", "House", "Downtempo"},
+		"Funk / Soul": {"Soul", "Funk", "Northern Soul", "Disco"},
+		"Folk":        {"Folk Rock", "Acoustic", "Country Blues"},
+	}
+	synthGenreNames = []string{"Jazz", "Rock", "Electronic", "Funk / Soul", "Folk"}
+)
+
+// The made up collection runs between these dates so the same seed gives the same dates
+var (
+	synthStart = time.Date(2005, 1, 1, 0, 0, 0, 0, time.UTC).Unix()
+	synthEnd   = time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC).Unix()
+)
+
+// synthFormat is a kind of pressing and the range of what we'd pay for it in pence
+type synthFormat struct {
+	name         string
+	descriptions []string
+	low, high    int
+}
+
+var synthFormats = []synthFormat{
+	{"Vinyl", []string{"LP", "Album"}, 1200, 4000},
+	{"Vinyl", []string{"LP", "Album"}, 1200, 4000},
+	{"Vinyl", []string{"LP", "Album", "Reissue"}, 1500, 2800},
+	{"Vinyl", []string{"7\"", "Single"}, 300, 900},
+	{"Vinyl", []string{"12\"", "EP"}, 700, 1500},
+	{"CD", []string{"Album"}, 400, 1200},
+}
+
+func pick(r *rand.Rand, list []string) string {
+	return list[r.Intn(len(list))]
+}
+
+func synthArtist(r *rand.Rand) string {
+	switch r.Intn(3) {
+	case 0:
+		return fmt.Sprintf("The %v %vs", pick(r, synthAdjectives), pick(r, synthNouns))
+	case 1:
+		return fmt.Sprintf("%v %v", pick(r, synthFirst), pick(r, synthLast))
+	}
+	return fmt.Sprintf("%v %v", pick(r, synthLast), pick(r, synthEnsembles))
+}
+
+func synthTitle(r *rand.Rand) string {
+	switch r.Intn(3) {
+	case 0:
+		return fmt.Sprintf("%v %v", pick(r, synthAdjectives), pick(r, synthNouns))
+	case 1:
+		return fmt.Sprintf("The %v", pick(r, synthNouns))
+	}
+	return fmt.Sprintf("%v of the %v", pick(r, synthNouns), pick(r, synthNouns))
+}
+
+// generate makes up a collection, the same config always gives the same collection
+func generate(cfg syntheticConfig) *synthetic {
+	r := rand.New(rand.NewSource(cfg.seed))
+	s := &synthetic{}
+
+	// Discogs puts new purchases in Uncategorized, the rest are filed by genre and format
+	s.folders = append(s.folders, &pbd.Folder{Id: uncategorized, Name: "Uncategorized"})
+	folderFor := make(map[string]*pbd.Folder)
+	for i, name := range append(append([]string{}, synthGenreNames...), "7 Inches", "CDs") {
+		folderFor[name] = &pbd.Folder{Id: int32(100 + i), Name: name}
+		s.folders = append(s.folders, folderFor[name])
+	}
+
+	artists := make([]string, cfg.releases/6+1)
+	for i := range artists {
+		artists[i] = synthArtist(r)
+	}
+
+	var owned []*pbd.Release
+	releaseID, masterID := int32(100000), int32(10000)
+	for len(owned) < cfg.releases {
+		masterID++
+		artist := artists[r.Intn(len(artists))]
+		title := synthTitle(r)
+		genre := pick(r, synthGenreNames)
+		styles := []string{pick(r, synthGenres[genre])}
+		year := 1955 + r.Intn(60)
+
+		// Some releases never had a master, most masters have a few pressings
+		pressings, master := 1+r.Intn(4), masterID
+		if r.Intn(10) == 0 {
+			pressings, master = 1, 0
+		}
+
+		for p := 0; p < pressings; p++ {
+			releaseID++
+			format := synthFormats[r.Intn(len(synthFormats))]
+			pressed := year
+			if p > 0 {
+				pressed += r.Intn(2018 - year)
+			}
+			label := pick(r, synthLabels)
+			rel := &pbd.Release{
+				Id:       releaseID,
+				Title:    title,
+				Artists:  []*pbd.Artist{&pbd.Artist{Name: artist}},
+				Labels:   []*pbd.Label{&pbd.Label{Name: label, Catno: fmt.Sprintf("%v-%v", label[:2], 1000+r.Intn(9000))}},
+				Formats:  []*pbd.Format{&pbd.Format{Name: format.name, Descriptions: format.descriptions, Qty: "1"}},
+				Released: fmt.Sprintf("%v", pressed),
+				Country:  pick(r, synthCountries),
+				MasterId: master,
+				Genres:   []string{genre},
+				Styles:   styles,
+			}
+			s.catalog = append(s.catalog, rel)
+
+			// We usually own the original and sometimes the odd reissue
+			if (p == 0 && r.Intn(5) > 0) || r.Intn(4) == 0 {
+				owned = append(owned, rel)
+			}
+		}
+	}
+	owned = owned[:cfg.releases]
+
+	s.collection = &pb.RecordCollection{Wantlist: &pb.Wantlist{}, Cache: &pb.ReleaseList{}}
+	folders := make(map[int32]*pb.CollectionFolder)
+	for _, f := range s.folders {
+		folders[f.Id] = &pb.CollectionFolder{Folder: f, Releases: &pb.ReleaseList{}}
+		s.collection.Folders = append(s.collection.Folders, folders[f.Id])
+	}
+
+	// The collection grows over time, so later releases were added later
+	instanceID := int32(1000000)
+	span := synthEnd - synthStart
+	for i, template := range owned {
+		copies := 1
+		if r.Intn(100) == 0 {
+			copies = 2
+		}
+		for c := 0; c < copies; c++ {
+			rel := *template
+			instanceID++
+			rel.InstanceId = instanceID
+			if r.Intn(10) < 6 {
+				rel.Rating = int32(1 + r.Intn(5))
+			}
+
+			folder := folderFor[rel.Genres[0]]
+			switch {
+			case c > 0 || i >= len(owned)-len(owned)/50:
+				folder = s.folders[0]
+			case rel.Formats[0].Name == "CD":
+				folder = folderFor["CDs"]
+			case rel.Formats[0].Descriptions[0] == "7\"":
+				folder = folderFor["7 Inches"]
+			}
+			rel.FolderId = folder.Id
+			folders[folder.Id].Releases.Releases = append(folders[folder.Id].Releases.Releases, &rel)
+		}
+
+		format := synthFormats[0]
+		for _, f := range synthFormats {
+			if f.name == template.Formats[0].Name && f.descriptions[0] == template.Formats[0].Descriptions[0] {
+				format = f
+			}
+		}
+		added := synthStart + span*int64(i)/int64(len(owned)) + r.Int63n(86400*7)
+		md := &pb.ReleaseMetadata{
+			Id:            template.Id,
+			DateAdded:     added,
+			DateRefreshed: added + r.Int63n(86400*365),
+			Others:        copies > 1,
+		}
+		md.LastCache = md.DateRefreshed
+		if r.Intn(20) > 0 {
+			md.Cost = int32(format.low + r.Intn(format.high-format.low))
+		}
+		s.collection.Metadata = append(s.collection.Metadata, md)
+	}
+
+	s.generateWants(r, cfg.wants, owned)
+	return s
+}
+
+// generateWants adds wants for pressings and masters we don't own
+func (s *synthetic) generateWants(r *rand.Rand, count int, owned []*pbd.Release) {
+	have := make(map[int32]bool)
+	haveMaster := make(map[int32]bool)
+	for _, rel := range owned {
+		have[rel.Id] = true
+		haveMaster[rel.MasterId] = true
+	}
+
+	var candidates []*pbd.Release
+	for _, rel := range s.catalog {
+		if !have[rel.Id] {
+			candidates = append(candidates, rel)
+		}
+	}
+
+	wanted := make(map[int32]bool)
+	for _, i := range r.Perm(len(candidates)) {
+		rel := candidates[i]
+		if len(s.collection.Wantlist.Want) >= count {
+			break
+		}
+		want := &pb.Want{
+			Priority:  int32(r.Intn(6)),
+			DateAdded: synthStart + r.Int63n(synthEnd-synthStart),
+			Valued:    r.Intn(3) > 0,
+		}
+		if r.Intn(100) < 70 {
+			want.MaxPrice = int32(1000 + 100*r.Intn(40))
+		}
+
+		// A master we have nothing from is wanted in any pressing
+		if rel.MasterId > 0 && !haveMaster[rel.MasterId] && !wanted[-rel.MasterId] && r.Intn(5) == 0 {
+			wanted[-rel.MasterId] = true
+			want.MasterId = rel.MasterId
+			want.Format = rel.Formats[0].Name
+		} else {
+			want.ReleaseId = rel.Id
+			want.Wanted = true
+			s.collection.Cache.Releases = append(s.collection.Cache.Releases, rel)
+		}
+		s.collection.Wantlist.Want = append(s.collection.Wantlist.Want, want)
+	}
+}
+
+// syntheticCollection is a made up collection of the given size with a wantlist to match
+func syntheticCollection(size int, seed int64) *pb.RecordCollection {
+	return generate(syntheticConfig{releases: size, wants: size / 10, seed: seed}).collection
+}
+
+// startDev points the syncer at a made up collection kept under dir, making it up the
+// first time round, and a stand in for discogs which serves it
+func (syncer *Syncer) startDev(dir string, cfg syntheticConfig) {
+	data := generate(cfg)
+	syncer.GoServer.KSclient = *keystoreclient.GetTestClient(dir)
+	syncer.discogsDelay = 0
+	if err := syncer.readRecordCollection(); err == nil && len(syncer.collection.Folders) > 0 {
+		data.collection = syncer.collection
+	} else {
+		syncer.collection = data.collection
+		syncer.saveCollection()
+	}
+	syncer.retr = newOfflineRetriever(data)
+}
+
+// offlineRetriever stands in for discogs when we're working without a token, serving up
+// a made up collection and remembering what we do to it
+type offlineRetriever struct {
+	mutex   *sync.Mutex
+	folders []pbd.Folder
+	owned   []pbd.Release
+	catalog map[int32]*pbd.Release
+	wants   map[int32]bool
+	nextID  int32
+}
+
+// newOfflineRetriever serves up s, which can be nil for an empty discogs
+func newOfflineRetriever(s *synthetic) *offlineRetriever {
+	o := &offlineRetriever{mutex: &sync.Mutex{}, catalog: make(map[int32]*pbd.Release), wants: make(map[int32]bool), nextID: 5000000}
+	if s == nil {
+		return o
+	}
+
+	for _, rel := range s.catalog {
+		o.catalog[rel.Id] = rel
+	}
+	for _, f := range s.collection.Folders {
+		o.folders = append(o.folders, *f.Folder)
+		for _, rel := range f.GetReleases().GetReleases() {
+			o.owned = append(o.owned, *rel)
+		}
+	}
+	for _, w := range s.collection.Wantlist.Want {
+		if w.ReleaseId > 0 {
+			o.wants[w.ReleaseId] = true
+		}
+	}
+	return o
+}
+
+func (o *offlineRetriever) GetCollection() []pbd.Release {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	return append([]pbd.Release{}, o.owned...)
+}
+
+func (o *offlineRetriever) GetFolders() []pbd.Folder {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	return append([]pbd.Folder{}, o.folders...)
+}
+
+func (o *offlineRetriever) GetRelease(id int) (pbd.Release, error) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	if rel, ok := o.catalog[int32(id)]; ok {
+		return *rel, nil
+	}
+	return pbd.Release{Id: int32(id)}, fmt.Errorf("Unknown release %v", id)
+}
+
+func (o *offlineRetriever) MoveToFolder(folderID int, releaseID int, instanceID int, newFolderID int) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	for i, rel := range o.owned {
+		if rel.Id == int32(releaseID) && (instanceID == 0 || rel.InstanceId == int32(instanceID)) {
+			o.owned[i].FolderId = int32(newFolderID)
+			return
+		}
+	}
+}
+
+func (o *offlineRetriever) AddToFolder(folderID int, releaseID int) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	rel := pbd.Release{Id: int32(releaseID)}
+	if known, ok := o.catalog[int32(releaseID)]; ok {
+		rel = *known
+	}
+	o.nextID++
+	rel.InstanceId = o.nextID
+	rel.FolderId = int32(folderID)
+	o.owned = append(o.owned, rel)
+}
+
+func (o *offlineRetriever) SetRating(folderID int, releaseID int, instanceID int, rating int) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	for i, rel := range o.owned {
+		if rel.Id == int32(releaseID) && (instanceID == 0 || rel.InstanceId == int32(instanceID)) {
+			o.owned[i].Rating = int32(rating)
+		}
+	}
+}
+
+func (o *offlineRetriever) GetWantlist() ([]pbd.Release, error) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	var wants []pbd.Release
+	for id := range o.wants {
+		rel := pbd.Release{Id: id}
+		if known, ok := o.catalog[id]; ok {
+			rel = *known
+		}
+		wants = append(wants, rel)
+	}
+	sort.Sort(byReleaseID(wants))
+	return wants, nil
+}
+
+func (o *offlineRetriever) RemoveFromWantlist(releaseID int) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	delete(o.wants, int32(releaseID))
+}
+
+func (o *offlineRetriever) AddToWantlist(releaseID int) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	o.wants[int32(releaseID)] = true
+}
+
+func (o *offlineRetriever) SellRecord(releaseID int, price float32, state string) {}
+
+// GetSalePrice makes up a steady price for a release
+func (o *offlineRetriever) GetSalePrice(releaseID int) float32 {
+	return float32(5 + releaseID%30)
+}
+
+func (o *offlineRetriever) GetMasterReleases(masterID int) ([]pbd.Release, error) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	var releases []pbd.Release
+	for _, rel := range o.catalog {
+		if rel.MasterId == int32(masterID) {
+			releases = append(releases, *rel)
+		}
+	}
+	sort.Sort(byReleaseID(releases))
+	return releases, nil
+}
+
+func (o *offlineRetriever) CreateFolder(name string) (pbd.Folder, error) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	o.nextID++
+	folder := pbd.Folder{Id: o.nextID, Name: name}
+	o.folders = append(o.folders, folder)
+	return folder, nil
+}
+
+func (o *offlineRetriever) RenameFolder(folderID int, name string) error {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	for i, f := range o.folders {
+		if f.Id == int32(folderID) {
+			o.folders[i].Name = name
+			return nil
+		}
+	}
+	return fmt.Errorf("Unknown folder %v", folderID)
+}
+
+func (o *offlineRetriever) DeleteFolder(folderID int) error {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	for _, rel := range o.owned {
+		if rel.FolderId == int32(folderID) {
+			return errors.New("Folder is not empty")
+		}
+	}
+	for i, f := range o.folders {
+		if f.Id == int32(folderID) {
+			o.folders = append(o.folders[:i], o.folders[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("Unknown folder %v", folderID)
+}
+
+type byReleaseID []pbd.Release
+
+func (b byReleaseID) Len() int           { return len(b) }
+func (b byReleaseID) Less(i, j int) bool { return b[i].Id < b[j].Id }
+func (b byReleaseID) Swap(i, j int)      { b[i], b[j] = b[j], b[i] }
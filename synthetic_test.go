@@ -0,0 +1,133 @@
+package main
+
+import (
+	"os"
+	"testing"
+
+	"golang.org/x/net/context"
+
+	pb "github.com/brotherlogic/discogssyncer/server"
+	pbd "github.com/brotherlogic/godiscogs"
+)
+
+func countInstances(c *pb.RecordCollection) int {
+	count := 0
+	for _, f := range c.Folders {
+		count += len(f.GetReleases().GetReleases())
+	}
+	return count
+}
+
+func TestGenerateIsStable(t *testing.T) {
+	a := syntheticCollection(500, 12)
+	b := syntheticCollection(500, 12)
+	c := syntheticCollection(500, 13)
+	if a.String() != b.String() {
+		t.Errorf("Same seed has given different collections")
+	}
+	if a.String() == c.String() {
+		t.Errorf("Different seeds have given the same collection")
+	}
+}
+
+func TestGenerateShape(t *testing.T) {
+	s := generate(syntheticConfig{releases: 2000, wants: 200, seed: 1})
+
+	if len(s.collection.Metadata) != 2000 || countInstances(s.collection) < 2000 {
+		t.Fatalf("Wrong size collection: %v metadata, %v instances", len(s.collection.Metadata), countInstances(s.collection))
+	}
+
+	pressings := make(map[int32]int)
+	for _, f := range s.collection.Folders {
+		for _, rel := range f.Releases.Releases {
+			if rel.FolderId != f.Folder.Id || len(rel.Artists) == 0 || len(rel.Formats) == 0 || len(rel.Genres) == 0 {
+				t.Errorf("Badly made release: %v", rel)
+			}
+			if rel.MasterId > 0 {
+				pressings[rel.MasterId]++
+			}
+		}
+	}
+	multiple := 0
+	for _, count := range pressings {
+		if count > 1 {
+			multiple++
+		}
+	}
+	if multiple == 0 {
+		t.Errorf("No masters with more than one pressing")
+	}
+
+	costed := 0
+	for _, md := range s.collection.Metadata {
+		if md.DateAdded < synthStart || md.DateAdded > synthEnd+86400*7 {
+			t.Errorf("Date added is out of range: %v", md)
+		}
+		if md.Cost > 0 {
+			costed++
+		}
+	}
+	if costed < 1800 {
+		t.Errorf("Too few releases have a cost: %v", costed)
+	}
+
+	masters, cached := 0, make(map[int32]bool)
+	for _, r := range s.collection.Cache.Releases {
+		cached[r.Id] = true
+	}
+	for _, w := range s.collection.Wantlist.Want {
+		if w.ReleaseId == 0 {
+			masters++
+		} else if !cached[w.ReleaseId] {
+			t.Errorf("Want has not been cached: %v", w)
+		}
+	}
+	if len(s.collection.Wantlist.Want) != 200 || masters == 0 {
+		t.Errorf("Bad wantlist: %v wants, %v for masters", len(s.collection.Wantlist.Want), masters)
+	}
+}
+
+func TestOfflineSyncer(t *testing.T) {
+	os.RemoveAll(".testoffline")
+	syncer := offlineSyncer(".testoffline", generate(syntheticConfig{releases: 300, wants: 30, seed: 1}))
+	before := countInstances(syncer.collection)
+
+	syncer.SaveCollection()
+	if countInstances(syncer.collection) != before {
+		t.Errorf("Syncing with the offline retriever has changed the collection: %v -> %v", before, countInstances(syncer.collection))
+	}
+
+	rel := syncer.getReleases(100).Releases[0]
+	_, err := syncer.MoveToFolder(context.Background(), &pb.ReleaseMove{Release: &pbd.Release{Id: rel.Id, InstanceId: rel.InstanceId, FolderId: 100}, NewFolderId: 101})
+	if err != nil {
+		t.Fatalf("Error moving: %v", err)
+	}
+	for _, r := range syncer.retr.GetCollection() {
+		if r.InstanceId == rel.InstanceId && r.FolderId != 101 {
+			t.Errorf("Retriever has not seen the move: %v", r)
+		}
+	}
+
+	masters, err := syncer.retr.GetMasterReleases(int(rel.MasterId))
+	if rel.MasterId > 0 && (err != nil || len(masters) == 0) {
+		t.Errorf("No pressings for master %v: %v", rel.MasterId, err)
+	}
+}
+
+func TestStartDevKeepsCollection(t *testing.T) {
+	os.RemoveAll(".testdev")
+	cfg := syntheticConfig{releases: 100, wants: 10, seed: 1}
+	syncer := GetTestSyncer(".testdevunused", true)
+	syncer.startDev(".testdev", cfg)
+	if len(syncer.collection.Metadata) != 100 {
+		t.Fatalf("Dev collection has not been made up: %v", len(syncer.collection.Metadata))
+	}
+	syncer.collection.Metadata[0].Cost = 1
+	syncer.saveCollection()
+
+	restarted := GetTestSyncer(".testdevunused", true)
+	restarted.startDev(".testdev", cfg)
+	if restarted.findMetadata(syncer.collection.Metadata[0].Id).GetCost() != 1 {
+		t.Errorf("Restart has not kept the dev collection")
+	}
+}
@@ -0,0 +1,191 @@
+package main
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/brotherlogic/discogssyncer/server"
+	pbd "github.com/brotherlogic/godiscogs"
+)
+
+type byCount []*pb.TagCount
+
+func (s byCount) Len() int      { return len(s) }
+func (s byCount) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s byCount) Less(i, j int) bool {
+	if s[i].Count != s[j].Count {
+		return s[i].Count > s[j].Count
+	}
+	return s[i].Tag < s[j].Tag
+}
+
+func normaliseTag(tag string) string {
+	return strings.ToLower(strings.TrimSpace(tag))
+}
+
+// releaseTags lists the tags that apply to a given copy of a release
+func releaseTags(md *pb.ReleaseMetadata, rel *pbd.Release) []string {
+	var tags []string
+	for _, tag := range md.GetTags() {
+		if (tag.InstanceId == 0 || tag.InstanceId == rel.InstanceId) && !hasTag(tags, tag.Name) {
+			tags = append(tags, tag.Name)
+		}
+	}
+	return tags
+}
+
+func matchTags(md *pb.ReleaseMetadata, rel *pbd.Release, tags []string) bool {
+	have := releaseTags(md, rel)
+	for _, tag := range tags {
+		if !hasTag(have, normaliseTag(tag)) {
+			return false
+		}
+	}
+	return true
+}
+
+func (syncer *Syncer) tagMetadata(in *pb.TagRequest) (*pb.ReleaseMetadata, error) {
+	if in.Release == nil {
+		return nil, status.Error(codes.InvalidArgument, "No release to tag")
+	}
+	md := syncer.findMetadata(in.Release.Id)
+	if md == nil {
+		return nil, status.Errorf(codes.NotFound, "Unable to locate metadata for %v", in.Release.Id)
+	}
+	return md, nil
+}
+
+// AddTags tags a release, or a single copy of it
+func (syncer *Syncer) AddTags(ctx context.Context, in *pb.TagRequest) (*pb.ReleaseMetadata, error) {
+	t := time.Now()
+	md, err := syncer.tagMetadata(in)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range in.Tags {
+		name = normaliseTag(name)
+		if name == "" {
+			continue
+		}
+		found := false
+		for _, tag := range md.Tags {
+			if tag.Name == name && tag.InstanceId == in.InstanceId {
+				found = true
+			}
+		}
+		if !found {
+			md.Tags = append(md.Tags, &pb.Tag{Name: name, InstanceId: in.InstanceId})
+		}
+	}
+
+	syncer.touch(md.Id)
+	syncer.saveCollection()
+	syncer.LogFunction("AddTags", t)
+	return md, nil
+}
+
+// RemoveTags removes tags from a release, or a single copy of it
+func (syncer *Syncer) RemoveTags(ctx context.Context, in *pb.TagRequest) (*pb.ReleaseMetadata, error) {
+	t := time.Now()
+	md, err := syncer.tagMetadata(in)
+	if err != nil {
+		return nil, err
+	}
+
+	var remove []string
+	for _, name := range in.Tags {
+		remove = append(remove, normaliseTag(name))
+	}
+
+	var kept []*pb.Tag
+	for _, tag := range md.Tags {
+		if tag.InstanceId != in.InstanceId || !hasTag(remove, tag.Name) {
+			kept = append(kept, tag)
+		}
+	}
+	md.Tags = kept
+
+	syncer.touch(md.Id)
+	syncer.saveCollection()
+	syncer.LogFunction("RemoveTags", t)
+	return md, nil
+}
+
+func (syncer *Syncer) countTags() *pb.TagCountList {
+	col, _ := syncer.GetCollection(context.Background(), &pb.Empty{})
+	counts := make(map[string]int32)
+	for _, rel := range col.Releases {
+		for _, tag := range releaseTags(syncer.findMetadata(rel.Id), rel) {
+			counts[tag]++
+		}
+	}
+
+	list := &pb.TagCountList{}
+	for tag, count := range counts {
+		list.Counts = append(list.Counts, &pb.TagCount{Tag: tag, Count: count})
+	}
+	sort.Sort(byCount(list.Counts))
+	return list
+}
+
+// ListTags lists every tag in use along with how many records carry it
+func (syncer *Syncer) ListTags(ctx context.Context, in *pb.Empty) (*pb.TagCountList, error) {
+	t := time.Now()
+	list := syncer.countTags()
+	syncer.LogFunction("ListTags", t)
+	return list, nil
+}
+
+// GetReleasesByTag finds the records carrying all of the given tags
+func (syncer *Syncer) GetReleasesByTag(ctx context.Context, in *pb.TagQuery) (*pb.ReleaseList, error) {
+	t := time.Now()
+	col, _ := syncer.GetCollection(ctx, &pb.Empty{})
+	releases := &pb.ReleaseList{}
+	for _, rel := range col.Releases {
+		if matchTags(syncer.findMetadata(rel.Id), rel, in.Tags) {
+			releases.Releases = append(releases.Releases, rel)
+		}
+	}
+	syncer.LogFunction("GetReleasesByTag", t)
+	return releases, nil
+}
+
+// RenameTag renames a tag across the collection, merging it into the new name if that's already in use
+func (syncer *Syncer) RenameTag(ctx context.Context, in *pb.TagRename) (*pb.TagCountList, error) {
+	t := time.Now()
+	from := normaliseTag(in.From)
+	to := normaliseTag(in.To)
+	if from == "" || to == "" {
+		return nil, status.Error(codes.InvalidArgument, "Tags cannot be renamed to or from nothing")
+	}
+
+	for _, md := range syncer.collection.Metadata {
+		var kept []*pb.Tag
+		for _, tag := range md.Tags {
+			if tag.Name == from {
+				tag.Name = to
+			}
+
+			dupe := false
+			for _, k := range kept {
+				if k.Name == tag.Name && k.InstanceId == tag.InstanceId {
+					dupe = true
+				}
+			}
+			if !dupe {
+				kept = append(kept, tag)
+			}
+		}
+		md.Tags = kept
+	}
+
+	syncer.saveCollection()
+	syncer.LogFunction("RenameTag", t)
+	return syncer.countTags(), nil
+}
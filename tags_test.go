@@ -0,0 +1,107 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+
+	pb "github.com/brotherlogic/discogssyncer/server"
+	pbd "github.com/brotherlogic/godiscogs"
+)
+
+func TestAddAndRemoveTags(t *testing.T) {
+	syncer := GetTestSyncer(".testaddremovetags", true)
+	syncer.saveRelease(&pbd.Release{FolderId: 23, Id: 25, InstanceId: 37}, 23)
+
+	md, err := syncer.AddTags(context.Background(), &pb.TagRequest{Release: &pbd.Release{Id: 25}, Tags: []string{"Jazz ", "jazz", "late night"}})
+	if err != nil {
+		t.Fatalf("Error adding tags: %v", err)
+	}
+	if len(md.Tags) != 2 || md.Tags[0].Name != "jazz" {
+		t.Errorf("Tags have been badly added: %v", md)
+	}
+
+	md, err = syncer.RemoveTags(context.Background(), &pb.TagRequest{Release: &pbd.Release{Id: 25}, Tags: []string{"Late Night"}})
+	if err != nil || len(md.Tags) != 1 {
+		t.Errorf("Tag has not been removed: %v (%v)", md, err)
+	}
+
+	_, err = syncer.AddTags(context.Background(), &pb.TagRequest{Release: &pbd.Release{Id: 99}, Tags: []string{"jazz"}})
+	if errorCode(err) != codes.NotFound {
+		t.Errorf("Tagging a missing release gave %v", err)
+	}
+
+	_, err = syncer.AddTags(context.Background(), &pb.TagRequest{Tags: []string{"jazz"}})
+	if errorCode(err) != codes.InvalidArgument {
+		t.Errorf("Tagging nothing gave %v", err)
+	}
+}
+
+func TestTagsByInstance(t *testing.T) {
+	syncer := GetTestSyncer(".testtagsbyinstance", true)
+	syncer.saveRelease(&pbd.Release{FolderId: 23, Id: 25, InstanceId: 37}, 23)
+	syncer.saveRelease(&pbd.Release{FolderId: 25, Id: 25, InstanceId: 38}, 25)
+	syncer.saveRelease(&pbd.Release{FolderId: 23, Id: 27, InstanceId: 39}, 23)
+	syncer.AddTags(context.Background(), &pb.TagRequest{Release: &pbd.Release{Id: 25}, Tags: []string{"jazz"}})
+	syncer.AddTags(context.Background(), &pb.TagRequest{Release: &pbd.Release{Id: 25}, InstanceId: 38, Tags: []string{"spare"}})
+	syncer.AddTags(context.Background(), &pb.TagRequest{Release: &pbd.Release{Id: 27}, Tags: []string{"jazz"}})
+
+	tags, err := syncer.ListTags(context.Background(), &pb.Empty{})
+	if err != nil {
+		t.Fatalf("Error listing tags: %v", err)
+	}
+	if len(tags.Counts) != 2 || tags.Counts[0].Tag != "jazz" || tags.Counts[0].Count != 3 || tags.Counts[1].Count != 1 {
+		t.Errorf("Tag counts are wrong: %v", tags)
+	}
+
+	rels, err := syncer.GetReleasesByTag(context.Background(), &pb.TagQuery{Tags: []string{"jazz", "spare"}})
+	if err != nil || len(rels.Releases) != 1 || rels.Releases[0].InstanceId != 38 {
+		t.Errorf("Releases by tag are wrong: %v (%v)", rels, err)
+	}
+}
+
+func TestRenameTag(t *testing.T) {
+	syncer := GetTestSyncer(".testrenametag", true)
+	syncer.saveRelease(&pbd.Release{FolderId: 23, Id: 25, InstanceId: 37}, 23)
+	syncer.saveRelease(&pbd.Release{FolderId: 23, Id: 27, InstanceId: 39}, 23)
+	syncer.AddTags(context.Background(), &pb.TagRequest{Release: &pbd.Release{Id: 25}, Tags: []string{"bop", "bebop"}})
+	syncer.AddTags(context.Background(), &pb.TagRequest{Release: &pbd.Release{Id: 27}, Tags: []string{"bop"}})
+
+	tags, err := syncer.RenameTag(context.Background(), &pb.TagRename{From: "bop", To: "bebop"})
+	if err != nil {
+		t.Fatalf("Error renaming tag: %v", err)
+	}
+	if len(tags.Counts) != 1 || tags.Counts[0].Count != 2 {
+		t.Errorf("Tags have not been merged: %v", tags)
+	}
+	if len(syncer.findMetadata(25).Tags) != 1 {
+		t.Errorf("Merged tag has been duplicated: %v", syncer.findMetadata(25))
+	}
+
+	if _, err = syncer.RenameTag(context.Background(), &pb.TagRename{From: "bebop", To: " "}); errorCode(err) != codes.InvalidArgument {
+		t.Errorf("Renaming to nothing gave %v", err)
+	}
+}
+
+func TestTagFilters(t *testing.T) {
+	syncer := GetTestSyncer(".testtagfilters", true)
+	syncer.saveRelease(&pbd.Release{FolderId: 23, Id: 25, InstanceId: 37, Title: "Kind of Blue"}, 23)
+	syncer.saveRelease(&pbd.Release{FolderId: 23, Id: 27, InstanceId: 39, Title: "Blue Train"}, 23)
+	syncer.findMetadata(25).Cost = 1000
+	syncer.findMetadata(25).DateAdded = time.Now().Unix()
+	syncer.findMetadata(27).Cost = 2000
+	syncer.findMetadata(27).DateAdded = time.Now().Unix()
+	syncer.AddTags(context.Background(), &pb.TagRequest{Release: &pbd.Release{Id: 25}, Tags: []string{"gift"}})
+
+	res, err := syncer.Search(context.Background(), &pb.SearchRequest{Query: "blue", Tags: []string{"gift"}})
+	if err != nil || len(res.Releases) != 1 || res.Releases[0].Id != 25 {
+		t.Errorf("Search tag filter has failed: %v (%v)", res, err)
+	}
+
+	spend, err := syncer.GetSpend(context.Background(), &pb.SpendRequest{Tags: []string{"gift"}})
+	if err != nil || spend.TotalSpend != 1000 {
+		t.Errorf("Spend tag filter has failed: %v (%v)", spend, err)
+	}
+}
@@ -0,0 +1,273 @@
+package main
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/brotherlogic/discogssyncer/server"
+	pbd "github.com/brotherlogic/godiscogs"
+)
+
+type wantSorter struct {
+	wants []*pb.Want
+	less  func(a, b *pb.Want) bool
+}
+
+func (s wantSorter) Len() int           { return len(s.wants) }
+func (s wantSorter) Swap(i, j int)      { s.wants[i], s.wants[j] = s.wants[j], s.wants[i] }
+func (s wantSorter) Less(i, j int) bool { return s.less(s.wants[i], s.wants[j]) }
+
+func hasTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// wantField is a field of a want which EditWant can change
+type wantField struct {
+	name  string
+	given func(w *pb.Want) bool
+	set   func(dst, src *pb.Want)
+}
+
+var wantFields = []wantField{
+	{"valued", func(w *pb.Want) bool { return true }, func(dst, src *pb.Want) { dst.Valued = src.Valued }},
+	{"priority", func(w *pb.Want) bool { return w.Priority != 0 }, func(dst, src *pb.Want) { dst.Priority = src.Priority }},
+	{"note", func(w *pb.Want) bool { return len(w.Note) > 0 }, func(dst, src *pb.Want) { dst.Note = src.Note }},
+	{"max_price", func(w *pb.Want) bool { return w.MaxPrice != 0 }, func(dst, src *pb.Want) { dst.MaxPrice = src.MaxPrice }},
+	{"date_added", func(w *pb.Want) bool { return w.DateAdded != 0 }, func(dst, src *pb.Want) { dst.DateAdded = src.DateAdded }},
+	{"tags", func(w *pb.Want) bool { return len(w.Tags) > 0 }, func(dst, src *pb.Want) { dst.Tags = src.Tags }},
+	{"format", func(w *pb.Want) bool { return len(w.Format) > 0 }, func(dst, src *pb.Want) { dst.Format = src.Format }},
+	{"country", func(w *pb.Want) bool { return len(w.Country) > 0 }, func(dst, src *pb.Want) { dst.Country = src.Country }},
+	{"min_year", func(w *pb.Want) bool { return w.MinYear != 0 }, func(dst, src *pb.Want) { dst.MinYear = src.MinYear }},
+	{"max_year", func(w *pb.Want) bool { return w.MaxYear != 0 }, func(dst, src *pb.Want) { dst.MaxYear = src.MaxYear }},
+}
+
+// editedWantFields picks the fields an edit sets: those in the update mask, or the
+// non-zero ones if there's no mask. Valued has always been set by every edit, so it's
+// always picked when there's no mask.
+func editedWantFields(in *pb.Want) ([]wantField, error) {
+	var fields []wantField
+	if len(in.UpdateMask) == 0 {
+		for _, f := range wantFields {
+			if f.given(in) {
+				fields = append(fields, f)
+			}
+		}
+		return fields, nil
+	}
+
+	for _, name := range in.UpdateMask {
+		found := false
+		for _, f := range wantFields {
+			if f.name == name {
+				fields = append(fields, f)
+				found = true
+			}
+		}
+		if !found {
+			return nil, status.Errorf(codes.InvalidArgument, "%v cannot be edited", name)
+		}
+	}
+	return fields, nil
+}
+
+// sameWant matches wants on release, or on master for master wants
+func sameWant(a, b *pb.Want) bool {
+	if a.ReleaseId != 0 || b.ReleaseId != 0 {
+		return a.ReleaseId == b.ReleaseId
+	}
+	return a.MasterId == b.MasterId
+}
+
+func releaseYear(rel *pbd.Release) int32 {
+	if len(rel.Released) < 4 {
+		return 0
+	}
+	year, _ := strconv.Atoi(rel.Released[:4])
+	return int32(year)
+}
+
+// satisfies checks a pressing against the constraints on a master want
+func satisfies(want *pb.Want, rel *pbd.Release) bool {
+	if len(want.Format) > 0 {
+		found := false
+		for _, f := range rel.Formats {
+			if strings.EqualFold(f.Name, want.Format) {
+				found = true
+			}
+			for _, d := range f.Descriptions {
+				if strings.EqualFold(d, want.Format) {
+					found = true
+				}
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if len(want.Country) > 0 && !strings.EqualFold(rel.Country, want.Country) {
+		return false
+	}
+
+	year := releaseYear(rel)
+	if want.MinYear > 0 && (year == 0 || year < want.MinYear) {
+		return false
+	}
+	if want.MaxYear > 0 && (year == 0 || year > want.MaxYear) {
+		return false
+	}
+
+	return true
+}
+
+func matchWant(want *pb.Want, req *pb.WantlistRequest) bool {
+	if want.Priority < req.MinPriority {
+		return false
+	}
+
+	if req.WantedOnly && !want.Wanted {
+		return false
+	}
+
+	for _, tag := range req.Tags {
+		if !hasTag(want.Tags, tag) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// sortWants orders the wants, most wanted, newest or priciest first
+func sortWants(wants []*pb.Want, order pb.WantSort) {
+	var less func(a, b *pb.Want) bool
+	switch order {
+	case pb.WantSort_BY_PRIORITY:
+		less = func(a, b *pb.Want) bool { return a.Priority > b.Priority }
+	case pb.WantSort_BY_DATE_ADDED:
+		less = func(a, b *pb.Want) bool { return a.DateAdded > b.DateAdded }
+	case pb.WantSort_BY_MAX_PRICE:
+		less = func(a, b *pb.Want) bool { return a.MaxPrice > b.MaxPrice }
+	default:
+		return
+	}
+
+	sort.Stable(wantSorter{wants: wants, less: less})
+}
+
+// reconcileWants drops wants for releases which are now in the collection
+func (syncer *Syncer) reconcileWants(owned []*pbd.Release) []*pb.Want {
+	byID := make(map[int32]*pbd.Release)
+	byMaster := make(map[int32]*pbd.Release)
+	for _, rel := range owned {
+		byID[rel.Id] = rel
+		if rel.MasterId != 0 {
+			byMaster[rel.MasterId] = rel
+		}
+	}
+
+	var fulfilled []*pb.Want
+	remaining := make([]*pb.Want, 0)
+	for _, want := range syncer.collection.Wantlist.Want {
+		rel, ok := byID[want.ReleaseId]
+		if !ok && want.MasterId != 0 {
+			for _, pressing := range owned {
+				if pressing.MasterId == want.MasterId && satisfies(want, pressing) {
+					rel, ok = pressing, true
+				}
+			}
+		}
+		if !ok && want.ReleaseId != 0 && syncer.matchWantsByMaster {
+			wanted, err := syncer.getRelease(int(want.ReleaseId))
+			if err == nil && wanted.MasterId != 0 {
+				rel, ok = byMaster[wanted.MasterId]
+			}
+		}
+
+		if !ok {
+			remaining = append(remaining, want)
+			continue
+		}
+
+		if want.ReleaseId != 0 {
+			syncer.retr.RemoveFromWantlist(int(want.ReleaseId))
+			syncer.uncacheRelease(want.ReleaseId)
+		}
+		if md := syncer.findMetadata(rel.Id); md != nil {
+			md.WantFulfilled = time.Now().Unix()
+			md.WantPrice = md.Cost
+		}
+		fulfilled = append(fulfilled, want)
+	}
+
+	syncer.collection.Wantlist.Want = remaining
+	return fulfilled
+}
+
+// ExpandWant puts every suitable pressing of a master want on the wantlist
+func (syncer *Syncer) ExpandWant(ctx context.Context, in *pb.Want) (*pb.Wantlist, error) {
+	var master *pb.Want
+	for _, want := range syncer.collection.Wantlist.Want {
+		if want.ReleaseId == 0 && want.MasterId != 0 && want.MasterId == in.MasterId {
+			master = want
+		}
+	}
+
+	if master == nil {
+		return nil, status.Errorf(codes.NotFound, "Unable to locate want for master %v", in.MasterId)
+	}
+
+	pressings, err := syncer.retr.GetMasterReleases(int(master.MasterId))
+	if err != nil {
+		return nil, err
+	}
+
+	added := &pb.Wantlist{}
+	for i := range pressings {
+		rel := &pressings[i]
+		if !satisfies(master, rel) {
+			continue
+		}
+
+		seen := false
+		for _, want := range syncer.collection.Wantlist.Want {
+			if want.ReleaseId == rel.Id {
+				seen = true
+			}
+		}
+		if seen {
+			continue
+		}
+
+		want := &pb.Want{
+			ReleaseId: rel.Id,
+			MasterId:  master.MasterId,
+			Wanted:    true,
+			Valued:    master.Valued,
+			Priority:  master.Priority,
+			Note:      master.Note,
+			MaxPrice:  master.MaxPrice,
+			Tags:      master.Tags,
+			Format:    master.Format,
+			Country:   master.Country,
+			MinYear:   master.MinYear,
+			MaxYear:   master.MaxYear,
+		}
+		syncer.AddWant(ctx, want)
+		added.Want = append(added.Want, want)
+	}
+
+	syncer.saveCollection()
+	return added, nil
+}
@@ -0,0 +1,217 @@
+package main
+
+import (
+	"testing"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+
+	pb "github.com/brotherlogic/discogssyncer/server"
+	pbd "github.com/brotherlogic/godiscogs"
+)
+
+func TestEditWantDetails(t *testing.T) {
+	syncer := GetTestSyncer(".testeditwantdetails", true)
+	syncer.collection.Wantlist.Want = append(syncer.collection.Wantlist.Want, &pb.Want{ReleaseId: 256, Wanted: true, Note: "Original press only"})
+
+	want, err := syncer.EditWant(context.Background(), &pb.Want{ReleaseId: 256, Priority: 3, MaxPrice: 2500, Tags: []string{"jazz"}})
+	if err != nil {
+		t.Fatalf("Error editing want: %v", err)
+	}
+
+	if want.Priority != 3 || want.MaxPrice != 2500 || len(want.Tags) != 1 || want.Note != "Original press only" {
+		t.Errorf("Want has been badly edited: %v", want)
+	}
+
+	if _, err := syncer.EditWant(context.Background(), &pb.Want{ReleaseId: 257, Priority: 1}); errorCode(err) != codes.NotFound {
+		t.Errorf("Editing a missing want has not failed: %v", err)
+	}
+}
+
+func TestEditWantMask(t *testing.T) {
+	syncer := GetTestSyncer(".testeditwantmask", true)
+	syncer.collection.Wantlist.Want = append(syncer.collection.Wantlist.Want, &pb.Want{ReleaseId: 256, Valued: true, Priority: 4, MaxPrice: 2000, Note: "Original press only"})
+
+	want, err := syncer.EditWant(context.Background(), &pb.Want{ReleaseId: 256, Priority: 1, UpdateMask: []string{"max_price", "note"}})
+	if err != nil {
+		t.Fatalf("Error editing want: %v", err)
+	}
+	if want.MaxPrice != 0 || len(want.Note) > 0 || want.Priority != 4 || !want.Valued {
+		t.Errorf("Mask has not been followed: %v", want)
+	}
+
+	_, err = syncer.EditWant(context.Background(), &pb.Want{ReleaseId: 256, UpdateMask: []string{"release_id"}})
+	if err == nil {
+		t.Errorf("Edit of the release id has not failed")
+	}
+}
+
+func TestAddWantSetsDate(t *testing.T) {
+	syncer := GetTestSyncer(".testaddwantdate", true)
+	syncer.AddWant(context.Background(), &pb.Want{ReleaseId: 66})
+
+	wantlist, err := syncer.GetWantlist(context.Background(), &pb.WantlistRequest{})
+	if err != nil {
+		t.Fatalf("Error getting wantlist: %v", err)
+	}
+
+	if len(wantlist.Want) != 1 || wantlist.Want[0].DateAdded == 0 {
+		t.Errorf("Want has no date added: %v", wantlist)
+	}
+}
+
+func TestFilterWantlist(t *testing.T) {
+	syncer := GetTestSyncer(".testfilterwantlist", true)
+	syncer.collection.Wantlist.Want = append(syncer.collection.Wantlist.Want, &pb.Want{ReleaseId: 1, Priority: 1, Wanted: true, Tags: []string{"jazz"}})
+	syncer.collection.Wantlist.Want = append(syncer.collection.Wantlist.Want, &pb.Want{ReleaseId: 2, Priority: 5, Wanted: true, Tags: []string{"jazz", "blue note"}})
+	syncer.collection.Wantlist.Want = append(syncer.collection.Wantlist.Want, &pb.Want{ReleaseId: 3, Priority: 4, Tags: []string{"jazz"}})
+
+	wantlist, err := syncer.GetWantlist(context.Background(), &pb.WantlistRequest{MinPriority: 2})
+	if err != nil {
+		t.Fatalf("Error getting wantlist: %v", err)
+	}
+	if len(wantlist.Want) != 2 {
+		t.Errorf("Priority filter has failed: %v", wantlist)
+	}
+
+	wantlist, err = syncer.GetWantlist(context.Background(), &pb.WantlistRequest{Tags: []string{"jazz", "blue note"}})
+	if err != nil {
+		t.Fatalf("Error getting wantlist: %v", err)
+	}
+	if len(wantlist.Want) != 1 || wantlist.Want[0].ReleaseId != 2 {
+		t.Errorf("Tag filter has failed: %v", wantlist)
+	}
+
+	wantlist, err = syncer.GetWantlist(context.Background(), &pb.WantlistRequest{WantedOnly: true, Sort: pb.WantSort_BY_PRIORITY})
+	if err != nil {
+		t.Fatalf("Error getting wantlist: %v", err)
+	}
+	if len(wantlist.Want) != 2 || wantlist.Want[0].ReleaseId != 2 {
+		t.Errorf("Wanted filter or sort has failed: %v", wantlist)
+	}
+
+	// Sorting shouldn't reorder the stored wantlist
+	if syncer.collection.Wantlist.Want[0].ReleaseId != 1 {
+		t.Errorf("Stored wantlist has been reordered: %v", syncer.collection.Wantlist)
+	}
+}
+
+func TestCollapseWantlistByPriority(t *testing.T) {
+	syncer := GetTestSyncer(".testcollapsepriority", true)
+	syncer.collection.Wantlist.Want = append(syncer.collection.Wantlist.Want, &pb.Want{ReleaseId: 256, Wanted: true, Valued: true, Priority: 1})
+	syncer.collection.Wantlist.Want = append(syncer.collection.Wantlist.Want, &pb.Want{ReleaseId: 257, Wanted: true, Priority: 4})
+
+	wantlist, err := syncer.CollapseWantlist(context.Background(), &pb.CollapseRequest{PriorityThreshold: 3})
+	if err != nil {
+		t.Fatalf("Error collapsing wantlist: %v", err)
+	}
+
+	if wantlist.Want[0].Wanted || !wantlist.Want[1].Wanted {
+		t.Errorf("Wantlist has not been collapsed by priority: %v", wantlist)
+	}
+}
+
+func TestSyncRemovesFulfilledWants(t *testing.T) {
+	syncer := GetTestSyncer(".testsyncfulfilledwants", true)
+	syncer.AddWant(context.Background(), &pb.Want{ReleaseId: 25, MaxPrice: 1500})
+	syncer.AddWant(context.Background(), &pb.Want{ReleaseId: 999})
+
+	res, err := syncer.SyncWithDiscogs(context.Background(), &pb.Empty{})
+	if err != nil {
+		t.Fatalf("Error syncing: %v", err)
+	}
+
+	if len(res.Fulfilled) != 1 || res.Fulfilled[0].ReleaseId != 25 {
+		t.Errorf("Fulfilled want has not been reported: %v", res)
+	}
+
+	for _, want := range syncer.collection.Wantlist.Want {
+		if want.ReleaseId == 25 {
+			t.Errorf("Fulfilled want is still on the wantlist: %v", syncer.collection.Wantlist)
+		}
+	}
+
+	for _, r := range syncer.collection.Cache.Releases {
+		if r.Id == 25 {
+			t.Errorf("Fulfilled want is still cached as a want: %v", r)
+		}
+	}
+
+	md := syncer.findMetadata(25)
+	if md.WantFulfilled == 0 || md.WantPrice != 0 {
+		t.Errorf("Fulfilment has not been recorded: %v", md)
+	}
+
+	syncer.UpdateMetadata(context.Background(), &pb.MetadataUpdate{Release: &pbd.Release{Id: 25, FolderId: 23}, Update: &pb.ReleaseMetadata{Cost: 1200}})
+	if md.WantPrice != 1200 {
+		t.Errorf("Want price has not been filled in from the cost: %v", md)
+	}
+}
+
+func TestSyncRemovesWantsByMaster(t *testing.T) {
+	syncer := GetTestSyncer(".testsyncwantsbymaster", true)
+	syncer.collection.Wantlist.Want = append(syncer.collection.Wantlist.Want, &pb.Want{ReleaseId: 30, Wanted: true})
+
+	fulfilled := syncer.SaveCollection()
+	if len(fulfilled) != 0 {
+		t.Fatalf("Want has been matched by master without asking: %v", fulfilled)
+	}
+
+	syncer.matchWantsByMaster = true
+	fulfilled = syncer.SaveCollection()
+	if len(fulfilled) != 1 || len(syncer.collection.Wantlist.Want) != 0 {
+		t.Errorf("Want has not been matched by master: %v", fulfilled)
+	}
+
+	if syncer.findMetadata(32).WantFulfilled == 0 {
+		t.Errorf("Fulfilment has not been recorded against the owned pressing: %v", syncer.findMetadata(32))
+	}
+}
+
+func TestMasterWantFulfilledByAnyPressing(t *testing.T) {
+	syncer := GetTestSyncer(".testmasterwantfulfilled", true)
+	syncer.AddWant(context.Background(), &pb.Want{MasterId: 245})
+	syncer.AddWant(context.Background(), &pb.Want{MasterId: 234, Country: "UK"})
+
+	fulfilled := syncer.SaveCollection()
+	if len(fulfilled) != 1 || fulfilled[0].MasterId != 245 {
+		t.Errorf("Master want has been resolved badly: %v", fulfilled)
+	}
+
+	if len(syncer.collection.Wantlist.Want) != 1 || syncer.collection.Wantlist.Want[0].MasterId != 234 {
+		t.Errorf("Constrained master want has been removed: %v", syncer.collection.Wantlist)
+	}
+}
+
+func TestExpandWant(t *testing.T) {
+	syncer := GetTestSyncer(".testexpandwant", true)
+	syncer.AddWant(context.Background(), &pb.Want{MasterId: 234, Country: "us", MaxYear: 2000, Priority: 3})
+
+	added, err := syncer.ExpandWant(context.Background(), &pb.Want{MasterId: 234})
+	if err != nil {
+		t.Fatalf("Error expanding want: %v", err)
+	}
+
+	if len(added.Want) != 1 || added.Want[0].ReleaseId != 400 || added.Want[0].Priority != 3 {
+		t.Errorf("Want has been badly expanded: %v", added)
+	}
+
+	if len(syncer.collection.Wantlist.Want) != 2 {
+		t.Errorf("Expanded want has not been stored: %v", syncer.collection.Wantlist)
+	}
+
+	// Expanding again shouldn't duplicate the pressing
+	added, err = syncer.ExpandWant(context.Background(), &pb.Want{MasterId: 234})
+	if err != nil || len(added.Want) != 0 {
+		t.Errorf("Want has been expanded twice: %v (%v)", added, err)
+	}
+}
+
+func TestExpandUnknownWant(t *testing.T) {
+	syncer := GetTestSyncer(".testexpandunknownwant", true)
+
+	_, err := syncer.ExpandWant(context.Background(), &pb.Want{MasterId: 234})
+	if err == nil {
+		t.Errorf("Expanding a missing want did not fail")
+	}
+}
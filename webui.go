@@ -0,0 +1,437 @@
+package main
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"html/template"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/brotherlogic/discogssyncer/server"
+	pbd "github.com/brotherlogic/godiscogs"
+)
+
+// webUI serves a server rendered view of the collection under /ui/
+type webUI struct {
+	syncer *Syncer
+
+	// csrf is the token of the browser being served, set per request
+	csrf string
+}
+
+// uiPage is what every template is run against
+type uiPage struct {
+	Title string
+	Query string
+	CSRF  string
+	Data  interface{}
+}
+
+// csrfCookie holds a random token per browser which every form has to post back, so
+// forms on other sites can't make changes
+const csrfCookie = "csrf"
+
+// csrfToken returns the browser's token, handing it a new one if it doesn't have one
+func csrfToken(w http.ResponseWriter, r *http.Request) (string, error) {
+	if c, err := r.Cookie(csrfCookie); err == nil && len(c.Value) > 0 {
+		return c.Value, nil
+	}
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", status.Errorf(codes.Internal, "Unable to make a token: %v", err)
+	}
+	token := hex.EncodeToString(b)
+	http.SetCookie(w, &http.Cookie{Name: csrfCookie, Value: token, Path: "/ui/", HttpOnly: true})
+	return token, nil
+}
+
+// checkCSRF makes sure a form has come from one of our pages
+func checkCSRF(r *http.Request) error {
+	c, err := r.Cookie(csrfCookie)
+	if err != nil || len(c.Value) == 0 || subtle.ConstantTimeCompare([]byte(c.Value), []byte(r.PostFormValue(csrfCookie))) != 1 {
+		return status.Error(codes.PermissionDenied, "This form has expired, reload the page and try again")
+	}
+	return nil
+}
+
+var uiFuncs = template.FuncMap{
+	"pounds": func(pence int32) string {
+		return fmt.Sprintf("£%.2f", float64(pence)/100)
+	},
+	"date": func(v int64) string {
+		if v <= 0 {
+			return "-"
+		}
+		return time.Unix(v, 0).Format("2006-01-02")
+	},
+	"artist": func(r *pbd.Release) string {
+		return pbd.GetReleaseArtist(*r)
+	},
+	"formats": func(r *pbd.Release) string {
+		var names []string
+		for _, f := range r.Formats {
+			names = append(names, f.Name)
+		}
+		return strings.Join(names, ", ")
+	},
+	"releaseURL": releaseURL,
+}
+
+// uiTemplates holds each page, parsed together with the shared layout
+var uiTemplates = parseTemplates()
+
+func parseTemplates() map[string]*template.Template {
+	layout := template.Must(template.New("layout").Funcs(uiFuncs).Parse(uiLayout))
+	pages := make(map[string]*template.Template)
+	for name, src := range uiPages {
+		pages[name] = template.Must(template.Must(layout.Clone()).Parse(src))
+	}
+	return pages
+}
+
+func releaseURL(r *pbd.Release) string {
+	if r.InstanceId > 0 {
+		return fmt.Sprintf("/ui/releases/%v?instance=%v", r.Id, r.InstanceId)
+	}
+	return fmt.Sprintf("/ui/releases/%v", r.Id)
+}
+
+func (ui webUI) render(w http.ResponseWriter, code int, name string, page uiPage) error {
+	page.CSRF = ui.csrf
+	buf := &bytes.Buffer{}
+	if err := uiTemplates[name].Execute(buf, page); err != nil {
+		return status.Errorf(codes.Internal, "Unable to render %v: %v", name, err)
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(code)
+	buf.WriteTo(w)
+	return nil
+}
+
+// parsePounds reads a price like 12.50 into pence
+func parsePounds(value string) (int32, error) {
+	f, err := strconv.ParseFloat(strings.TrimPrefix(strings.TrimSpace(value), "£"), 64)
+	if err != nil || f < 0 {
+		return 0, status.Errorf(codes.InvalidArgument, "Bad price: %v", value)
+	}
+	return int32(f*100 + 0.5), nil
+}
+
+type uiRoute struct {
+	method  string
+	pattern string
+	handle  func(ui webUI, w http.ResponseWriter, r *http.Request, params []string) error
+}
+
+var uiRoutes = []uiRoute{
+	{"GET", "/ui", webUI.folders},
+	{"GET", "/ui/folders/{id}", webUI.folder},
+	{"GET", "/ui/releases/{id}", webUI.release},
+	{"POST", "/ui/releases/{id}/move", webUI.move},
+	{"POST", "/ui/releases/{id}/rate", webUI.rate},
+	{"POST", "/ui/releases/{id}/metadata", webUI.edit},
+	{"GET", "/ui/search", webUI.search},
+	{"GET", "/ui/spend", webUI.spend},
+	{"GET", "/ui/wantlist", webUI.wantlist},
+}
+
+func (ui webUI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	token, err := csrfToken(w, r)
+	if err != nil {
+		st, _ := status.FromError(err)
+		ui.render(w, http.StatusInternalServerError, "error", uiPage{Title: "Error", Data: st})
+		return
+	}
+	ui.csrf = token
+
+	var allowed []string
+	for _, rt := range uiRoutes {
+		params, ok := matchPath(rt.pattern, r.URL.Path)
+		if !ok {
+			continue
+		}
+		if rt.method != r.Method {
+			allowed = append(allowed, rt.method)
+			continue
+		}
+
+		if rt.method == "POST" {
+			err = checkCSRF(r)
+		}
+		if err == nil {
+			err = rt.handle(ui, w, r, params)
+		}
+		if err != nil {
+			st, _ := status.FromError(err)
+			ui.render(w, httpCode(st.Code()), "error", uiPage{Title: "Error", Data: st})
+		}
+		return
+	}
+
+	if len(allowed) > 0 {
+		w.Header().Set("Allow", strings.Join(allowed, ", "))
+		ui.render(w, http.StatusMethodNotAllowed, "error", uiPage{Title: "Error", Data: status.New(codes.Unimplemented, r.Method+" is not supported for "+r.URL.Path)})
+		return
+	}
+	ui.render(w, http.StatusNotFound, "error", uiPage{Title: "Error", Data: status.New(codes.NotFound, "Nothing is served at "+r.URL.Path)})
+}
+
+type uiFolder struct {
+	Folder *pbd.Folder
+	Count  int
+}
+
+type byFolderName []uiFolder
+
+func (f byFolderName) Len() int           { return len(f) }
+func (f byFolderName) Less(i, j int) bool { return f[i].Folder.Name < f[j].Folder.Name }
+func (f byFolderName) Swap(i, j int)      { f[i], f[j] = f[j], f[i] }
+
+func (ui webUI) folders(w http.ResponseWriter, r *http.Request, params []string) error {
+	var folders []uiFolder
+	for _, f := range ui.syncer.collection.Folders {
+		folders = append(folders, uiFolder{Folder: f.Folder, Count: len(f.GetReleases().GetReleases())})
+	}
+	sort.Sort(byFolderName(folders))
+	return ui.render(w, http.StatusOK, "folders", uiPage{Title: "Folders", Data: struct {
+		Folders []uiFolder
+		Smart   []*pb.SmartFolder
+	}{folders, ui.syncer.collection.SmartFolders}})
+}
+
+func (ui webUI) folderName(id int32) string {
+	if f := ui.syncer.findFolder(id); f != nil {
+		return f.Folder.Name
+	}
+	if sf := ui.syncer.findSmartFolder(&pbd.Folder{Id: id}); sf != nil {
+		return sf.Folder.Name
+	}
+	return fmt.Sprintf("%v", id)
+}
+
+func (ui webUI) folder(w http.ResponseWriter, r *http.Request, params []string) error {
+	folder := folderSpec(params[0])
+	if !ui.syncer.knownFolder(folder) {
+		return status.Errorf(codes.NotFound, "Unable to locate folder %v", params[0])
+	}
+	records, err := ui.syncer.GetReleasesInFolder(r.Context(), &pb.FolderList{Folders: []*pbd.Folder{folder}})
+	if err != nil {
+		return err
+	}
+
+	name := folder.Name
+	if len(name) == 0 {
+		name = ui.folderName(folder.Id)
+	}
+	return ui.render(w, http.StatusOK, "folder", uiPage{Title: name, Data: records})
+}
+
+func (ui webUI) release(w http.ResponseWriter, r *http.Request, params []string) error {
+	rel, err := ui.syncer.gatewayRelease(r, params[0])
+	if err != nil {
+		return err
+	}
+	metadata, _ := ui.syncer.GetMetadata(r.Context(), rel)
+
+	var copies []*pbd.Release
+	for _, f := range ui.syncer.collection.Folders {
+		for _, c := range f.GetReleases().GetReleases() {
+			if c.Id == rel.Id && c.InstanceId != rel.InstanceId {
+				copies = append(copies, c)
+			}
+		}
+	}
+
+	var folders []uiFolder
+	for _, f := range ui.syncer.collection.Folders {
+		folders = append(folders, uiFolder{Folder: f.Folder})
+	}
+	sort.Sort(byFolderName(folders))
+
+	cost, added := "", ""
+	if metadata.GetCost() > 0 {
+		cost = fmt.Sprintf("%.2f", float64(metadata.Cost)/100)
+	}
+	if metadata.GetDateAdded() > 0 {
+		added = time.Unix(metadata.DateAdded, 0).Format("2006-01-02")
+	}
+
+	return ui.render(w, http.StatusOK, "release", uiPage{Title: rel.Title, Data: struct {
+		Release  *pbd.Release
+		Metadata *pb.ReleaseMetadata
+		Loan     *pb.Loan
+		Folder   string
+		Folders  []uiFolder
+		Copies   []*pbd.Release
+		Ratings  []int32
+		Cost     string
+		Added    string
+	}{rel, metadata, ui.syncer.currentLoan(rel), ui.folderName(rel.FolderId), folders, copies, []int32{0, 1, 2, 3, 4, 5}, cost, added}})
+}
+
+// backToRelease sends the browser back to the release page once a form has gone through
+func (ui webUI) backToRelease(w http.ResponseWriter, r *http.Request, rel *pbd.Release) error {
+	// Moves can lose track of the instance, so fall back to any copy
+	if ui.syncer.ownedRelease(rel.Id, rel.InstanceId) == nil {
+		rel = &pbd.Release{Id: rel.Id}
+	}
+	http.Redirect(w, r, releaseURL(rel), http.StatusSeeOther)
+	return nil
+}
+
+func (ui webUI) move(w http.ResponseWriter, r *http.Request, params []string) error {
+	rel, err := ui.syncer.gatewayRelease(r, params[0])
+	if err != nil {
+		return err
+	}
+	folder, err := parseID(r.FormValue("folder"), "folder")
+	if err != nil {
+		return err
+	}
+	if folder != rel.FolderId {
+		_, err = ui.syncer.MoveToFolder(r.Context(), &pb.ReleaseMove{Release: &pbd.Release{Id: rel.Id, InstanceId: rel.InstanceId, FolderId: rel.FolderId}, NewFolderId: folder})
+		if err != nil {
+			return err
+		}
+	}
+	return ui.backToRelease(w, r, rel)
+}
+
+func (ui webUI) rate(w http.ResponseWriter, r *http.Request, params []string) error {
+	rel, err := ui.syncer.gatewayRelease(r, params[0])
+	if err != nil {
+		return err
+	}
+	rating, err := parseID(r.FormValue("rating"), "rating")
+	if err != nil {
+		return err
+	}
+	if rating < 0 || rating > 5 {
+		return status.Errorf(codes.InvalidArgument, "Ratings run from 0 to 5, not %v", rating)
+	}
+	_, err = ui.syncer.UpdateRating(r.Context(), &pbd.Release{Id: rel.Id, InstanceId: rel.InstanceId, FolderId: rel.FolderId, Rating: rating})
+	if err != nil {
+		return err
+	}
+	return ui.backToRelease(w, r, rel)
+}
+
+func (ui webUI) edit(w http.ResponseWriter, r *http.Request, params []string) error {
+	rel, err := ui.syncer.gatewayRelease(r, params[0])
+	if err != nil {
+		return err
+	}
+
+	update := &pb.ReleaseMetadata{}
+	if current := ui.syncer.findMetadata(rel.Id); current != nil {
+		update.Others = current.Others
+	}
+	if cost := r.FormValue("cost"); len(cost) > 0 {
+		if update.Cost, err = parsePounds(cost); err != nil {
+			return err
+		}
+	}
+	if added := r.FormValue("date_added"); len(added) > 0 {
+		d, err := time.ParseInLocation("2006-01-02", added, time.Local)
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "Bad date: %v", added)
+		}
+		update.DateAdded = d.Unix()
+	}
+
+	_, err = ui.syncer.UpdateMetadata(r.Context(), &pb.MetadataUpdate{Release: rel, Update: update})
+	if err != nil {
+		return err
+	}
+	return ui.backToRelease(w, r, rel)
+}
+
+func (ui webUI) search(w http.ResponseWriter, r *http.Request, params []string) error {
+	query := strings.TrimSpace(r.URL.Query().Get("q"))
+	results := &pb.ReleaseList{}
+	if len(query) > 0 {
+		var err error
+		if results, err = ui.syncer.Search(r.Context(), &pb.SearchRequest{Query: query}); err != nil {
+			return err
+		}
+	}
+	return ui.render(w, http.StatusOK, "search", uiPage{Title: "Search", Query: query, Data: results})
+}
+
+type uiMonth struct {
+	Name  string
+	Count int
+	Spend int32
+}
+
+type bySpendDate []*pb.MetadataUpdate
+
+func (s bySpendDate) Len() int           { return len(s) }
+func (s bySpendDate) Less(i, j int) bool { return s[i].Update.DateAdded < s[j].Update.DateAdded }
+func (s bySpendDate) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+
+func (ui webUI) spend(w http.ResponseWriter, r *http.Request, params []string) error {
+	year, err := queryID(r, "year")
+	if err != nil {
+		return err
+	}
+	if year == 0 {
+		year = int32(time.Now().Year())
+	}
+	spend, err := ui.syncer.GetSpend(r.Context(), &pb.SpendRequest{Year: year})
+	if err != nil {
+		return err
+	}
+
+	months := make([]uiMonth, 12)
+	for i := range months {
+		months[i].Name = time.Month(i + 1).String()
+	}
+	for _, s := range spend.Spends {
+		m := time.Unix(s.Update.DateAdded, 0).Month() - 1
+		months[m].Count++
+		months[m].Spend += spendCost(s.Update)
+	}
+	sort.Sort(bySpendDate(spend.Spends))
+
+	return ui.render(w, http.StatusOK, "spend", uiPage{Title: fmt.Sprintf("Spend in %v", year), Data: struct {
+		Year   int32
+		Total  int32
+		Months []uiMonth
+		Spends []*pb.MetadataUpdate
+	}{year, spend.TotalSpend, months, spend.Spends}})
+}
+
+// knownRelease finds a release we already hold, without going out to discogs
+func (ui webUI) knownRelease(id int32) *pbd.Release {
+	if rel := ui.syncer.ownedRelease(id, 0); rel != nil {
+		return rel
+	}
+	return ui.syncer.cachedRelease(id)
+}
+
+type uiWant struct {
+	Want    *pb.Want
+	Release *pbd.Release
+}
+
+func (ui webUI) wantlist(w http.ResponseWriter, r *http.Request, params []string) error {
+	wants, err := ui.syncer.GetWantlist(r.Context(), &pb.WantlistRequest{Sort: pb.WantSort_BY_PRIORITY})
+	if err != nil {
+		return err
+	}
+	var rows []uiWant
+	for _, want := range wants.Want {
+		rows = append(rows, uiWant{Want: want, Release: ui.knownRelease(want.ReleaseId)})
+	}
+	return ui.render(w, http.StatusOK, "wantlist", uiPage{Title: "Wantlist", Data: rows})
+}
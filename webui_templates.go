@@ -0,0 +1,130 @@
+package main
+
+// uiLayout wraps every page of the web UI; it's kept small enough to work well on a phone
+const uiLayout = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}} - discogssyncer</title>
+<style>
+body { font-family: sans-serif; margin: 0; padding: 0 0.8em 2em; max-width: 50em; }
+nav { display: flex; flex-wrap: wrap; gap: 0.8em; align-items: center; padding: 0.6em 0; border-bottom: 1px solid #ccc; }
+nav form { flex: 1; display: flex; min-width: 12em; }
+nav input[type=search] { flex: 1; }
+table { border-collapse: collapse; width: 100%; }
+td, th { text-align: left; padding: 0.3em 0.4em; border-bottom: 1px solid #eee; vertical-align: top; }
+td.num, th.num { text-align: right; }
+form.edit { margin: 0.6em 0; }
+.error { color: #a00; }
+</style>
+</head>
+<body>
+<nav>
+<a href="/ui/">Folders</a>
+<a href="/ui/wantlist">Wantlist</a>
+<a href="/ui/spend">Spend</a>
+<form action="/ui/search" method="get"><input type="search" name="q" value="{{.Query}}" placeholder="Search"><button>Go</button></form>
+</nav>
+<h1>{{.Title}}</h1>
+{{template "content" .}}
+</body>
+</html>`
+
+// uiPages holds the body of each page, keyed by the name handlers render them with
+var uiPages = map[string]string{
+	"folders": `{{define "content"}}
+<table>
+{{range .Data.Folders}}<tr><td><a href="/ui/folders/{{.Folder.Id}}">{{.Folder.Name}}</a></td><td class="num">{{.Count}}</td></tr>
+{{end}}
+</table>
+{{if .Data.Smart}}<h2>Smart folders</h2>
+<ul>
+{{range .Data.Smart}}<li><a href="/ui/folders/{{.Folder.Id}}">{{.Folder.Name}}</a></li>
+{{end}}
+</ul>{{end}}
+{{end}}`,
+
+	"folder": `{{define "content"}}
+{{with .Data.Records}}<table>
+<tr><th>Artist</th><th>Title</th><th class="num">Rating</th></tr>
+{{range .}}<tr><td>{{artist .Release}}</td><td><a href="{{releaseURL .Release}}">{{.Release.Title}}</a>{{if .Loan}} (lent to {{.Loan.Borrower}}){{end}}</td><td class="num">{{.Release.Rating}}</td></tr>
+{{end}}
+</table>{{else}}<p>Nothing in this folder.</p>{{end}}
+{{end}}`,
+
+	"release": `{{define "content"}}
+{{with .Data}}
+<table>
+<tr><th>Artist</th><td>{{artist .Release}}</td></tr>
+<tr><th>Format</th><td>{{formats .Release}}</td></tr>
+<tr><th>Released</th><td>{{.Release.Released}}</td></tr>
+<tr><th>Folder</th><td><a href="/ui/folders/{{.Release.FolderId}}">{{.Folder}}</a></td></tr>
+<tr><th>Rating</th><td>{{.Release.Rating}}</td></tr>
+{{with .Metadata}}<tr><th>Added</th><td>{{date .DateAdded}}</td></tr>
+<tr><th>Cost</th><td>{{if .Cost}}{{pounds .Cost}}{{else}}-{{end}}</td></tr>
+{{if .Notes}}<tr><th>Notes</th><td>{{.Notes}}</td></tr>{{end}}
+{{if .Tags}}<tr><th>Tags</th><td>{{range $i, $t := .Tags}}{{if $i}}, {{end}}{{$t.Name}}{{end}}</td></tr>{{end}}
+{{range .Custom}}<tr><th>{{.Name}}</th><td>{{.Value}}</td></tr>
+{{end}}{{end}}
+{{with .Loan}}<tr><th>On loan</th><td>to {{.Borrower}} since {{date .Lent}}</td></tr>{{end}}
+</table>
+{{if .Copies}}<p>Other copies: {{range .Copies}}<a href="{{releaseURL .}}">{{.InstanceId}}</a> {{end}}</p>{{end}}
+
+<h2>Edit</h2>
+<form class="edit" method="post" action="/ui/releases/{{.Release.Id}}/move?instance={{.Release.InstanceId}}">
+<input type="hidden" name="csrf" value="{{$.CSRF}}">
+<select name="folder">{{$current := .Release.FolderId}}{{range .Folders}}<option value="{{.Folder.Id}}"{{if eq .Folder.Id $current}} selected{{end}}>{{.Folder.Name}}</option>{{end}}</select>
+<button>Move</button>
+</form>
+<form class="edit" method="post" action="/ui/releases/{{.Release.Id}}/rate?instance={{.Release.InstanceId}}">
+<input type="hidden" name="csrf" value="{{$.CSRF}}">
+<select name="rating">{{$rating := .Release.Rating}}{{range .Ratings}}<option{{if eq . $rating}} selected{{end}}>{{.}}</option>{{end}}</select>
+<button>Rate</button>
+</form>
+<form class="edit" method="post" action="/ui/releases/{{.Release.Id}}/metadata?instance={{.Release.InstanceId}}">
+<input type="hidden" name="csrf" value="{{$.CSRF}}">
+<label>Cost £<input name="cost" value="{{.Cost}}" inputmode="decimal" size="7"></label>
+<label>Added <input type="date" name="date_added" value="{{.Added}}"></label>
+<button>Save</button>
+</form>
+{{end}}
+{{end}}`,
+
+	"search": `{{define "content"}}
+{{if .Query}}{{with .Data.Releases}}<table>
+<tr><th>Artist</th><th>Title</th></tr>
+{{range .}}<tr><td>{{artist .}}</td><td><a href="{{releaseURL .}}">{{.Title}}</a></td></tr>
+{{end}}
+</table>{{else}}<p>Nothing matches {{.Query}}.</p>{{end}}{{end}}
+{{end}}`,
+
+	"spend": `{{define "content"}}
+{{with .Data}}
+<p>Total: {{pounds .Total}}</p>
+<form method="get" action="/ui/spend"><input name="year" value="{{.Year}}" inputmode="numeric" size="5"><button>Show year</button></form>
+<table>
+<tr><th>Month</th><th class="num">Records</th><th class="num">Spend</th></tr>
+{{range .Months}}<tr><td>{{.Name}}</td><td class="num">{{.Count}}</td><td class="num">{{pounds .Spend}}</td></tr>
+{{end}}
+</table>
+{{if .Spends}}<h2>Purchases</h2>
+<table>
+{{range .Spends}}<tr><td>{{date .Update.DateAdded}}</td><td><a href="{{releaseURL .Release}}">{{artist .Release}} - {{.Release.Title}}</a></td><td class="num">{{if .Update.Cost}}{{pounds .Update.Cost}}{{else}}-{{end}}</td></tr>
+{{end}}
+</table>{{end}}
+{{end}}
+{{end}}`,
+
+	"wantlist": `{{define "content"}}
+{{with .Data}}<table>
+<tr><th>Want</th><th class="num">Priority</th><th class="num">Max</th><th>Note</th></tr>
+{{range .}}<tr><td>{{if .Release}}{{artist .Release}} - {{.Release.Title}}{{else if .Want.ReleaseId}}Release {{.Want.ReleaseId}}{{else}}Any pressing of master {{.Want.MasterId}}{{end}}{{if not .Want.Wanted}} (not on discogs){{end}}</td><td class="num">{{.Want.Priority}}</td><td class="num">{{if .Want.MaxPrice}}{{pounds .Want.MaxPrice}}{{else}}-{{end}}</td><td>{{.Want.Note}}</td></tr>
+{{end}}
+</table>{{else}}<p>The wantlist is empty.</p>{{end}}
+{{end}}`,
+
+	"error": `{{define "content"}}
+<p class="error">{{.Data.Message}}</p>
+{{end}}`,
+}
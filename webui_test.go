@@ -0,0 +1,189 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+
+	pb "github.com/brotherlogic/discogssyncer/server"
+)
+
+func uiSyncer(foldername string) *Syncer {
+	os.RemoveAll(foldername)
+	return offlineSyncer(foldername, generate(syntheticConfig{releases: 100, wants: 10, seed: 1}))
+}
+
+// testToken stands in for the token a browser would have been given
+const testToken = "testtoken"
+
+// browse makes a request as a browser we've already handed a token to
+func browse(syncer *Syncer, method, path string, form url.Values) *httptest.ResponseRecorder {
+	if form != nil {
+		form.Set(csrfCookie, testToken)
+	}
+	return browseWithToken(syncer, method, path, form, testToken)
+}
+
+func browseWithToken(syncer *Syncer, method, path string, form url.Values, token string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(method, path, strings.NewReader(form.Encode()))
+	if form != nil {
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+	if len(token) > 0 {
+		r.AddCookie(&http.Cookie{Name: csrfCookie, Value: token})
+	}
+	webUI{syncer: syncer}.ServeHTTP(w, r)
+	return w
+}
+
+func TestUIPages(t *testing.T) {
+	syncer := uiSyncer(".testuipages")
+	rel := syncer.collection.Folders[1].Releases.Releases[0]
+	var tests = []struct {
+		path string
+		want string
+	}{
+		{"/ui/", escaped(syncer.collection.Folders[1].Folder.Name)},
+		{fmt.Sprintf("/ui/folders/%v", rel.FolderId), escaped(rel.Title)},
+		{releaseURL(rel), `name="date_added"`},
+		{"/ui/search?q=" + url.QueryEscape(rel.Title), escaped(releaseURL(rel))},
+		{"/ui/search?q=nothingwillmatchthis", "Nothing matches"},
+		{"/ui/spend?year=2010", "December"},
+		{"/ui/wantlist", "Priority"},
+	}
+
+	for _, test := range tests {
+		w := browse(syncer, "GET", test.path, nil)
+		if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), test.want) {
+			t.Errorf("%v gave %v, missing %v: %v", test.path, w.Code, test.want, w.Body.String())
+		}
+	}
+}
+
+// escaped escapes a string the way html/template will have written it
+func escaped(s string) string {
+	return strings.NewReplacer("&", "&amp;", "'", "&#39;", `"`, "&#34;", "<", "&lt;", ">", "&gt;").Replace(s)
+}
+
+func TestUIForms(t *testing.T) {
+	syncer := uiSyncer(".testuiforms")
+	rel := syncer.collection.Folders[1].Releases.Releases[0]
+	base := fmt.Sprintf("/ui/releases/%v", rel.Id)
+
+	w := browse(syncer, "POST", base+"/metadata", url.Values{"cost": {"12.50"}, "date_added": {"2016-05-04"}})
+	if w.Code != http.StatusSeeOther || w.Header().Get("Location") != releaseURL(rel) {
+		t.Fatalf("Bad edit: %v %v", w.Code, w.Body.String())
+	}
+	md := syncer.findMetadata(rel.Id)
+	if md.Cost != 1250 || time.Unix(md.DateAdded, 0).Format("2006-01-02") != "2016-05-04" {
+		t.Errorf("Edit has not been applied: %v", md)
+	}
+
+	w = browse(syncer, "POST", base+"/rate", url.Values{"rating": {"3"}})
+	if w.Code != http.StatusSeeOther || syncer.ownedRelease(rel.Id, 0).Rating != 3 {
+		t.Errorf("Rating has not been applied: %v %v", w.Code, w.Body.String())
+	}
+
+	dest := syncer.collection.Folders[2].Folder.Id
+	w = browse(syncer, "POST", base+"/move", url.Values{"folder": {fmt.Sprintf("%v", dest)}})
+	if w.Code != http.StatusSeeOther || syncer.ownedRelease(rel.Id, 0).FolderId != dest {
+		t.Errorf("Move has not been applied: %v %v", w.Code, w.Body.String())
+	}
+}
+
+func TestUIFormsNeedToken(t *testing.T) {
+	syncer := uiSyncer(".testuicsrf")
+	rel := syncer.collection.Folders[1].Releases.Releases[0]
+	path := fmt.Sprintf("/ui/releases/%v/rate", rel.Id)
+
+	// A new browser is handed a token, which ends up in the forms
+	w := browseWithToken(syncer, "GET", releaseURL(rel), nil, "")
+	cookies := w.Result().Cookies()
+	if len(cookies) != 1 || cookies[0].Name != csrfCookie || !strings.Contains(w.Body.String(), `name="csrf" value="`+cookies[0].Value+`"`) {
+		t.Fatalf("Token has not been handed out: %v, %v", cookies, w.Body.String())
+	}
+
+	var tests = []struct {
+		form  url.Values
+		token string
+	}{
+		{url.Values{"rating": {"3"}}, ""},
+		{url.Values{"rating": {"3"}}, cookies[0].Value},
+		{url.Values{"rating": {"3"}, "csrf": {cookies[0].Value}}, ""},
+		{url.Values{"rating": {"3"}, "csrf": {"forged"}}, cookies[0].Value},
+	}
+	for _, test := range tests {
+		w = browseWithToken(syncer, "POST", path, test.form, test.token)
+		if w.Code != http.StatusForbidden || syncer.ownedRelease(rel.Id, 0).Rating == 3 {
+			t.Errorf("%v with %q has not been refused: %v %v", test.form, test.token, w.Code, w.Body.String())
+		}
+	}
+
+	w = browseWithToken(syncer, "POST", path, url.Values{"rating": {"3"}, "csrf": {cookies[0].Value}}, cookies[0].Value)
+	if w.Code != http.StatusSeeOther || syncer.ownedRelease(rel.Id, 0).Rating != 3 {
+		t.Errorf("Form with the token has been refused: %v %v", w.Code, w.Body.String())
+	}
+}
+
+func TestUIErrors(t *testing.T) {
+	syncer := uiSyncer(".testuierrors")
+	rel := syncer.collection.Folders[1].Releases.Releases[0]
+	var tests = []struct {
+		method string
+		path   string
+		form   url.Values
+		code   int
+	}{
+		{"GET", "/ui/releases/1", nil, http.StatusNotFound},
+		{"GET", "/ui/folders/12345678", nil, http.StatusNotFound},
+		{"GET", "/ui/nothing", nil, http.StatusNotFound},
+		{"GET", fmt.Sprintf("/ui/releases/%v/rate", rel.Id), nil, http.StatusMethodNotAllowed},
+		{"POST", fmt.Sprintf("/ui/releases/%v/rate", rel.Id), url.Values{"rating": {"7"}}, http.StatusBadRequest},
+		{"POST", fmt.Sprintf("/ui/releases/%v/metadata", rel.Id), url.Values{"cost": {"lots"}}, http.StatusBadRequest},
+		{"POST", fmt.Sprintf("/ui/releases/%v/metadata", rel.Id), url.Values{"date_added": {"yesterday"}}, http.StatusBadRequest},
+		{"POST", fmt.Sprintf("/ui/releases/%v/move", rel.Id), url.Values{"folder": {"12345678"}}, http.StatusNotFound},
+	}
+
+	for _, test := range tests {
+		w := browse(syncer, test.method, test.path, test.form)
+		if w.Code != test.code || !strings.Contains(w.Body.String(), `class="error"`) {
+			t.Errorf("%v %v gave %v, expected %v: %v", test.method, test.path, w.Code, test.code, w.Body.String())
+		}
+	}
+}
+
+func TestUISpendMatchesRPC(t *testing.T) {
+	syncer := uiSyncer(".testuispend")
+	spend, _ := syncer.GetSpend(context.Background(), &pb.SpendRequest{Year: 2010})
+	w := browse(syncer, "GET", "/ui/spend?year=2010", nil)
+	if !strings.Contains(w.Body.String(), fmt.Sprintf("Total: £%.2f", float64(spend.TotalSpend)/100)) {
+		t.Errorf("Spend page does not agree with GetSpend (%v): %v", spend.TotalSpend, w.Body.String())
+	}
+}
+
+func TestParsePounds(t *testing.T) {
+	var tests = []struct {
+		in   string
+		want int32
+	}{
+		{"12.50", 1250},
+		{"£3", 300},
+		{" 0.99 ", 99},
+	}
+	for _, test := range tests {
+		if got, err := parsePounds(test.in); err != nil || got != test.want {
+			t.Errorf("%v parsed as %v, %v", test.in, got, err)
+		}
+	}
+	if _, err := parsePounds("-1"); err == nil {
+		t.Errorf("Negative price has been accepted")
+	}
+}